@@ -6,4 +6,10 @@ var (
     ErrCarNotFound      = errors.New("car not found")
     ErrInvalidTimeRange = errors.New("invalid time range: from must be less than to")
     ErrInvalidCarID     = errors.New("invalid car id")
+    ErrRuleNotFound     = errors.New("violation rule not found")
+    ErrInvalidRuleID    = errors.New("invalid violation rule id")
+    ErrRuleTypeExists   = errors.New("violation rule with this type already exists")
+    ErrGeozoneNotFound  = errors.New("geozone not found")
+    ErrInvalidGeozoneID = errors.New("invalid geozone id")
+    ErrInvalidGeozone   = errors.New("invalid geozone")
 )
\ No newline at end of file
@@ -48,6 +48,52 @@ type CarState struct {
 	Time      int64   `json:"time" db:"timestamp"`
 }
 
+type ViolationRule struct {
+	ID              string `json:"id" db:"id"`
+	Type            string `json:"type" db:"type"`
+	Description     string `json:"description" db:"description"`
+	Condition       string `json:"condition" db:"condition"`
+	Severity        string `json:"severity" db:"severity"`
+	CooldownSeconds int32  `json:"cooldown_seconds" db:"cooldown_seconds"`
+	Enabled         bool   `json:"enabled" db:"enabled"`
+	CreatedAt       int64  `json:"created_at" db:"created_at"`
+	UpdatedAt       int64  `json:"updated_at" db:"updated_at"`
+}
+
+// Refuel: times are unix sec, VolumeLiters is 0 when the model has no fuel norm.
+type Refuel struct {
+	ID           string  `json:"id" db:"id"`
+	CarID        string  `json:"car_id" db:"car_id"`
+	StartedAt    int64   `json:"started_at" db:"started_at"`
+	EndedAt      int64   `json:"ended_at" db:"ended_at"`
+	Lat          float64 `json:"lat" db:"lat"`
+	Lon          float64 `json:"lon" db:"lon"`
+	Odo          int64   `json:"odo" db:"odo"`
+	FuelBefore   float64 `json:"fuel_before" db:"fuel_before"`
+	FuelAfter    float64 `json:"fuel_after" db:"fuel_after"`
+	VolumeLiters float64 `json:"volume_liters" db:"volume_liters"`
+}
+
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Geozone: AllowedFrom and AllowedTo are "HH:MM", both empty means any time.
+type Geozone struct {
+	ID            string     `json:"id" db:"id"`
+	Name          string     `json:"name" db:"name"`
+	Polygon       []GeoPoint `json:"polygon" db:"polygon"`
+	SpeedLimit    int32      `json:"speed_limit" db:"speed_limit"`
+	NoEntry       bool       `json:"no_entry" db:"no_entry"`
+	OperatingArea bool       `json:"operating_area" db:"operating_area"`
+	AllowedFrom   string     `json:"allowed_from" db:"allowed_from"`
+	AllowedTo     string     `json:"allowed_to" db:"allowed_to"`
+	Enabled       bool       `json:"enabled" db:"enabled"`
+	CreatedAt     int64      `json:"created_at" db:"created_at"`
+	UpdatedAt     int64      `json:"updated_at" db:"updated_at"`
+}
+
 type HistoryFilter struct {
 	From      int64
 	To        int64
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jekiti/citydrive/admin/internal/domain"
+	adminpb "github.com/jekiti/citydrive/gen/proto/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ListGeozones(ctx context.Context, req *adminpb.ListGeozonesRequest) (*adminpb.ListGeozonesResponse, error) {
+	log := h.log.With("module", "handler", "function", "ListGeozones")
+	log.Info("received ListGeozones request")
+	zones, err := h.service.ListGeozones(ctx)
+	if err != nil {
+		return nil, geozoneError(log, err)
+	}
+	var resp adminpb.ListGeozonesResponse
+	for _, zone := range zones {
+		resp.Geozones = append(resp.Geozones, geozoneToProto(zone))
+	}
+	return &resp, nil
+}
+
+func (h *Handler) GetGeozone(ctx context.Context, req *adminpb.GetGeozoneRequest) (*adminpb.GetGeozoneResponse, error) {
+	log := h.log.With("module", "handler", "function", "GetGeozone", "zone_id", req.Id)
+	log.Info("received GetGeozone request")
+	zone, err := h.service.GetGeozone(ctx, req.Id)
+	if err != nil {
+		return nil, geozoneError(log, err)
+	}
+	return &adminpb.GetGeozoneResponse{Geozone: geozoneToProto(zone)}, nil
+}
+
+func (h *Handler) CreateGeozone(ctx context.Context, req *adminpb.CreateGeozoneRequest) (*adminpb.CreateGeozoneResponse, error) {
+	log := h.log.With("module", "handler", "function", "CreateGeozone", "name", req.Name)
+	log.Info("received CreateGeozone request")
+	zone, err := h.service.CreateGeozone(ctx, domain.Geozone{
+		Name:          req.Name,
+		Polygon:       pointsFromProto(req.Polygon),
+		SpeedLimit:    req.SpeedLimit,
+		NoEntry:       req.NoEntry,
+		OperatingArea: req.OperatingArea,
+		AllowedFrom:   req.AllowedFrom,
+		AllowedTo:     req.AllowedTo,
+		Enabled:       req.Enabled == nil || *req.Enabled,
+	})
+	if err != nil {
+		return nil, geozoneError(log, err)
+	}
+	return &adminpb.CreateGeozoneResponse{Geozone: geozoneToProto(zone)}, nil
+}
+
+func (h *Handler) UpdateGeozone(ctx context.Context, req *adminpb.UpdateGeozoneRequest) (*adminpb.UpdateGeozoneResponse, error) {
+	log := h.log.With("module", "handler", "function", "UpdateGeozone", "zone_id", req.Id)
+	log.Info("received UpdateGeozone request")
+	zone, err := h.service.UpdateGeozone(ctx, domain.Geozone{
+		ID:            req.Id,
+		Name:          req.Name,
+		Polygon:       pointsFromProto(req.Polygon),
+		SpeedLimit:    req.SpeedLimit,
+		NoEntry:       req.NoEntry,
+		OperatingArea: req.OperatingArea,
+		AllowedFrom:   req.AllowedFrom,
+		AllowedTo:     req.AllowedTo,
+		Enabled:       req.Enabled == nil || *req.Enabled,
+	})
+	if err != nil {
+		return nil, geozoneError(log, err)
+	}
+	return &adminpb.UpdateGeozoneResponse{Geozone: geozoneToProto(zone)}, nil
+}
+
+func (h *Handler) DeleteGeozone(ctx context.Context, req *adminpb.DeleteGeozoneRequest) (*adminpb.DeleteGeozoneResponse, error) {
+	log := h.log.With("module", "handler", "function", "DeleteGeozone", "zone_id", req.Id)
+	log.Info("received DeleteGeozone request")
+	if err := h.service.DeleteGeozone(ctx, req.Id); err != nil {
+		return nil, geozoneError(log, err)
+	}
+	return &adminpb.DeleteGeozoneResponse{}, nil
+}
+
+func geozoneError(log *slog.Logger, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGeozone):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInvalidGeozoneID):
+		return status.Error(codes.InvalidArgument, "invalid geozone id")
+	case errors.Is(err, domain.ErrGeozoneNotFound):
+		return status.Error(codes.NotFound, "geozone not found")
+	default:
+		log.Error("error handling geozone request", "error", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+func geozoneToProto(zone domain.Geozone) *adminpb.Geozone {
+	polygon := make([]*adminpb.GeoPoint, 0, len(zone.Polygon))
+	for _, p := range zone.Polygon {
+		polygon = append(polygon, &adminpb.GeoPoint{Lat: p.Lat, Lon: p.Lon})
+	}
+	return &adminpb.Geozone{
+		Id:            zone.ID,
+		Name:          zone.Name,
+		Polygon:       polygon,
+		SpeedLimit:    zone.SpeedLimit,
+		NoEntry:       zone.NoEntry,
+		OperatingArea: zone.OperatingArea,
+		AllowedFrom:   zone.AllowedFrom,
+		AllowedTo:     zone.AllowedTo,
+		Enabled:       zone.Enabled,
+		CreatedAt:     zone.CreatedAt,
+		UpdatedAt:     zone.UpdatedAt,
+	}
+}
+
+func pointsFromProto(points []*adminpb.GeoPoint) []domain.GeoPoint {
+	polygon := make([]domain.GeoPoint, 0, len(points))
+	for _, p := range points {
+		polygon = append(polygon, domain.GeoPoint{Lat: p.Lat, Lon: p.Lon})
+	}
+	return polygon
+}
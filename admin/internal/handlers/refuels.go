@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jekiti/citydrive/admin/internal/domain"
+	adminpb "github.com/jekiti/citydrive/gen/proto/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ListRefuels(ctx context.Context, req *adminpb.ListRefuelsRequest) (*adminpb.ListRefuelsResponse, error) {
+	log := h.log.With("module", "handler", "function", "ListRefuels", "car_id", req.CarId)
+	log.Info("received ListRefuels request", "from", req.From, "to", req.To)
+	refuels, err := h.service.ListRefuels(ctx, req.CarId, req.From, req.To)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTimeRange):
+			return nil, status.Error(codes.InvalidArgument, "invalid time range: 'from' timestamp is greater than or equal to 'to' timestamp")
+		case errors.Is(err, domain.ErrInvalidCarID):
+			return nil, status.Error(codes.InvalidArgument, "invalid car id")
+		default:
+			log.Error("error fetching refuels", "error", err)
+			return nil, status.Error(codes.Internal, "internal server error")
+		}
+	}
+
+	var resp adminpb.ListRefuelsResponse
+	for _, refuel := range refuels {
+		resp.Refuels = append(resp.Refuels, &adminpb.Refuel{
+			Id:           refuel.ID,
+			CarId:        refuel.CarID,
+			StartedAt:    refuel.StartedAt,
+			EndedAt:      refuel.EndedAt,
+			Lat:          refuel.Lat,
+			Lon:          refuel.Lon,
+			Odo:          refuel.Odo,
+			FuelBefore:   refuel.FuelBefore,
+			FuelAfter:    refuel.FuelAfter,
+			VolumeLiters: refuel.VolumeLiters,
+		})
+	}
+	return &resp, nil
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jekiti/citydrive/admin/internal/domain"
+	adminpb "github.com/jekiti/citydrive/gen/proto/admin"
+	"github.com/jekiti/citydrive/pkg/violationrules"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ListViolationRules(ctx context.Context, req *adminpb.ListViolationRulesRequest) (*adminpb.ListViolationRulesResponse, error) {
+	log := h.log.With("module", "handler", "function", "ListViolationRules")
+	log.Info("received ListViolationRules request")
+	rules, err := h.service.ListViolationRules(ctx)
+	if err != nil {
+		return nil, ruleError(log, err)
+	}
+	var resp adminpb.ListViolationRulesResponse
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, ruleToProto(rule))
+	}
+	return &resp, nil
+}
+
+func (h *Handler) GetViolationRule(ctx context.Context, req *adminpb.GetViolationRuleRequest) (*adminpb.GetViolationRuleResponse, error) {
+	log := h.log.With("module", "handler", "function", "GetViolationRule", "rule_id", req.Id)
+	log.Info("received GetViolationRule request")
+	rule, err := h.service.GetViolationRule(ctx, req.Id)
+	if err != nil {
+		return nil, ruleError(log, err)
+	}
+	return &adminpb.GetViolationRuleResponse{Rule: ruleToProto(rule)}, nil
+}
+
+func (h *Handler) CreateViolationRule(ctx context.Context, req *adminpb.CreateViolationRuleRequest) (*adminpb.CreateViolationRuleResponse, error) {
+	log := h.log.With("module", "handler", "function", "CreateViolationRule", "type", req.Type)
+	log.Info("received CreateViolationRule request")
+	rule, err := h.service.CreateViolationRule(ctx, domain.ViolationRule{
+		Type:            req.Type,
+		Description:     req.Description,
+		Condition:       req.Condition,
+		Severity:        req.Severity,
+		CooldownSeconds: req.CooldownSeconds,
+		Enabled:         req.Enabled == nil || *req.Enabled,
+	})
+	if err != nil {
+		return nil, ruleError(log, err)
+	}
+	return &adminpb.CreateViolationRuleResponse{Rule: ruleToProto(rule)}, nil
+}
+
+func (h *Handler) UpdateViolationRule(ctx context.Context, req *adminpb.UpdateViolationRuleRequest) (*adminpb.UpdateViolationRuleResponse, error) {
+	log := h.log.With("module", "handler", "function", "UpdateViolationRule", "rule_id", req.Id)
+	log.Info("received UpdateViolationRule request")
+	rule, err := h.service.UpdateViolationRule(ctx, domain.ViolationRule{
+		ID:              req.Id,
+		Type:            req.Type,
+		Description:     req.Description,
+		Condition:       req.Condition,
+		Severity:        req.Severity,
+		CooldownSeconds: req.CooldownSeconds,
+		Enabled:         req.Enabled == nil || *req.Enabled,
+	})
+	if err != nil {
+		return nil, ruleError(log, err)
+	}
+	return &adminpb.UpdateViolationRuleResponse{Rule: ruleToProto(rule)}, nil
+}
+
+func (h *Handler) DeleteViolationRule(ctx context.Context, req *adminpb.DeleteViolationRuleRequest) (*adminpb.DeleteViolationRuleResponse, error) {
+	log := h.log.With("module", "handler", "function", "DeleteViolationRule", "rule_id", req.Id)
+	log.Info("received DeleteViolationRule request")
+	if err := h.service.DeleteViolationRule(ctx, req.Id); err != nil {
+		return nil, ruleError(log, err)
+	}
+	return &adminpb.DeleteViolationRuleResponse{}, nil
+}
+
+func ruleError(log *slog.Logger, err error) error {
+	switch {
+	case errors.Is(err, violationrules.ErrInvalidRule):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInvalidRuleID):
+		return status.Error(codes.InvalidArgument, "invalid violation rule id")
+	case errors.Is(err, domain.ErrRuleNotFound):
+		return status.Error(codes.NotFound, "violation rule not found")
+	case errors.Is(err, domain.ErrRuleTypeExists):
+		return status.Error(codes.AlreadyExists, "violation rule with this type already exists")
+	default:
+		log.Error("error handling violation rule request", "error", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+func ruleToProto(rule domain.ViolationRule) *adminpb.ViolationRule {
+	return &adminpb.ViolationRule{
+		Id:              rule.ID,
+		Type:            rule.Type,
+		Description:     rule.Description,
+		Condition:       rule.Condition,
+		Severity:        rule.Severity,
+		CooldownSeconds: rule.CooldownSeconds,
+		Enabled:         rule.Enabled,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
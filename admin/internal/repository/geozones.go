@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/jekiti/citydrive/admin/internal/domain"
+)
+
+const geozoneColumns = `id::text, name, polygon, COALESCE(speed_limit, 0), no_entry, operating_area,
+	COALESCE(to_char(allowed_from, 'HH24:MI'), ''), COALESCE(to_char(allowed_to, 'HH24:MI'), ''), enabled,
+	EXTRACT(EPOCH FROM created_at)::bigint, EXTRACT(EPOCH FROM updated_at)::bigint`
+
+func (r *PostgresRepository) ListGeozones(ctx context.Context) ([]domain.Geozone, error) {
+	log := r.log.With("module", "repository", "function", "ListGeozones")
+	query := `SELECT ` + geozoneColumns + ` FROM citydrive.geozones ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Error("error querying geozones", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := []domain.Geozone{}
+	for rows.Next() {
+		zone, err := scanGeozone(rows)
+		if err != nil {
+			log.Error("error scanning geozone row", "error", err)
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+	return zones, rows.Err()
+}
+
+func (r *PostgresRepository) GetGeozone(ctx context.Context, id string) (domain.Geozone, error) {
+	log := r.log.With("module", "repository", "function", "GetGeozone", "zone_id", id)
+	query := `SELECT ` + geozoneColumns + ` FROM citydrive.geozones WHERE id = $1`
+	zone, err := scanGeozone(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Geozone{}, domain.ErrGeozoneNotFound
+		}
+		log.Error("error getting geozone", "error", err)
+		return domain.Geozone{}, err
+	}
+	return zone, nil
+}
+
+func (r *PostgresRepository) CreateGeozone(ctx context.Context, zone domain.Geozone) (domain.Geozone, error) {
+	log := r.log.With("module", "repository", "function", "CreateGeozone", "name", zone.Name)
+	polygon, err := json.Marshal(zone.Polygon)
+	if err != nil {
+		return domain.Geozone{}, err
+	}
+	query := `INSERT INTO citydrive.geozones
+		(name, polygon, speed_limit, no_entry, operating_area, allowed_from, allowed_to, enabled)
+	VALUES ($1, $2, NULLIF($3, 0), $4, $5, NULLIF($6, '')::time, NULLIF($7, '')::time, $8)
+	RETURNING ` + geozoneColumns
+	created, err := scanGeozone(r.db.QueryRowContext(ctx, query,
+		zone.Name, polygon, zone.SpeedLimit, zone.NoEntry, zone.OperatingArea, zone.AllowedFrom, zone.AllowedTo, zone.Enabled))
+	if err != nil {
+		log.Error("error creating geozone", "error", err)
+		return domain.Geozone{}, err
+	}
+	return created, nil
+}
+
+func (r *PostgresRepository) UpdateGeozone(ctx context.Context, zone domain.Geozone) (domain.Geozone, error) {
+	log := r.log.With("module", "repository", "function", "UpdateGeozone", "zone_id", zone.ID)
+	polygon, err := json.Marshal(zone.Polygon)
+	if err != nil {
+		return domain.Geozone{}, err
+	}
+	query := `UPDATE citydrive.geozones
+	SET name = $2, polygon = $3, speed_limit = NULLIF($4, 0), no_entry = $5, operating_area = $6,
+		allowed_from = NULLIF($7, '')::time, allowed_to = NULLIF($8, '')::time, enabled = $9,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1
+	RETURNING ` + geozoneColumns
+	updated, err := scanGeozone(r.db.QueryRowContext(ctx, query,
+		zone.ID, zone.Name, polygon, zone.SpeedLimit, zone.NoEntry, zone.OperatingArea, zone.AllowedFrom, zone.AllowedTo, zone.Enabled))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Geozone{}, domain.ErrGeozoneNotFound
+		}
+		log.Error("error updating geozone", "error", err)
+		return domain.Geozone{}, err
+	}
+	return updated, nil
+}
+
+func (r *PostgresRepository) DeleteGeozone(ctx context.Context, id string) error {
+	log := r.log.With("module", "repository", "function", "DeleteGeozone", "zone_id", id)
+	result, err := r.db.ExecContext(ctx, `DELETE FROM citydrive.geozones WHERE id = $1`, id)
+	if err != nil {
+		log.Error("error deleting geozone", "error", err)
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrGeozoneNotFound
+	}
+	return nil
+}
+
+func scanGeozone(row rowScanner) (domain.Geozone, error) {
+	var zone domain.Geozone
+	var polygon []byte
+	err := row.Scan(
+		&zone.ID,
+		&zone.Name,
+		&polygon,
+		&zone.SpeedLimit,
+		&zone.NoEntry,
+		&zone.OperatingArea,
+		&zone.AllowedFrom,
+		&zone.AllowedTo,
+		&zone.Enabled,
+		&zone.CreatedAt,
+		&zone.UpdatedAt,
+	)
+	if err != nil {
+		return zone, err
+	}
+	err = json.Unmarshal(polygon, &zone.Polygon)
+	return zone, err
+}
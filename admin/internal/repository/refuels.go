@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jekiti/citydrive/admin/internal/domain"
+)
+
+// ListRefuels returns the refuels started in [from, to], of one car if carID is set.
+func (r *PostgresRepository) ListRefuels(ctx context.Context, carID string, from, to int64) ([]domain.Refuel, error) {
+	log := r.log.With("module", "repository", "function", "ListRefuels", "car_id", carID)
+	query := `SELECT id::text, car_id::text,
+		EXTRACT(EPOCH FROM started_at)::bigint, EXTRACT(EPOCH FROM ended_at)::bigint,
+		lat, lon, odo, fuel_before, fuel_after, COALESCE(volume_liters, 0)
+	FROM citydrive.refuels
+	WHERE started_at >= to_timestamp($1) AND started_at <= to_timestamp($2)
+		AND ($3 = '' OR car_id = NULLIF($3, '')::uuid)
+	ORDER BY started_at`
+	rows, err := r.db.QueryContext(ctx, query, from, to, carID)
+	if err != nil {
+		log.Error("error querying refuels", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	refuels := []domain.Refuel{}
+	for rows.Next() {
+		var refuel domain.Refuel
+		err := rows.Scan(
+			&refuel.ID,
+			&refuel.CarID,
+			&refuel.StartedAt,
+			&refuel.EndedAt,
+			&refuel.Lat,
+			&refuel.Lon,
+			&refuel.Odo,
+			&refuel.FuelBefore,
+			&refuel.FuelAfter,
+			&refuel.VolumeLiters,
+		)
+		if err != nil {
+			log.Error("error scanning refuel row", "error", err)
+			return nil, err
+		}
+		refuels = append(refuels, refuel)
+	}
+	return refuels, rows.Err()
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jekiti/citydrive/admin/internal/domain"
+)
+
+const violationRuleColumns = `id::text, type, description, condition, severity, cooldown_seconds, enabled,
+	EXTRACT(EPOCH FROM created_at)::bigint, EXTRACT(EPOCH FROM updated_at)::bigint`
+
+func (r *PostgresRepository) ListViolationRules(ctx context.Context) ([]domain.ViolationRule, error) {
+	log := r.log.With("module", "repository", "function", "ListViolationRules")
+	query := `SELECT ` + violationRuleColumns + ` FROM citydrive.violation_rules ORDER BY type`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		log.Error("error querying violation rules", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []domain.ViolationRule{}
+	for rows.Next() {
+		rule, err := scanViolationRule(rows)
+		if err != nil {
+			log.Error("error scanning violation rule row", "error", err)
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (r *PostgresRepository) GetViolationRule(ctx context.Context, id string) (domain.ViolationRule, error) {
+	log := r.log.With("module", "repository", "function", "GetViolationRule", "rule_id", id)
+	query := `SELECT ` + violationRuleColumns + ` FROM citydrive.violation_rules WHERE id = $1`
+	rule, err := scanViolationRule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ViolationRule{}, domain.ErrRuleNotFound
+		}
+		log.Error("error getting violation rule", "error", err)
+		return domain.ViolationRule{}, err
+	}
+	return rule, nil
+}
+
+func (r *PostgresRepository) CreateViolationRule(ctx context.Context, rule domain.ViolationRule) (domain.ViolationRule, error) {
+	log := r.log.With("module", "repository", "function", "CreateViolationRule", "type", rule.Type)
+	query := `INSERT INTO citydrive.violation_rules (type, description, condition, severity, cooldown_seconds, enabled)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING ` + violationRuleColumns
+	created, err := scanViolationRule(r.db.QueryRowContext(ctx, query,
+		rule.Type, rule.Description, rule.Condition, rule.Severity, rule.CooldownSeconds, rule.Enabled))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ViolationRule{}, domain.ErrRuleTypeExists
+		}
+		log.Error("error creating violation rule", "error", err)
+		return domain.ViolationRule{}, err
+	}
+	return created, nil
+}
+
+func (r *PostgresRepository) UpdateViolationRule(ctx context.Context, rule domain.ViolationRule) (domain.ViolationRule, error) {
+	log := r.log.With("module", "repository", "function", "UpdateViolationRule", "rule_id", rule.ID)
+	query := `UPDATE citydrive.violation_rules
+	SET type = $2, description = $3, condition = $4, severity = $5, cooldown_seconds = $6, enabled = $7,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1
+	RETURNING ` + violationRuleColumns
+	updated, err := scanViolationRule(r.db.QueryRowContext(ctx, query,
+		rule.ID, rule.Type, rule.Description, rule.Condition, rule.Severity, rule.CooldownSeconds, rule.Enabled))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ViolationRule{}, domain.ErrRuleNotFound
+		}
+		if isUniqueViolation(err) {
+			return domain.ViolationRule{}, domain.ErrRuleTypeExists
+		}
+		log.Error("error updating violation rule", "error", err)
+		return domain.ViolationRule{}, err
+	}
+	return updated, nil
+}
+
+func (r *PostgresRepository) DeleteViolationRule(ctx context.Context, id string) error {
+	log := r.log.With("module", "repository", "function", "DeleteViolationRule", "rule_id", id)
+	result, err := r.db.ExecContext(ctx, `DELETE FROM citydrive.violation_rules WHERE id = $1`, id)
+	if err != nil {
+		log.Error("error deleting violation rule", "error", err)
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrRuleNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanViolationRule(row rowScanner) (domain.ViolationRule, error) {
+	var rule domain.ViolationRule
+	err := row.Scan(
+		&rule.ID,
+		&rule.Type,
+		&rule.Description,
+		&rule.Condition,
+		&rule.Severity,
+		&rule.CooldownSeconds,
+		&rule.Enabled,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	return rule, err
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiti/citydrive/admin/internal/domain"
+)
+
+const (
+	maxGeozoneNameLength = 100
+	maxGeozonePoints     = 1000
+)
+
+func (s *service) ListGeozones(ctx context.Context) ([]domain.Geozone, error) {
+	log := s.log.With("module", "service", "function", "ListGeozones")
+	zones, err := s.repoDB.ListGeozones(ctx)
+	if err != nil {
+		log.Error("error fetching geozones from repository", "error", err)
+		return nil, err
+	}
+	return zones, nil
+}
+
+func (s *service) GetGeozone(ctx context.Context, id string) (domain.Geozone, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return domain.Geozone{}, domain.ErrInvalidGeozoneID
+	}
+	return s.repoDB.GetGeozone(ctx, id)
+}
+
+func (s *service) CreateGeozone(ctx context.Context, zone domain.Geozone) (domain.Geozone, error) {
+	log := s.log.With("module", "service", "function", "CreateGeozone", "name", zone.Name)
+	if err := validateGeozone(zone); err != nil {
+		log.Info("invalid geozone", "error", err)
+		return domain.Geozone{}, err
+	}
+	created, err := s.repoDB.CreateGeozone(ctx, zone)
+	if err != nil {
+		return domain.Geozone{}, err
+	}
+	log.Info("geozone created", "zone_id", created.ID)
+	return created, nil
+}
+
+func (s *service) UpdateGeozone(ctx context.Context, zone domain.Geozone) (domain.Geozone, error) {
+	log := s.log.With("module", "service", "function", "UpdateGeozone", "zone_id", zone.ID)
+	if _, err := uuid.Parse(zone.ID); err != nil {
+		return domain.Geozone{}, domain.ErrInvalidGeozoneID
+	}
+	if err := validateGeozone(zone); err != nil {
+		log.Info("invalid geozone", "error", err)
+		return domain.Geozone{}, err
+	}
+	updated, err := s.repoDB.UpdateGeozone(ctx, zone)
+	if err != nil {
+		return domain.Geozone{}, err
+	}
+	log.Info("geozone updated")
+	return updated, nil
+}
+
+func (s *service) DeleteGeozone(ctx context.Context, id string) error {
+	log := s.log.With("module", "service", "function", "DeleteGeozone", "zone_id", id)
+	if _, err := uuid.Parse(id); err != nil {
+		return domain.ErrInvalidGeozoneID
+	}
+	if err := s.repoDB.DeleteGeozone(ctx, id); err != nil {
+		return err
+	}
+	log.Info("geozone deleted")
+	return nil
+}
+
+func validateGeozone(zone domain.Geozone) error {
+	name := strings.TrimSpace(zone.Name)
+	if name == "" || len(name) > maxGeozoneNameLength {
+		return fmt.Errorf("%w: name is required, at most %d characters", domain.ErrInvalidGeozone, maxGeozoneNameLength)
+	}
+	if len(zone.Polygon) < 3 || len(zone.Polygon) > maxGeozonePoints {
+		return fmt.Errorf("%w: polygon must have from 3 to %d points", domain.ErrInvalidGeozone, maxGeozonePoints)
+	}
+	for _, p := range zone.Polygon {
+		if p.Lat < -90 || p.Lat > 90 || p.Lon < -180 || p.Lon > 180 {
+			return fmt.Errorf("%w: point %v,%v is out of range", domain.ErrInvalidGeozone, p.Lat, p.Lon)
+		}
+	}
+	if zone.SpeedLimit < 0 {
+		return fmt.Errorf("%w: speed limit must not be negative", domain.ErrInvalidGeozone)
+	}
+	if (zone.AllowedFrom == "") != (zone.AllowedTo == "") {
+		return fmt.Errorf("%w: allowed_from and allowed_to must be set together", domain.ErrInvalidGeozone)
+	}
+	for _, hours := range []string{zone.AllowedFrom, zone.AllowedTo} {
+		if hours == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", hours); err != nil {
+			return fmt.Errorf("%w: allowed hours must be HH:MM", domain.ErrInvalidGeozone)
+		}
+	}
+	return nil
+}
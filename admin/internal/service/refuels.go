@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jekiti/citydrive/admin/internal/domain"
+)
+
+func (s *service) ListRefuels(ctx context.Context, carID string, from, to int64) ([]domain.Refuel, error) {
+	log := s.log.With("module", "service", "function", "ListRefuels", "car_id", carID)
+	if from >= to {
+		log.Info("invalid time range", "from", from, "to", to)
+		return nil, domain.ErrInvalidTimeRange
+	}
+	if carID != "" {
+		if _, err := uuid.Parse(carID); err != nil {
+			return nil, domain.ErrInvalidCarID
+		}
+	}
+	refuels, err := s.repoDB.ListRefuels(ctx, carID, from, to)
+	if err != nil {
+		log.Error("error fetching refuels from repository", "error", err)
+		return nil, err
+	}
+	return refuels, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jekiti/citydrive/admin/internal/domain"
+	"github.com/jekiti/citydrive/pkg/violationrules"
+)
+
+func (s *service) ListViolationRules(ctx context.Context) ([]domain.ViolationRule, error) {
+	log := s.log.With("module", "service", "function", "ListViolationRules")
+	rules, err := s.repoDB.ListViolationRules(ctx)
+	if err != nil {
+		log.Error("error fetching violation rules from repository", "error", err)
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (s *service) GetViolationRule(ctx context.Context, id string) (domain.ViolationRule, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return domain.ViolationRule{}, domain.ErrInvalidRuleID
+	}
+	return s.repoDB.GetViolationRule(ctx, id)
+}
+
+func (s *service) CreateViolationRule(ctx context.Context, rule domain.ViolationRule) (domain.ViolationRule, error) {
+	log := s.log.With("module", "service", "function", "CreateViolationRule", "type", rule.Type)
+	if err := validateRule(rule); err != nil {
+		log.Info("invalid violation rule", "error", err)
+		return domain.ViolationRule{}, err
+	}
+	created, err := s.repoDB.CreateViolationRule(ctx, rule)
+	if err != nil {
+		return domain.ViolationRule{}, err
+	}
+	log.Info("violation rule created", "rule_id", created.ID)
+	return created, nil
+}
+
+func (s *service) UpdateViolationRule(ctx context.Context, rule domain.ViolationRule) (domain.ViolationRule, error) {
+	log := s.log.With("module", "service", "function", "UpdateViolationRule", "rule_id", rule.ID)
+	if _, err := uuid.Parse(rule.ID); err != nil {
+		return domain.ViolationRule{}, domain.ErrInvalidRuleID
+	}
+	if err := validateRule(rule); err != nil {
+		log.Info("invalid violation rule", "error", err)
+		return domain.ViolationRule{}, err
+	}
+	updated, err := s.repoDB.UpdateViolationRule(ctx, rule)
+	if err != nil {
+		return domain.ViolationRule{}, err
+	}
+	log.Info("violation rule updated")
+	return updated, nil
+}
+
+func (s *service) DeleteViolationRule(ctx context.Context, id string) error {
+	log := s.log.With("module", "service", "function", "DeleteViolationRule", "rule_id", id)
+	if _, err := uuid.Parse(id); err != nil {
+		return domain.ErrInvalidRuleID
+	}
+	if err := s.repoDB.DeleteViolationRule(ctx, id); err != nil {
+		return err
+	}
+	log.Info("violation rule deleted")
+	return nil
+}
+
+// validateRule compiles the condition the same way telemetry does, so a saved rule always loads.
+func validateRule(rule domain.ViolationRule) error {
+	return violationrules.Validate(&violationrules.Rule{
+		Type:            rule.Type,
+		Condition:       rule.Condition,
+		Severity:        rule.Severity,
+		CooldownSeconds: rule.CooldownSeconds,
+	})
+}
@@ -11,9 +11,20 @@ import (
 
 type Service interface {
 	GetCarHistory(ctx context.Context, carID string, from, to int64) ([]domain.CarState, error)
+	ListRefuels(ctx context.Context, carID string, from, to int64) ([]domain.Refuel, error)
 	GetCarsHistory(ctx context.Context, from, to int64, activated *bool) (map[string][]domain.CarHistoryPoint, error)
 	GetCarsNow(ctx context.Context) ([]domain.CarShort, error)
 	GetCar(ctx context.Context, carID string) (domain.CarDetails, error)
+	ListViolationRules(ctx context.Context) ([]domain.ViolationRule, error)
+	GetViolationRule(ctx context.Context, id string) (domain.ViolationRule, error)
+	CreateViolationRule(ctx context.Context, rule domain.ViolationRule) (domain.ViolationRule, error)
+	UpdateViolationRule(ctx context.Context, rule domain.ViolationRule) (domain.ViolationRule, error)
+	DeleteViolationRule(ctx context.Context, id string) error
+	ListGeozones(ctx context.Context) ([]domain.Geozone, error)
+	GetGeozone(ctx context.Context, id string) (domain.Geozone, error)
+	CreateGeozone(ctx context.Context, zone domain.Geozone) (domain.Geozone, error)
+	UpdateGeozone(ctx context.Context, zone domain.Geozone) (domain.Geozone, error)
+	DeleteGeozone(ctx context.Context, id string) error
 }
 
 type service struct {
@@ -5,7 +5,9 @@ import (
 	"github.com/jekiti/citydrive/api-gateway/internal/config"
 	"github.com/jekiti/citydrive/api-gateway/internal/handler"
 	"github.com/jekiti/citydrive/api-gateway/internal/middleware"
+	"github.com/jekiti/citydrive/api-gateway/internal/repository"
 	"github.com/jekiti/citydrive/api-gateway/internal/service"
+	"github.com/jekiti/citydrive/pkg/jwks"
 	"github.com/jekiti/citydrive/pkg/logger"
 )
 
@@ -51,31 +53,123 @@ func main() {
 	log.Info("AdminClient created successful")
 	defer adminClient.Close()
 
+	revocations, err := repository.NewRedisRevocationRepository(&cfg.Redis)
+	if err != nil {
+		log.Error("failed to create revocation repository:", "error", err)
+		panic("revocation repository failed")
+	}
+	log.Info("RevocationRepository created successful")
+	defer revocations.Close()
+
+	rateLimiter, err := repository.NewRedisRateLimiter(&cfg.Redis)
+	if err != nil {
+		log.Error("failed to create rate limiter:", "error", err)
+		panic("rate limiter failed")
+	}
+	log.Info("RateLimiter created successful")
+	defer rateLimiter.Close()
+
+	userKeyfunc := middleware.HMACKeyfunc(cfg.JWT.SecretKey)
+	carKeyfunc := middleware.HMACKeyfunc(cfg.JWT.CarSecretKey)
+	if cfg.JWT.Algorithm != "HS256" {
+		jwksClient := jwks.NewClient(cfg.JWT.JWKSURL, cfg.JWT.JWKSCacheTTL)
+		userKeyfunc = jwksClient.Keyfunc
+		carKeyfunc = jwksClient.Keyfunc
+		log.Info("JWKSClient created successful", "url", cfg.JWT.JWKSURL, "alg", cfg.JWT.Algorithm)
+	}
+
+	userOrAPIKey := middleware.RequireAuthOrAPIKey(userKeyfunc, revocations, authClient, rateLimiter)
+
 	carHandler := handler.NewTelemetryHandler(telemetryClient)
 	authHandler := handler.NewAuthHandler(authClient)
 	adminHandler := handler.NewAdminHandler(adminClient)
 	router := gin.Default()
+	// ClientIP feeds the login limiter and last_used_ip of API keys, a client
+	// must not be able to pick it with X-Forwarded-For
+	if err := router.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		log.Error("invalid GATEWAY_TRUSTED_PROXIES:", "error", err)
+		panic("trusted proxies failed")
+	}
 
 	router.Use(middleware.TracingMiddleware(cfg.Tracing.HeaderName))
 
 	carInfoGroup := router.Group("/api/v1")
 	{
-		carInfoGroup.Use(middleware.RequireCarAuth(cfg.JWT.CarSecretKey))
+		carInfoGroup.Use(middleware.RequireCarAuth(carKeyfunc, revocations))
 		carInfoGroup.PUT("/car-info", carHandler.PutCarInfo)
+		carInfoGroup.PUT("/car-info/batch", carHandler.PutCarInfoBatch)
+		carInfoGroup.GET("/car-info/stream", carHandler.StreamCarInfo)
 	}
 	authGroup := router.Group("/v1/user")
 	{
 		authGroup.POST("/login", authHandler.Login)
-		authGroup.POST("/register", authHandler.Register)
+		authGroup.POST("/register", middleware.OptionalAuth(userKeyfunc, revocations), authHandler.Register)
+		authGroup.POST("/refresh", authHandler.Refresh)
+		authGroup.POST("/password/reset", authHandler.RequestPasswordReset)
+		authGroup.POST("/password/reset/confirm", authHandler.ConfirmPasswordReset)
+		authGroup.POST("/mfa/verify", authHandler.VerifyMFA)
+	}
+
+	sessionGroup := router.Group("/v1/user")
+	{
+		sessionGroup.Use(middleware.RequireAuth(userKeyfunc, revocations))
+		sessionGroup.POST("/logout", authHandler.Logout)
+		sessionGroup.GET("/sessions", authHandler.ListSessions)
+		sessionGroup.DELETE("/sessions/:id", authHandler.RevokeSession)
+		sessionGroup.POST("/password", authHandler.ChangePassword)
+		sessionGroup.POST("/totp/enroll", authHandler.EnrollTOTP)
+		sessionGroup.POST("/totp/activate", authHandler.ActivateTOTP)
+	}
+
+	usersGroup := router.Group("/v1/users")
+	{
+		usersGroup.Use(userOrAPIKey, middleware.RequirePermission(middleware.PermUsersManage))
+		usersGroup.POST("/unlock", authHandler.UnlockUser)
+		usersGroup.GET("", authHandler.ListUsers)
+		usersGroup.GET("/:id", authHandler.GetUser)
+		usersGroup.PATCH("/:id", authHandler.UpdateUser)
+		usersGroup.POST("/:id/disable", authHandler.DisableUser)
+		usersGroup.DELETE("/:id", authHandler.DeleteUser)
+	}
+
+	apiKeysGroup := router.Group("/v1/api-keys")
+	{
+		apiKeysGroup.Use(middleware.RequireAuth(userKeyfunc, revocations), middleware.RequirePermission(middleware.PermAPIKeysManage))
+		apiKeysGroup.POST("", authHandler.CreateAPIKey)
+		apiKeysGroup.GET("", authHandler.ListAPIKeys)
+		apiKeysGroup.DELETE("/:id", authHandler.RevokeAPIKey)
 	}
 
 	adminGroup := router.Group("/api/v1/cars")
 	{
-		adminGroup.Use(middleware.RequireAuth(cfg.JWT.SecretKey))
-		adminGroup.GET("/now", adminHandler.GetCarsNow)
-		adminGroup.GET("/:id", adminHandler.GetCar)
-		adminGroup.GET("/history", adminHandler.GetCarsHistory)
-		adminGroup.GET("/:id/history", adminHandler.GetCarHistory)
+		adminGroup.Use(userOrAPIKey)
+		adminGroup.GET("/now", middleware.RequirePermission(middleware.PermCarsNowRead), adminHandler.GetCarsNow)
+		adminGroup.GET("/:id", middleware.RequirePermission(middleware.PermCarsDetailsRead), adminHandler.GetCar)
+		adminGroup.GET("/history", middleware.RequirePermission(middleware.PermCarsHistoryRead), adminHandler.GetCarsHistory)
+		adminGroup.GET("/:id/history", middleware.RequirePermission(middleware.PermCarsHistoryRead), adminHandler.GetCarHistory)
+		adminGroup.GET("/refuels", middleware.RequirePermission(middleware.PermCarsHistoryRead), adminHandler.ListRefuels)
+		adminGroup.POST("/:id/token", middleware.RequirePermission(middleware.PermCarsTokensManage), authHandler.IssueCarToken)
+		adminGroup.DELETE("/:id/token", middleware.RequirePermission(middleware.PermCarsTokensManage), authHandler.RevokeCarToken)
+	}
+
+	rulesGroup := router.Group("/api/v1/violation-rules")
+	{
+		rulesGroup.Use(userOrAPIKey, middleware.RequirePermission(middleware.PermViolationRulesManage))
+		rulesGroup.GET("", adminHandler.ListViolationRules)
+		rulesGroup.POST("", adminHandler.CreateViolationRule)
+		rulesGroup.GET("/:id", adminHandler.GetViolationRule)
+		rulesGroup.PUT("/:id", adminHandler.UpdateViolationRule)
+		rulesGroup.DELETE("/:id", adminHandler.DeleteViolationRule)
+	}
+
+	geozonesGroup := router.Group("/api/v1/geozones")
+	{
+		geozonesGroup.Use(userOrAPIKey, middleware.RequirePermission(middleware.PermGeozonesManage))
+		geozonesGroup.GET("", adminHandler.ListGeozones)
+		geozonesGroup.POST("", adminHandler.CreateGeozone)
+		geozonesGroup.GET("/:id", adminHandler.GetGeozone)
+		geozonesGroup.PUT("/:id", adminHandler.UpdateGeozone)
+		geozonesGroup.DELETE("/:id", adminHandler.DeleteGeozone)
 	}
 
 	router.GET("/health", func(c *gin.Context) {
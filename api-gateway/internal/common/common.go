@@ -2,9 +2,13 @@ package common
 
 import (
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
 )
 
 func Response(c *gin.Context, httpCode int, code, description, err string) {
@@ -39,3 +43,50 @@ func GetTraceID(c *gin.Context) string {
 	}
 	return ""
 }
+
+func GetUserID(c *gin.Context) (int64, bool) {
+	sub, exists := c.Get("user_id")
+	if !exists {
+		return 0, false
+	}
+	subString, ok := sub.(string)
+	if !ok || !strings.HasPrefix(subString, "user:") {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(strings.TrimPrefix(subString, "user:"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func RetryAfter(err error) (time.Duration, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+			return info.RetryDelay.AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// ValidationResponse is a 400 Response with the field violations the service sent as BadRequest details.
+func ValidationResponse(c *gin.Context, code, description string, err error) {
+	body := gin.H{
+		"error_code":        code,
+		"error_description": description,
+		"trace_id":          GetTraceID(c),
+		"error":             err.Error(),
+	}
+	var fields []gin.H
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, v := range badRequest.FieldViolations {
+				fields = append(fields, gin.H{"field": v.Field, "description": v.Description})
+			}
+		}
+	}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+	c.JSON(400, body)
+}
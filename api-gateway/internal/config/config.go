@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,6 +13,7 @@ import (
 type GatewayConfig struct {
 	HTTP    HTTPConfig
 	GRPC    GRPCConfig
+	Redis   RedisConfig
 	JWT     JWTConfig
 	App     AppConfig
 	Tracing TracingConfig
@@ -23,6 +25,9 @@ type HTTPConfig struct {
 	ReadHeaderTimeout time.Duration
 	WriteTimeout      time.Duration
 	IdleTimeout       time.Duration
+	// addresses or CIDRs of the proxies in front of the gateway, only they may set
+	// X-Forwarded-For; empty means the client IP is the address of the connection
+	TrustedProxies []string
 }
 
 type GRPCConfig struct {
@@ -33,9 +38,20 @@ type GRPCConfig struct {
 	MaxCallRecvMsgSize int
 }
 
+type RedisConfig struct {
+	Host        string
+	Port        string
+	Password    string
+	DB          int
+	ReadTimeout time.Duration
+}
+
 type JWTConfig struct {
-	SecretKey string
+	Algorithm    string
+	SecretKey    string
 	CarSecretKey string
+	JWKSURL      string
+	JWKSCacheTTL time.Duration
 }
 
 type AppConfig struct {
@@ -56,6 +72,7 @@ func LoadGatewayConfig() *GatewayConfig {
 			ReadHeaderTimeout: getDurationDefault("GATEWAY_READ_HEADER_TIMEOUT", "10s"),
 			WriteTimeout:      getDurationDefault("GATEWAY_WRITE_TIMEOUT", "15s"),
 			IdleTimeout:       getDurationDefault("GATEWAY_IDLE_TIMEOUT", "60s"),
+			TrustedProxies:    getSliceDefault("GATEWAY_TRUSTED_PROXIES", nil),
 		},
 		GRPC: GRPCConfig{
 			AuthAddr:           mustGet("AUTH_GRPC_ADDR"),
@@ -64,9 +81,19 @@ func LoadGatewayConfig() *GatewayConfig {
 			DialTimeout:        getDurationDefault("GRPC_DIAL_TIMEOUT", "5s"),
 			MaxCallRecvMsgSize: getIntDefault("GRPC_MAX_RECV_MSG_SIZE", 4194304),
 		},
+		Redis: RedisConfig{
+			Host:        getDefault("REDIS_HOST", "localhost"),
+			Port:        getDefault("REDIS_PORT", "6379"),
+			Password:    getDefault("REDIS_PASSWORD", ""),
+			DB:          getIntDefault("REDIS_DB", 0),
+			ReadTimeout: getDurationDefault("REDIS_READ_TIMEOUT", "3s"),
+		},
 		JWT: JWTConfig{
-			SecretKey: mustGet("JWT_SECRET_KEY"),
-			CarSecretKey: mustGet("JWT_CAR_SECRET_KEY"),
+			Algorithm:    getDefault("JWT_ALG", "HS256"),
+			SecretKey:    getDefault("JWT_SECRET_KEY", ""),
+			CarSecretKey: getDefault("JWT_CAR_SECRET_KEY", ""),
+			JWKSURL:      getDefault("AUTH_JWKS_URL", ""),
+			JWKSCacheTTL: getDurationDefault("JWKS_CACHE_TTL", "5m"),
 		},
 		App: AppConfig{
 			Env:      getDefault("ENV", "development"),
@@ -79,11 +106,20 @@ func LoadGatewayConfig() *GatewayConfig {
 }
 
 func (c *GatewayConfig) Validate() error {
-	if c.JWT.SecretKey == "" {
-		log.Fatal("JWT_SECRET_KEY is required")
-	}
-	if c.JWT.CarSecretKey == "" {
-		log.Fatal("JWT_SECRET_KEY is required")
+	switch c.JWT.Algorithm {
+	case "HS256":
+		if c.JWT.SecretKey == "" {
+			log.Fatal("JWT_SECRET_KEY is required")
+		}
+		if c.JWT.CarSecretKey == "" {
+			log.Fatal("JWT_CAR_SECRET_KEY is required")
+		}
+	case "RS256", "EdDSA":
+		if c.JWT.JWKSURL == "" {
+			log.Fatalf("AUTH_JWKS_URL is required for %s", c.JWT.Algorithm)
+		}
+	default:
+		log.Fatalf("unsupported JWT_ALG: %s", c.JWT.Algorithm)
 	}
 	return nil
 }
@@ -158,3 +194,17 @@ func getBoolDefault(key string, def bool) bool {
 	}
 	return b
 }
+
+func getSliceDefault(key string, def []string) []string {
+	s := os.Getenv(key)
+	if s == "" {
+		return def
+	}
+	var result []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
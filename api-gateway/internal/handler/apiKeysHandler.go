@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	authpb "github.com/jekiti/citydrive/gen/proto/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *AuthHandler) CreateAPIKey(c *gin.Context) {
+	var req model.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+	userID, ok := common.GetUserID(c)
+	if !ok {
+		common.Response(c, 401, "INVALID_CLAIMS", "Invalid token subject", "")
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.CreateAPIKey(ctx, traceID, &authpb.CreateAPIKeyRequest{
+		Name:        req.Name,
+		Permissions: req.Permissions,
+		RateLimit:   req.RateLimit,
+		ExpiresIn:   req.ExpiresIn,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		apiKeyManagementError(c, err)
+		return
+	}
+	c.JSON(201, model.CreateAPIKeyResponse{
+		APIKey: resp.ApiKey,
+		Key:    toAPIKeyResponse(resp.Key),
+	})
+}
+
+func (h *AuthHandler) ListAPIKeys(c *gin.Context) {
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.ListAPIKeys(ctx, traceID, &authpb.ListAPIKeysRequest{})
+	if err != nil {
+		apiKeyManagementError(c, err)
+		return
+	}
+
+	keys := make([]model.APIKeyResponse, len(resp.Keys))
+	for i, key := range resp.Keys {
+		keys[i] = toAPIKeyResponse(key)
+	}
+	c.JSON(200, model.ListAPIKeysResponse{Keys: keys})
+}
+
+func (h *AuthHandler) RevokeAPIKey(c *gin.Context) {
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.RevokeAPIKey(ctx, traceID, &authpb.RevokeAPIKeyRequest{Id: c.Param("id")})
+	if err != nil {
+		apiKeyManagementError(c, err)
+		return
+	}
+	c.JSON(200, toAPIKeyResponse(resp))
+}
+
+func apiKeyManagementError(c *gin.Context, err error) {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+	case codes.DeadlineExceeded:
+		common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+	case codes.InvalidArgument:
+		common.Response(c, 400, "VALIDATION_FAILED", "Invalid api key data", err.Error())
+	case codes.PermissionDenied:
+		common.Response(c, 403, "INSUFFICIENT_PERMISSIONS", "Key permissions must be a subset of your own", err.Error())
+	case codes.NotFound:
+		common.Response(c, 404, "API_KEY_NOT_FOUND", "API key not found", err.Error())
+	default:
+		common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+	}
+}
+
+func toAPIKeyResponse(key *authpb.ApiKey) model.APIKeyResponse {
+	return model.APIKeyResponse{
+		ID:          key.Id,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Permissions: key.Permissions,
+		RateLimit:   key.RateLimit,
+		CreatedBy:   key.CreatedBy,
+		CreatedAt:   key.CreatedAt,
+		ExpiresAt:   key.ExpiresAt,
+		LastUsedAt:  key.LastUsedAt,
+		LastUsedIP:  key.LastUsedIp,
+		RevokedAt:   key.RevokedAt,
+	}
+}
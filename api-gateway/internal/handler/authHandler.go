@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"math"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/middleware"
 	"github.com/jekiti/citydrive/api-gateway/internal/model"
 	"github.com/jekiti/citydrive/api-gateway/internal/service"
 	authpb "github.com/jekiti/citydrive/gen/proto/auth"
@@ -30,6 +34,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	resp, err := h.authClient.Login(ctx, traceID, &authpb.LoginRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		ClientIp: c.ClientIP(),
 	})
 	if err != nil {
 		switch status.Code(err) {
@@ -40,10 +45,117 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
 			return
 		case codes.InvalidArgument:
-			common.Response(c, 400, "INVALID_DATA", "Invalid Auth data", err.Error())
+			common.Response(c, 400, "VALIDATION_FAILED", "Invalid login data", err.Error())
+			return
+		case codes.Unauthenticated:
+			common.Response(c, 401, "INVALID_CREDENTIALS", "Invalid email or password", err.Error())
 			return
 		case codes.PermissionDenied:
-			common.Response(c, 403, "PERMISSION_DENIED", "Access denied", err.Error())
+			common.Response(c, 403, "USER_DISABLED", "User is disabled", err.Error())
+			return
+		case codes.ResourceExhausted:
+			if retryAfter, ok := common.RetryAfter(err); ok {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+			common.Response(c, 429, "TOO_MANY_ATTEMPTS", "Too many login attempts, try again later", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	if resp.MfaRequired {
+		c.JSON(200, model.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    resp.MfaToken,
+			ExpiresIn:   resp.ExpiresIn,
+		})
+		return
+	}
+	c.JSON(200, model.TokenResponse{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresIn:    resp.ExpiresIn,
+	})
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req model.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+	traceID := common.GetTraceID(c)
+
+	ctx := c.Request.Context()
+	resp, err := h.authClient.Refresh(ctx, traceID, &authpb.RefreshRequest{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.Unauthenticated:
+			common.Response(c, 401, "INVALID_REFRESH_TOKEN", "Refresh token is invalid, expired or revoked", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	c.JSON(200, model.TokenResponse{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresIn:    resp.ExpiresIn,
+	})
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	sessionID, _ := c.Get("session_id")
+	sessionIDString, _ := sessionID.(string)
+	if sessionIDString == "" {
+		common.Response(c, 400, "NO_SESSION", "Token is not bound to a session", "")
+		return
+	}
+	h.revokeSession(c, sessionIDString)
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	sessionID := c.Param("id")
+	if sessionID == "" {
+		common.Response(c, 400, "INVALID_DATA", "Session id is required", "")
+		return
+	}
+	h.revokeSession(c, sessionID)
+}
+
+func (h *AuthHandler) revokeSession(c *gin.Context, sessionID string) {
+	userID, ok := common.GetUserID(c)
+	if !ok {
+		common.Response(c, 401, "INVALID_CLAIMS", "Invalid token subject", "")
+		return
+	}
+	traceID := common.GetTraceID(c)
+
+	ctx := c.Request.Context()
+	_, err := h.authClient.Logout(ctx, traceID, &authpb.LogoutRequest{
+		UserId:    userID,
+		SessionId: sessionID,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.NotFound:
+			common.Response(c, 404, "SESSION_NOT_FOUND", "Session not found", err.Error())
 			return
 		default:
 			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
@@ -51,10 +163,49 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		}
 	}
 	c.JSON(200, gin.H{
-		"access_token": resp.AccessToken,
+		"session_id": sessionID,
+		"revoked":    true,
 	})
 }
 
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, ok := common.GetUserID(c)
+	if !ok {
+		common.Response(c, 401, "INVALID_CLAIMS", "Invalid token subject", "")
+		return
+	}
+	traceID := common.GetTraceID(c)
+
+	ctx := c.Request.Context()
+	resp, err := h.authClient.ListSessions(ctx, traceID, &authpb.ListSessionsRequest{UserId: userID})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+
+	currentSessionID, _ := c.Get("session_id")
+	sessions := make([]model.SessionResponse, len(resp.Sessions))
+	for i, session := range resp.Sessions {
+		sessions[i] = model.SessionResponse{
+			ID:         session.Id,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.Id == currentSessionID,
+		}
+	}
+	c.JSON(200, model.ListSessionsResponse{Sessions: sessions})
+}
+
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req model.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -62,6 +213,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	if req.Role != "" && !middleware.HasPermission(c, middleware.PermUsersManage) {
+		common.Response(c, 403, "INSUFFICIENT_PERMISSIONS", "Permission users.manage is required to assign a role", "")
+		return
+	}
+
 	traceID := common.GetTraceID(c)
 	ctx := c.Request.Context()
 	resp, err := h.authClient.Register(ctx, traceID, &authpb.RegisterRequest{
@@ -69,6 +225,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Name:       req.Name,
 		Surname:    req.Surname,
 		Department: req.Department,
+		Role:       req.Role,
 	})
 	if err != nil {
 		switch status.Code(err) {
@@ -79,7 +236,10 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
 			return
 		case codes.InvalidArgument:
-			common.Response(c, 400, "INVALID_DATA", "Invalid Auth data", err.Error())
+			common.Response(c, 400, "VALIDATION_FAILED", "Invalid registration data", err.Error())
+			return
+		case codes.AlreadyExists:
+			common.Response(c, 409, "EMAIL_TAKEN", "Email is already registered", err.Error())
 			return
 		case codes.PermissionDenied:
 			common.Response(c, 403, "PERMISSION_DENIED", "Access denied", err.Error())
@@ -92,5 +252,115 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	c.JSON(201, gin.H{
 		"id":       resp.Id,
 		"password": resp.Password,
+		"role":     resp.Role,
+	})
+}
+
+func (h *AuthHandler) IssueCarToken(c *gin.Context) {
+	carID := c.Param("id")
+	if carID == "" {
+		common.Response(c, 400, "INVALID_DATA", "CarID is required", "")
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.IssueCarToken(ctx, traceID, &authpb.IssueCarTokenRequest{CarId: carID})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.InvalidArgument:
+			common.Response(c, 400, "INVALID_DATA", "Invalid car id", err.Error())
+			return
+		case codes.NotFound:
+			common.Response(c, 404, "CAR_NOT_FOUND", "Car not found", err.Error())
+			return
+		case codes.Aborted:
+			common.Response(c, 409, "CONFLICT", "Car token was issued concurrently, retry", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	c.JSON(201, model.CarTokenResponse{
+		CarID:       resp.CarId,
+		AccessToken: resp.AccessToken,
+		TokenID:     resp.TokenId,
+		ExpiresAt:   resp.ExpiresAt,
+		Rotated:     resp.Rotated,
+	})
+}
+
+func (h *AuthHandler) RevokeCarToken(c *gin.Context) {
+	carID := c.Param("id")
+	if carID == "" {
+		common.Response(c, 400, "INVALID_DATA", "CarID is required", "")
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.RevokeCarToken(ctx, traceID, &authpb.RevokeCarTokenRequest{CarId: carID})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.InvalidArgument:
+			common.Response(c, 400, "INVALID_DATA", "Invalid car id", err.Error())
+			return
+		case codes.NotFound:
+			common.Response(c, 404, "CAR_TOKEN_NOT_FOUND", "Car has no active token", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	c.JSON(200, gin.H{
+		"car_id":   resp.CarId,
+		"token_id": resp.TokenId,
+		"revoked":  true,
+	})
+}
+
+func (h *AuthHandler) UnlockUser(c *gin.Context) {
+	var req model.UnlockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.UnlockUser(ctx, traceID, &authpb.UnlockUserRequest{Email: req.Email})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.InvalidArgument:
+			common.Response(c, 400, "VALIDATION_FAILED", "Invalid email", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	c.JSON(200, gin.H{
+		"email":    resp.Email,
+		"unlocked": true,
 	})
 }
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	adminpb "github.com/jekiti/citydrive/gen/proto/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *AdminHandler) ListGeozones(c *gin.Context) {
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.ListGeozones(ctx, traceID, &adminpb.ListGeozonesRequest{})
+	if err != nil {
+		geozoneError(c, err)
+		return
+	}
+
+	zones := make([]model.Geozone, len(resp.Geozones))
+	for i, zone := range resp.Geozones {
+		zones[i] = toGeozone(zone)
+	}
+	c.JSON(200, model.ListGeozonesResponse{Geozones: zones})
+}
+
+func (h *AdminHandler) GetGeozone(c *gin.Context) {
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.GetGeozone(ctx, traceID, &adminpb.GetGeozoneRequest{Id: c.Param("id")})
+	if err != nil {
+		geozoneError(c, err)
+		return
+	}
+	c.JSON(200, toGeozone(resp.Geozone))
+}
+
+func (h *AdminHandler) CreateGeozone(c *gin.Context) {
+	var req model.GeozoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.CreateGeozone(ctx, traceID, &adminpb.CreateGeozoneRequest{
+		Name:          req.Name,
+		Polygon:       toGeoPoints(req.Polygon),
+		SpeedLimit:    req.SpeedLimit,
+		NoEntry:       req.NoEntry,
+		OperatingArea: req.OperatingArea,
+		AllowedFrom:   req.AllowedFrom,
+		AllowedTo:     req.AllowedTo,
+		Enabled:       req.Enabled,
+	})
+	if err != nil {
+		geozoneError(c, err)
+		return
+	}
+	c.JSON(201, toGeozone(resp.Geozone))
+}
+
+func (h *AdminHandler) UpdateGeozone(c *gin.Context) {
+	var req model.GeozoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.UpdateGeozone(ctx, traceID, &adminpb.UpdateGeozoneRequest{
+		Id:            c.Param("id"),
+		Name:          req.Name,
+		Polygon:       toGeoPoints(req.Polygon),
+		SpeedLimit:    req.SpeedLimit,
+		NoEntry:       req.NoEntry,
+		OperatingArea: req.OperatingArea,
+		AllowedFrom:   req.AllowedFrom,
+		AllowedTo:     req.AllowedTo,
+		Enabled:       req.Enabled,
+	})
+	if err != nil {
+		geozoneError(c, err)
+		return
+	}
+	c.JSON(200, toGeozone(resp.Geozone))
+}
+
+func (h *AdminHandler) DeleteGeozone(c *gin.Context) {
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	_, err := h.adminClient.DeleteGeozone(ctx, traceID, &adminpb.DeleteGeozoneRequest{Id: c.Param("id")})
+	if err != nil {
+		geozoneError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"id":      c.Param("id"),
+		"deleted": true,
+	})
+}
+
+func geozoneError(c *gin.Context, err error) {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		common.Response(c, 502, "SERVICE_UNAVAILABLE", "Admin service is down", err.Error())
+	case codes.DeadlineExceeded:
+		common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+	case codes.InvalidArgument:
+		common.Response(c, 400, "VALIDATION_FAILED", "Invalid geozone", err.Error())
+	case codes.NotFound:
+		common.Response(c, 404, "GEOZONE_NOT_FOUND", "Geozone not found", err.Error())
+	default:
+		common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+	}
+}
+
+func toGeozone(zone *adminpb.Geozone) model.Geozone {
+	polygon := make([]model.GeoPoint, len(zone.Polygon))
+	for i, p := range zone.Polygon {
+		polygon[i] = model.GeoPoint{Lat: p.Lat, Lon: p.Lon}
+	}
+	return model.Geozone{
+		ID:            zone.Id,
+		Name:          zone.Name,
+		Polygon:       polygon,
+		SpeedLimit:    zone.SpeedLimit,
+		NoEntry:       zone.NoEntry,
+		OperatingArea: zone.OperatingArea,
+		AllowedFrom:   zone.AllowedFrom,
+		AllowedTo:     zone.AllowedTo,
+		Enabled:       zone.Enabled,
+		CreatedAt:     zone.CreatedAt,
+		UpdatedAt:     zone.UpdatedAt,
+	}
+}
+
+func toGeoPoints(points []model.GeoPoint) []*adminpb.GeoPoint {
+	polygon := make([]*adminpb.GeoPoint, len(points))
+	for i, p := range points {
+		polygon[i] = &adminpb.GeoPoint{Lat: p.Lat, Lon: p.Lon}
+	}
+	return polygon
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	authpb "github.com/jekiti/citydrive/gen/proto/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req model.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.VerifyTOTP(ctx, traceID, &authpb.VerifyTOTPRequest{
+		MfaToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		ClientIp:     c.ClientIP(),
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.InvalidArgument:
+			common.Response(c, 400, "VALIDATION_FAILED", "Invalid verification data", err.Error())
+			return
+		case codes.Unauthenticated:
+			common.Response(c, 401, "INVALID_MFA_CODE", "Invalid code or expired mfa token", err.Error())
+			return
+		case codes.PermissionDenied:
+			common.Response(c, 403, "USER_DISABLED", "User is disabled", err.Error())
+			return
+		case codes.ResourceExhausted:
+			if retryAfter, ok := common.RetryAfter(err); ok {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+			common.Response(c, 429, "TOO_MANY_ATTEMPTS", "Too many login attempts, try again later", err.Error())
+			return
+		case codes.FailedPrecondition:
+			common.Response(c, 503, "MFA_UNAVAILABLE", "Two-factor authentication is not available", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	c.JSON(200, model.TokenResponse{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		ExpiresIn:    resp.ExpiresIn,
+	})
+}
+
+func (h *AuthHandler) EnrollTOTP(c *gin.Context) {
+	userID, ok := common.GetUserID(c)
+	if !ok {
+		common.Response(c, 401, "INVALID_CLAIMS", "Invalid token subject", "")
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.EnrollTOTP(ctx, traceID, &authpb.EnrollTOTPRequest{UserId: userID})
+	if err != nil {
+		h.totpError(c, err)
+		return
+	}
+	c.JSON(200, model.TOTPEnrollmentResponse{
+		Secret:     resp.Secret,
+		OtpauthURL: resp.OtpauthUrl,
+	})
+}
+
+func (h *AuthHandler) ActivateTOTP(c *gin.Context) {
+	var req model.ActivateTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+	userID, ok := common.GetUserID(c)
+	if !ok {
+		common.Response(c, 401, "INVALID_CLAIMS", "Invalid token subject", "")
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.ActivateTOTP(ctx, traceID, &authpb.ActivateTOTPRequest{
+		UserId: userID,
+		Code:   req.Code,
+	})
+	if err != nil {
+		h.totpError(c, err)
+		return
+	}
+	c.JSON(200, model.RecoveryCodesResponse{RecoveryCodes: resp.RecoveryCodes})
+}
+
+func (h *AuthHandler) totpError(c *gin.Context, err error) {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+	case codes.DeadlineExceeded:
+		common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+	case codes.Unauthenticated:
+		common.Response(c, 400, "INVALID_TOTP_CODE", "Invalid authenticator code", err.Error())
+	case codes.NotFound:
+		common.Response(c, 404, "USER_NOT_FOUND", "User not found", err.Error())
+	case codes.FailedPrecondition:
+		common.Response(c, 409, "TOTP_STATE_CONFLICT", "Two-factor authentication is already enabled, not enrolled or unavailable", err.Error())
+	default:
+		common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	authpb "github.com/jekiti/citydrive/gen/proto/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req model.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+	userID, ok := common.GetUserID(c)
+	if !ok {
+		common.Response(c, 401, "INVALID_CLAIMS", "Invalid token subject", "")
+		return
+	}
+	sessionID, _ := c.Get("session_id")
+	sessionIDString, _ := sessionID.(string)
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.ChangePassword(ctx, traceID, &authpb.ChangePasswordRequest{
+		UserId:      userID,
+		SessionId:   sessionIDString,
+		OldPassword: req.OldPassword,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.InvalidArgument:
+			common.Response(c, 400, "WEAK_PASSWORD", "New password does not meet the password policy", err.Error())
+			return
+		case codes.Unauthenticated:
+			common.Response(c, 401, "INVALID_CREDENTIALS", "Old password is incorrect", err.Error())
+			return
+		case codes.NotFound:
+			common.Response(c, 404, "USER_NOT_FOUND", "User not found", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	c.JSON(200, gin.H{
+		"changed":          true,
+		"revoked_sessions": resp.RevokedSessions,
+	})
+}
+
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req model.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	_, err := h.authClient.RequestPasswordReset(ctx, traceID, &authpb.RequestPasswordResetRequest{
+		Email:    req.Email,
+		ClientIp: c.ClientIP(),
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.InvalidArgument:
+			common.Response(c, 400, "VALIDATION_FAILED", "Invalid email", err.Error())
+			return
+		case codes.ResourceExhausted:
+			if retryAfter, ok := common.RetryAfter(err); ok {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+			common.Response(c, 429, "TOO_MANY_REQUESTS", "Too many password reset requests, try again later", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	c.JSON(202, gin.H{
+		"status": "if the email is registered, a reset link has been sent",
+	})
+}
+
+func (h *AuthHandler) ConfirmPasswordReset(c *gin.Context) {
+	var req model.ConfirmPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	_, err := h.authClient.ConfirmPasswordReset(ctx, traceID, &authpb.ConfirmPasswordResetRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.InvalidArgument:
+			common.Response(c, 400, "WEAK_PASSWORD", "New password does not meet the password policy", err.Error())
+			return
+		case codes.Unauthenticated:
+			common.Response(c, 400, "INVALID_RESET_TOKEN", "Reset token is invalid, expired or already used", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+	c.JSON(200, gin.H{
+		"reset": true,
+	})
+}
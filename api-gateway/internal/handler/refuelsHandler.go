@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	adminpb "github.com/jekiti/citydrive/gen/proto/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *AdminHandler) ListRefuels(c *gin.Context) {
+	from, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil {
+		common.Response(c, 400, "INVALID_DATA", "Query parameter FROM is required", err.Error())
+		return
+	}
+	to, err := strconv.ParseInt(c.Query("to"), 10, 64)
+	if err != nil {
+		common.Response(c, 400, "INVALID_DATA", "Query parameter TO is required", err.Error())
+		return
+	}
+	if from >= to {
+		common.Response(c, 400, "INVALID_DATA", "Query parameter FROM >= TO", "")
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.ListRefuels(ctx, traceID, &adminpb.ListRefuelsRequest{
+		From:  from,
+		To:    to,
+		CarId: c.Query("car_id"),
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Admin service is down", err.Error())
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+		case codes.InvalidArgument:
+			common.Response(c, 400, "INVALID_DATA", "Invalid Admin data", err.Error())
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+		}
+		return
+	}
+
+	refuels := make([]model.Refuel, len(resp.Refuels))
+	for i, refuel := range resp.Refuels {
+		refuels[i] = model.Refuel{
+			ID:           refuel.Id,
+			CarID:        refuel.CarId,
+			StartedAt:    refuel.StartedAt,
+			EndedAt:      refuel.EndedAt,
+			Lat:          refuel.Lat,
+			Lon:          refuel.Lon,
+			Odo:          refuel.Odo,
+			FuelBefore:   refuel.FuelBefore,
+			FuelAfter:    refuel.FuelAfter,
+			VolumeLiters: refuel.VolumeLiters,
+		}
+	}
+	c.JSON(200, model.ListRefuelsResponse{Refuels: refuels})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	telemetrypb "github.com/jekiti/citydrive/gen/proto/telemetry"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	batchItemAccepted = "accepted"
+	batchItemRejected = "rejected"
+	batchItemRetry    = "retry"
+)
+
+func (h *TelemetryHandler) PutCarInfoBatch(c *gin.Context) {
+	var req model.CarInfoBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+	carID := c.GetString("car_id")
+	if carID == "" {
+		common.Response(c, 401, "CAR_ID_MISSING", "car_id not found in context", "")
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	items := make([]*model.CarData, len(req.Items))
+	results := make([]model.BatchItemResult, len(req.Items))
+	for i := range req.Items {
+		items[i] = toCarData(&req.Items[i])
+		// items the service leaves out of its answer are resent by the car
+		results[i] = model.BatchItemResult{Index: int32(i), Status: batchItemRetry}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	resp, err := h.telemetryClient.PutTelemetryBatch(ctx, traceID, carID, items)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable:
+			common.Response(c, 502, "SERVICE_UNAVAILABLE", "Telemetry service is down", err.Error())
+			return
+		case codes.DeadlineExceeded:
+			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+			return
+		case codes.InvalidArgument:
+			common.ValidationResponse(c, "INVALID_DATA", "Invalid telemetry batch", err)
+			return
+		case codes.ResourceExhausted:
+			common.Response(c, 429, "TELEMETRY_BUSY", "Telemetry queue is full, retry later", err.Error())
+			return
+		case codes.PermissionDenied:
+			common.Response(c, 403, "PERMISSION_DENIED", "Access denied", err.Error())
+			return
+		default:
+			common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+			return
+		}
+	}
+
+	for _, item := range resp.Results {
+		if int(item.Index) >= len(results) {
+			continue
+		}
+		result := &results[item.Index]
+		result.Error = item.Error
+		switch item.Status {
+		case telemetrypb.BatchItemStatus_BATCH_ITEM_STATUS_ACCEPTED:
+			result.Status = batchItemAccepted
+		case telemetrypb.BatchItemStatus_BATCH_ITEM_STATUS_REJECTED:
+			result.Status = batchItemRejected
+		default:
+			result.Status = batchItemRetry
+		}
+	}
+
+	c.JSON(200, model.CarInfoBatchResponse{
+		Results:  results,
+		Accepted: resp.Accepted,
+	})
+}
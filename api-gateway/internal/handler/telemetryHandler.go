@@ -26,33 +26,21 @@ func (h *TelemetryHandler) PutCarInfo(c *gin.Context) {
 		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
 		return
 	}
-	carID, exists := c.Get("car_id")
-	if !exists {
+	carID := c.GetString("car_id")
+	if carID == "" {
 		common.Response(c, 401, "CAR_ID_MISSING", "car_id not found in context", "")
 		return
 	}
 
 	traceID := common.GetTraceID(c)
-	carData := &model.CarData{
-		Brand:             req.Brand,
-		Model:             req.Model,
-		YearOfManufacture: int32(req.YearOfManufacture),
-		Odo:               req.Odo,
-		Lat:               req.Lat,
-		Lon:               req.Lon,
-		Fuel:              req.Fuel,
-		FuelType:          req.FuelType,
-		Speed:             int32(req.Speed),
-		EngineOn:          req.EngineOn,
-		Locked:            req.Locked,
-		Activated:         req.Activated,
-		Rpm:               int32(req.Rpm),
-		Handbrake:         req.Handbrake,
+	carData := toCarData(&req)
+	if key := c.GetHeader("Idempotency-Key"); key != "" {
+		carData.IdempotencyKey = key
 	}
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	resp, err := h.telemetryClient.PutTelemetry(ctx, traceID, carID.(string), carData)
+	resp, err := h.telemetryClient.PutTelemetry(ctx, traceID, carID, carData)
 	if err != nil {
 		switch status.Code(err) {
 		case codes.Unavailable:
@@ -62,7 +50,13 @@ func (h *TelemetryHandler) PutCarInfo(c *gin.Context) {
 			common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
 			return
 		case codes.InvalidArgument:
-			common.Response(c, 400, "INVALID_DATA", "Invalid telemetry data", err.Error())
+			common.ValidationResponse(c, "INVALID_DATA", "Invalid telemetry data", err)
+			return
+		case codes.NotFound:
+			common.Response(c, 404, "CAR_NOT_REGISTERED", "Car is not registered", err.Error())
+			return
+		case codes.ResourceExhausted:
+			common.Response(c, 429, "TELEMETRY_BUSY", "Telemetry queue is full, retry later", err.Error())
 			return
 		case codes.PermissionDenied:
 			common.Response(c, 403, "PERMISSION_DENIED", "Access denied", err.Error())
@@ -74,10 +68,33 @@ func (h *TelemetryHandler) PutCarInfo(c *gin.Context) {
 	}
 
 	c.JSON(202, gin.H{
-		"status":   "accepted",
-		"message":  resp.Message,
-		"car_id":   carID,
-		"trace_id": traceID,
+		"status":    "accepted",
+		"message":   resp.Message,
+		"duplicate": resp.Duplicate,
+		"car_id":    carID,
+		"trace_id":  traceID,
 	})
 
 }
+
+func toCarData(req *model.CarInfoRequest) *model.CarData {
+	return &model.CarData{
+		Brand:             req.Brand,
+		Model:             req.Model,
+		YearOfManufacture: int32(req.YearOfManufacture),
+		Odo:               req.Odo,
+		Lat:               req.Lat,
+		Lon:               req.Lon,
+		Fuel:              req.Fuel,
+		FuelType:          req.FuelType,
+		Speed:             int32(req.Speed),
+		EngineOn:          req.EngineOn,
+		Locked:            req.Locked,
+		Activated:         req.Activated,
+		Rpm:               int32(req.Rpm),
+		Handbrake:         req.Handbrake,
+		RecordedAt:        req.RecordedAt,
+		Sequence:          req.Sequence,
+		IdempotencyKey:    req.IdempotencyKey,
+	}
+}
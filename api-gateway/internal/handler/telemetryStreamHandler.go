@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	"github.com/jekiti/citydrive/api-gateway/internal/service"
+	telemetrypb "github.com/jekiti/citydrive/gen/proto/telemetry"
+	"golang.org/x/net/websocket"
+)
+
+const streamMaxFrameBytes = 64 << 10
+
+// StreamCarInfo bridges a car's WebSocket connection to the StreamTelemetry gRPC stream.
+// Every JSON frame gets an ack frame with the same message_id.
+func (h *TelemetryHandler) StreamCarInfo(c *gin.Context) {
+	carID, exists := c.Get("car_id")
+	carIDString, ok := carID.(string)
+	if !exists || !ok || carIDString == "" {
+		common.Response(c, 401, "CAR_ID_MISSING", "car_id not found in context", "")
+		return
+	}
+	traceID := common.GetTraceID(c)
+	logger := common.LoggerForModule(c, "handler", "StreamCarInfo").With("car_id", carIDString)
+
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+		ws.MaxPayloadBytes = streamMaxFrameBytes
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+
+		var writeMu sync.Mutex
+		send := func(v any) error {
+			writeMu.Lock()
+			defer writeMu.Unlock()
+			return websocket.JSON.Send(ws, v)
+		}
+
+		stream, err := h.telemetryClient.OpenStream(ctx, traceID, carIDString)
+		if err != nil {
+			logger.Error("failed to open telemetry stream", "error", err)
+			send(gin.H{
+				"error_code":        "SERVICE_UNAVAILABLE",
+				"error_description": "Telemetry service is down",
+				"trace_id":          traceID,
+			})
+			return
+		}
+		logger.Info("telemetry websocket opened")
+
+		acksDone := make(chan struct{})
+		go func() {
+			defer close(acksDone)
+			for {
+				ack, err := stream.Recv()
+				if err != nil {
+					if !errors.Is(err, io.EOF) {
+						logger.Warn("telemetry stream closed by server", "error", err)
+						// unblock the reader below, the car has to reconnect
+						ws.Close()
+					}
+					return
+				}
+				if err := send(model.CarInfoAck{
+					MessageID: ack.MessageId,
+					Accepted:  ack.Accepted,
+					Error:     ack.Error,
+				}); err != nil {
+					logger.Warn("failed to deliver ack", "message_id", ack.MessageId, "error", err)
+				}
+			}
+		}()
+
+		for {
+			var msg model.CarInfoStreamMessage
+			if err := websocket.JSON.Receive(ws, &msg); err != nil {
+				if !errors.Is(err, io.EOF) {
+					logger.Warn("failed to read telemetry frame", "error", err)
+				}
+				break
+			}
+
+			data := toCarData(&msg.CarInfoRequest)
+
+			// Send blocks while the telemetry service is behind, which in turn stops reading the socket
+			err := stream.Send(&telemetrypb.StreamTelemetryRequest{
+				MessageId: msg.MessageID,
+				Telemetry: service.ToPutRequest(data),
+			})
+			if err != nil {
+				logger.Warn("failed to forward telemetry frame", "error", err)
+				break
+			}
+		}
+
+		stream.CloseSend()
+		<-acksDone
+		logger.Info("telemetry websocket closed")
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	authpb "github.com/jekiti/citydrive/gen/proto/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *AuthHandler) ListUsers(c *gin.Context) {
+	var query model.ListUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid query parameters", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.ListUsers(ctx, traceID, &authpb.ListUsersRequest{
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+		Department: query.Department,
+	})
+	if err != nil {
+		userManagementError(c, err)
+		return
+	}
+
+	users := make([]model.UserResponse, len(resp.Users))
+	for i, user := range resp.Users {
+		users[i] = toUserResponse(user)
+	}
+	c.JSON(200, model.ListUsersResponse{Users: users, Total: resp.Total})
+}
+
+func (h *AuthHandler) GetUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.GetUser(ctx, traceID, &authpb.GetUserRequest{Id: id})
+	if err != nil {
+		userManagementError(c, err)
+		return
+	}
+	c.JSON(200, toUserResponse(resp))
+}
+
+func (h *AuthHandler) UpdateUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	var req model.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.UpdateUser(ctx, traceID, &authpb.UpdateUserRequest{
+		Id:         id,
+		Name:       req.Name,
+		Surname:    req.Surname,
+		Department: req.Department,
+		Role:       req.Role,
+	})
+	if err != nil {
+		userManagementError(c, err)
+		return
+	}
+	c.JSON(200, toUserResponse(resp))
+}
+
+func (h *AuthHandler) DisableUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok || !notSelf(c, id) {
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.DisableUser(ctx, traceID, &authpb.DisableUserRequest{Id: id})
+	if err != nil {
+		userManagementError(c, err)
+		return
+	}
+	c.JSON(200, toUserResponse(resp))
+}
+
+func (h *AuthHandler) DeleteUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok || !notSelf(c, id) {
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.authClient.DeleteUser(ctx, traceID, &authpb.DeleteUserRequest{Id: id})
+	if err != nil {
+		userManagementError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"id":      resp.Id,
+		"deleted": true,
+	})
+}
+
+func userIDParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		common.Response(c, 400, "INVALID_DATA", "Invalid user id", "")
+		return 0, false
+	}
+	return id, true
+}
+
+func notSelf(c *gin.Context, id int64) bool {
+	if currentID, ok := common.GetUserID(c); ok && currentID == id {
+		common.Response(c, 400, "CANNOT_MODIFY_SELF", "Operation is not allowed on your own account", "")
+		return false
+	}
+	return true
+}
+
+func userManagementError(c *gin.Context, err error) {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		common.Response(c, 502, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+	case codes.DeadlineExceeded:
+		common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+	case codes.InvalidArgument:
+		common.Response(c, 400, "VALIDATION_FAILED", "Invalid user data", err.Error())
+	case codes.NotFound:
+		common.Response(c, 404, "USER_NOT_FOUND", "User not found", err.Error())
+	default:
+		common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+	}
+}
+
+func toUserResponse(user *authpb.User) model.UserResponse {
+	return model.UserResponse{
+		ID:         user.Id,
+		Email:      user.Email,
+		Name:       user.Name,
+		Surname:    user.Surname,
+		Department: user.Department,
+		Roles:      user.Roles,
+		CreatedAt:  user.CreatedAt,
+		UpdatedAt:  user.UpdatedAt,
+		DisabledAt: user.DisabledAt,
+	}
+}
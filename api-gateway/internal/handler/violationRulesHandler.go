@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/model"
+	adminpb "github.com/jekiti/citydrive/gen/proto/admin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *AdminHandler) ListViolationRules(c *gin.Context) {
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.ListViolationRules(ctx, traceID, &adminpb.ListViolationRulesRequest{})
+	if err != nil {
+		violationRuleError(c, err)
+		return
+	}
+
+	rules := make([]model.ViolationRule, len(resp.Rules))
+	for i, rule := range resp.Rules {
+		rules[i] = toViolationRule(rule)
+	}
+	c.JSON(200, model.ListViolationRulesResponse{Rules: rules})
+}
+
+func (h *AdminHandler) GetViolationRule(c *gin.Context) {
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.GetViolationRule(ctx, traceID, &adminpb.GetViolationRuleRequest{Id: c.Param("id")})
+	if err != nil {
+		violationRuleError(c, err)
+		return
+	}
+	c.JSON(200, toViolationRule(resp.Rule))
+}
+
+func (h *AdminHandler) CreateViolationRule(c *gin.Context) {
+	var req model.ViolationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.CreateViolationRule(ctx, traceID, &adminpb.CreateViolationRuleRequest{
+		Type:            req.Type,
+		Description:     req.Description,
+		Condition:       req.Condition,
+		Severity:        req.Severity,
+		CooldownSeconds: req.CooldownSeconds,
+		Enabled:         req.Enabled,
+	})
+	if err != nil {
+		violationRuleError(c, err)
+		return
+	}
+	c.JSON(201, toViolationRule(resp.Rule))
+}
+
+func (h *AdminHandler) UpdateViolationRule(c *gin.Context) {
+	var req model.ViolationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		common.Response(c, 400, "INVALID_REQUEST", "Invalid request payload", err.Error())
+		return
+	}
+
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	resp, err := h.adminClient.UpdateViolationRule(ctx, traceID, &adminpb.UpdateViolationRuleRequest{
+		Id:              c.Param("id"),
+		Type:            req.Type,
+		Description:     req.Description,
+		Condition:       req.Condition,
+		Severity:        req.Severity,
+		CooldownSeconds: req.CooldownSeconds,
+		Enabled:         req.Enabled,
+	})
+	if err != nil {
+		violationRuleError(c, err)
+		return
+	}
+	c.JSON(200, toViolationRule(resp.Rule))
+}
+
+func (h *AdminHandler) DeleteViolationRule(c *gin.Context) {
+	traceID := common.GetTraceID(c)
+	ctx := c.Request.Context()
+	_, err := h.adminClient.DeleteViolationRule(ctx, traceID, &adminpb.DeleteViolationRuleRequest{Id: c.Param("id")})
+	if err != nil {
+		violationRuleError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"id":      c.Param("id"),
+		"deleted": true,
+	})
+}
+
+func violationRuleError(c *gin.Context, err error) {
+	switch status.Code(err) {
+	case codes.Unavailable:
+		common.Response(c, 502, "SERVICE_UNAVAILABLE", "Admin service is down", err.Error())
+	case codes.DeadlineExceeded:
+		common.Response(c, 504, "TIMEOUT", "Request timeout", err.Error())
+	case codes.InvalidArgument:
+		common.Response(c, 400, "VALIDATION_FAILED", "Invalid violation rule", err.Error())
+	case codes.AlreadyExists:
+		common.Response(c, 409, "VIOLATION_RULE_EXISTS", "Violation rule with this type already exists", err.Error())
+	case codes.NotFound:
+		common.Response(c, 404, "VIOLATION_RULE_NOT_FOUND", "Violation rule not found", err.Error())
+	default:
+		common.Response(c, 500, "INTERNAL_ERROR", "Internal server error", err.Error())
+	}
+}
+
+func toViolationRule(rule *adminpb.ViolationRule) model.ViolationRule {
+	return model.ViolationRule{
+		ID:              rule.Id,
+		Type:            rule.Type,
+		Description:     rule.Description,
+		Condition:       rule.Condition,
+		Severity:        rule.Severity,
+		CooldownSeconds: rule.CooldownSeconds,
+		Enabled:         rule.Enabled,
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/repository"
+	authpb "github.com/jekiti/citydrive/gen/proto/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, traceID string, req *authpb.AuthenticateAPIKeyRequest) (*authpb.ApiKey, error)
+}
+
+// RequireAuthOrAPIKey accepts either a user bearer token or an X-API-Key header.
+func RequireAuthOrAPIKey(keyfunc jwt.Keyfunc, revocations repository.RevocationRepository,
+	apiKeys APIKeyAuthenticator, limiter repository.RateLimiter) gin.HandlerFunc {
+	requireAuth := RequireAuth(keyfunc, revocations)
+	requireAPIKey := RequireAPIKey(apiKeys, limiter)
+	return func(c *gin.Context) {
+		if c.GetHeader(APIKeyHeader) != "" {
+			requireAPIKey(c)
+			return
+		}
+		requireAuth(c)
+	}
+}
+
+func RequireAPIKey(apiKeys APIKeyAuthenticator, limiter repository.RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger := common.LoggerForModule(c, "middleware", "RequireAPIKey")
+		traceID := common.GetTraceID(c)
+
+		rawKey := c.GetHeader(APIKeyHeader)
+		if rawKey == "" {
+			logger.Warn("missing api key header")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error_code":        "MISSING_API_KEY",
+				"error_description": "X-API-Key header is required",
+				"trace_id":          traceID,
+			})
+			c.Abort()
+			return
+		}
+
+		key, err := apiKeys.AuthenticateAPIKey(c.Request.Context(), traceID, &authpb.AuthenticateAPIKeyRequest{
+			ApiKey:   rawKey,
+			ClientIp: c.ClientIP(),
+		})
+		if err != nil {
+			switch status.Code(err) {
+			case codes.Unauthenticated:
+				logger.Warn("invalid api key")
+				common.Response(c, http.StatusUnauthorized, "INVALID_API_KEY", "API key is invalid, expired or revoked", "")
+			case codes.Unavailable:
+				logger.Error("auth service unavailable", "error", err)
+				common.Response(c, http.StatusBadGateway, "SERVICE_UNAVAILABLE", "Auth service is down", err.Error())
+			default:
+				logger.Error("failed to check api key", "error", err)
+				common.Response(c, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error", err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		allowed, retryAfter, err := limiter.Allow(c.Request.Context(), "apikey:"+key.Id, int(key.RateLimit), time.Minute)
+		if err != nil {
+			// the limiter must not take integrations down together with redis
+			logger.Error("failed to check api key rate limit", "key_id", key.Id, "error", err)
+		} else if !allowed {
+			logger.Warn("api key rate limit exceeded", "key_id", key.Id, "limit", key.RateLimit)
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			common.Response(c, http.StatusTooManyRequests, "RATE_LIMITED", "API key rate limit exceeded", "")
+			c.Abort()
+			return
+		}
+
+		permissions := make([]any, len(key.Permissions))
+		for i, p := range key.Permissions {
+			permissions[i] = p
+		}
+		c.Set("api_key_id", key.Id)
+		c.Set("permissions", permissions)
+
+		c.Next()
+
+		logger.Info("api key request",
+			"audit", true,
+			"key_id", key.Id,
+			"key_name", key.Name,
+			"method", c.Request.Method,
+			"path", c.FullPath(),
+			"status", c.Writer.Status(),
+			"client_ip", c.ClientIP(),
+		)
+	}
+}
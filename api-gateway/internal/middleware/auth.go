@@ -8,9 +8,10 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/repository"
 )
 
-func RequireAuth(jwtSecret string) gin.HandlerFunc {
+func RequireAuth(keyfunc jwt.Keyfunc, revocations repository.RevocationRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		logger := common.LoggerForModule(c, "middleware", "RequireAuth")
 
@@ -42,12 +43,7 @@ func RequireAuth(jwtSecret string) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(jwtSecret), nil
-		})
+		token, err := jwt.Parse(tokenString, keyfunc)
 
 		if err != nil || !token.Valid {
 			logger.Warn("invalid token", "error", err)
@@ -73,9 +69,27 @@ func RequireAuth(jwtSecret string) gin.HandlerFunc {
 				return
 			}
 
+			sub, _ := claims["sub"].(string)
+			if !strings.HasPrefix(sub, "user:") {
+				logger.Warn("token subject is not a user", "sub", sub)
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error_code":        "INVALID_CLAIMS",
+					"error_description": "Invalid token claims",
+					"trace_id":          traceID,
+				})
+				c.Abort()
+				return
+			}
+
+			if !checkNotRevoked(c, logger, revocations, claims) {
+				return
+			}
+
 			c.Set("user_id", claims["sub"])
 			c.Set("email", claims["email"])
 			c.Set("roles", claims["roles"])
+			c.Set("permissions", claims["permissions"])
+			c.Set("session_id", claims["sid"])
 			c.Set("claims", claims)
 
 			logger.Info("user authenticated", "user_id", claims["sub"])
@@ -92,3 +106,14 @@ func RequireAuth(jwtSecret string) gin.HandlerFunc {
 		}
 	}
 }
+
+func OptionalAuth(keyfunc jwt.Keyfunc, revocations repository.RevocationRepository) gin.HandlerFunc {
+	requireAuth := RequireAuth(keyfunc, revocations)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		requireAuth(c)
+	}
+}
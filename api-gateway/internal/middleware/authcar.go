@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -8,9 +9,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/repository"
+	"github.com/jekiti/citydrive/pkg/cartoken"
 )
 
-func RequireCarAuth(jwtSecret string) gin.HandlerFunc {
+func RequireCarAuth(keyfunc jwt.Keyfunc, revocations repository.RevocationRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		logger := common.LoggerForModule(c, "middleware", "RequireCarAuth")
 
@@ -42,12 +45,7 @@ func RequireCarAuth(jwtSecret string) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(jwtSecret), nil
-		})
+		token, err := jwt.Parse(tokenString, keyfunc)
 
 		if err != nil || !token.Valid {
 			logger.Warn("invalid token", "error", err)
@@ -61,7 +59,9 @@ func RequireCarAuth(jwtSecret string) gin.HandlerFunc {
 		}
 
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			if float64(time.Now().Unix()) > claims["exp"].(float64) {
+			carID, err := cartoken.CarID(claims, time.Now())
+			switch {
+			case errors.Is(err, cartoken.ErrExpired):
 				logger.Warn("token expired")
 				c.JSON(http.StatusUnauthorized, gin.H{
 					"error_code":        "TOKEN_EXPIRED",
@@ -70,10 +70,17 @@ func RequireCarAuth(jwtSecret string) gin.HandlerFunc {
 				})
 				c.Abort()
 				return
-			}
-			roles, ok := claims["roles"].([]any)
-			if !ok {
-				logger.Warn("invalid token roles claim")
+			case errors.Is(err, cartoken.ErrNotCar):
+				logger.Warn("insufficient token roles", "roles", claims["roles"])
+				c.JSON(http.StatusForbidden, gin.H{
+					"error_code":        "INSUFFICIENT_ROLES",
+					"error_description": "Insufficient token roles",
+					"trace_id":          traceID,
+				})
+				c.Abort()
+				return
+			case err != nil:
+				logger.Warn("invalid car token claims", "sub", claims["sub"], "error", err)
 				c.JSON(http.StatusUnauthorized, gin.H{
 					"error_code":        "INVALID_CLAIMS",
 					"error_description": "Invalid token claims",
@@ -82,22 +89,16 @@ func RequireCarAuth(jwtSecret string) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
-			if roles[0] != "car" {
-				logger.Warn("insufficient token roles", "roles", roles)
-				c.JSON(http.StatusForbidden, gin.H{
-					"error_code":        "INSUFFICIENT_ROLES",
-					"error_description": "Insufficient token roles",
-					"trace_id":          traceID,
-				})
-				c.Abort()
+
+			if !checkNotRevoked(c, logger, revocations, claims) {
 				return
 			}
 
-			c.Set("car_id", claims["car_id"])
+			c.Set("car_id", carID)
 
 			c.Set("claims", claims)
 
-			logger.Info("car authenticated", "car_id", claims["car_id"])
+			logger.Info("car authenticated", "car_id", carID)
 			c.Next()
 		} else {
 			logger.Warn("invalid token claims")
@@ -0,0 +1,14 @@
+package middleware
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func HMACKeyfunc(secret string) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(secret), nil
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+)
+
+const (
+	PermCarsNowRead          = "cars.now.read"
+	PermCarsDetailsRead      = "cars.details.read"
+	PermCarsHistoryRead      = "cars.history.read"
+	PermCarsTokensManage     = "cars.tokens.manage"
+	PermUsersManage          = "users.manage"
+	PermAPIKeysManage        = "api_keys.manage"
+	PermViolationRulesManage = "violation_rules.manage"
+	PermGeozonesManage       = "geozones.manage"
+)
+
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger := common.LoggerForModule(c, "middleware", "RequirePermission")
+
+		if !HasPermission(c, permission) {
+			logger.Warn("insufficient permissions", "user_id", c.GetString("user_id"), "api_key_id", c.GetString("api_key_id"), "permission", permission)
+			c.JSON(http.StatusForbidden, gin.H{
+				"error_code":        "INSUFFICIENT_PERMISSIONS",
+				"error_description": "Permission " + permission + " is required",
+				"trace_id":          common.GetTraceID(c),
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func HasPermission(c *gin.Context, permission string) bool {
+	value, exists := c.Get("permissions")
+	if !exists {
+		return false
+	}
+	permissions, ok := value.([]any)
+	if !ok {
+		return false
+	}
+	for _, p := range permissions {
+		if s, ok := p.(string); ok && s == permission {
+			return true
+		}
+	}
+	return false
+}
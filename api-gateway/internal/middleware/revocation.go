@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jekiti/citydrive/api-gateway/internal/common"
+	"github.com/jekiti/citydrive/api-gateway/internal/repository"
+)
+
+func checkNotRevoked(c *gin.Context, logger *slog.Logger, revocations repository.RevocationRepository, claims jwt.MapClaims) bool {
+	jti, ok := claims["jti"].(string)
+	if !ok {
+		return true
+	}
+	traceID := common.GetTraceID(c)
+
+	revoked, err := revocations.IsRevoked(c.Request.Context(), jti)
+	if err != nil {
+		logger.Error("failed to check token revocation", "error", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error_code":        "SERVICE_UNAVAILABLE",
+			"error_description": "Token revocation check failed",
+			"trace_id":          traceID,
+		})
+		c.Abort()
+		return false
+	}
+	if revoked {
+		logger.Warn("token revoked", "jti", jti)
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error_code":        "TOKEN_REVOKED",
+			"error_description": "Token is revoked",
+			"trace_id":          traceID,
+		})
+		c.Abort()
+		return false
+	}
+	return true
+}
@@ -83,3 +83,79 @@ type CarState struct {
     Handbrake bool    `json:"handbrake" db:"handbrake"`
     Time      int64   `json:"time" db:"timestamp"`
 }
+
+type ViolationRuleRequest struct {
+    Type            string `json:"type" binding:"required,max=64"`
+    Description     string `json:"description"`
+    Condition       string `json:"condition" binding:"required"`
+    Severity        string `json:"severity" binding:"required,oneof=low medium high critical"`
+    CooldownSeconds int32  `json:"cooldown_seconds" binding:"omitempty,min=0"`
+    Enabled         *bool  `json:"enabled"`
+}
+
+type ViolationRule struct {
+    ID              string `json:"id"`
+    Type            string `json:"type"`
+    Description     string `json:"description"`
+    Condition       string `json:"condition"`
+    Severity        string `json:"severity"`
+    CooldownSeconds int32  `json:"cooldown_seconds"`
+    Enabled         bool   `json:"enabled"`
+    CreatedAt       int64  `json:"created_at"`
+    UpdatedAt       int64  `json:"updated_at"`
+}
+
+type ListViolationRulesResponse struct {
+    Rules []ViolationRule `json:"rules"`
+}
+
+type GeoPoint struct {
+    Lat float64 `json:"lat" binding:"min=-90,max=90"`
+    Lon float64 `json:"lon" binding:"min=-180,max=180"`
+}
+
+type GeozoneRequest struct {
+    Name          string     `json:"name" binding:"required,max=100"`
+    Polygon       []GeoPoint `json:"polygon" binding:"required,min=3,dive"`
+    SpeedLimit    int32      `json:"speed_limit" binding:"omitempty,min=0"`
+    NoEntry       bool       `json:"no_entry"`
+    OperatingArea bool       `json:"operating_area"`
+    AllowedFrom   string     `json:"allowed_from"`
+    AllowedTo     string     `json:"allowed_to"`
+    Enabled       *bool      `json:"enabled"`
+}
+
+type Geozone struct {
+    ID            string     `json:"id"`
+    Name          string     `json:"name"`
+    Polygon       []GeoPoint `json:"polygon"`
+    SpeedLimit    int32      `json:"speed_limit"`
+    NoEntry       bool       `json:"no_entry"`
+    OperatingArea bool       `json:"operating_area"`
+    AllowedFrom   string     `json:"allowed_from"`
+    AllowedTo     string     `json:"allowed_to"`
+    Enabled       bool       `json:"enabled"`
+    CreatedAt     int64      `json:"created_at"`
+    UpdatedAt     int64      `json:"updated_at"`
+}
+
+type ListGeozonesResponse struct {
+    Geozones []Geozone `json:"geozones"`
+}
+
+type Refuel struct {
+    ID           string  `json:"id"`
+    CarID        string  `json:"car_id"`
+    StartedAt    int64   `json:"started_at"`
+    EndedAt      int64   `json:"ended_at"`
+    Lat          float64 `json:"lat"`
+    Lon          float64 `json:"lon"`
+    Odo          int64   `json:"odo"`
+    FuelBefore   float64 `json:"fuel_before"`
+    FuelAfter    float64 `json:"fuel_after"`
+    VolumeLiters float64 `json:"volume_liters"`
+}
+
+type ListRefuelsResponse struct {
+    Refuels []Refuel `json:"refuels"`
+}
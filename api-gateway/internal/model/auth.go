@@ -10,4 +10,138 @@ type RegisterRequest struct {
     Name       string `json:"name" binding:"required"`
     Surname    string `json:"surname" binding:"required"` 
     Department string `json:"department" binding:"required"`
-}
\ No newline at end of file
+    Role       string `json:"role" binding:"omitempty,oneof=viewer dispatcher fleet-admin superuser"`
+}
+
+type UnlockUserRequest struct {
+    Email string `json:"email" binding:"required,email"`
+}
+
+type CarTokenResponse struct {
+    CarID       string `json:"car_id"`
+    AccessToken string `json:"access_token"`
+    TokenID     string `json:"token_id"`
+    ExpiresAt   int64  `json:"expires_at"`
+    Rotated     bool   `json:"rotated"`
+}
+
+type RefreshRequest struct {
+    RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type TokenResponse struct {
+    AccessToken  string `json:"access_token"`
+    RefreshToken string `json:"refresh_token"`
+    ExpiresIn    int64  `json:"expires_in"`
+}
+
+type MFAChallengeResponse struct {
+    MFARequired bool   `json:"mfa_required"`
+    MFAToken    string `json:"mfa_token"`
+    ExpiresIn   int64  `json:"expires_in"`
+}
+
+type SessionResponse struct {
+    ID         string `json:"id"`
+    CreatedAt  int64  `json:"created_at"`
+    LastUsedAt int64  `json:"last_used_at"`
+    ExpiresAt  int64  `json:"expires_at"`
+    Current    bool   `json:"current"`
+}
+
+type ListSessionsResponse struct {
+    Sessions []SessionResponse `json:"sessions"`
+}
+
+type UserResponse struct {
+    ID         int64    `json:"id"`
+    Email      string   `json:"email"`
+    Name       string   `json:"name"`
+    Surname    string   `json:"surname"`
+    Department string   `json:"department"`
+    Roles      []string `json:"roles"`
+    CreatedAt  int64    `json:"created_at"`
+    UpdatedAt  int64    `json:"updated_at"`
+    DisabledAt int64    `json:"disabled_at,omitempty"`
+}
+
+type ListUsersQuery struct {
+    Limit      int32  `form:"limit" binding:"omitempty,min=1,max=200"`
+    Offset     int32  `form:"offset" binding:"omitempty,min=0"`
+    Department string `form:"department"`
+}
+
+type ListUsersResponse struct {
+    Users []UserResponse `json:"users"`
+    Total int64          `json:"total"`
+}
+
+type UpdateUserRequest struct {
+    Name       *string `json:"name" binding:"omitempty,min=1"`
+    Surname    *string `json:"surname" binding:"omitempty,min=1"`
+    Department *string `json:"department" binding:"omitempty,min=1"`
+    Role       *string `json:"role" binding:"omitempty,oneof=viewer dispatcher fleet-admin superuser"`
+}
+
+type ChangePasswordRequest struct {
+    OldPassword string `json:"old_password" binding:"required"`
+    NewPassword string `json:"new_password" binding:"required"`
+}
+
+type PasswordResetRequest struct {
+    Email string `json:"email" binding:"required,email"`
+}
+
+type ConfirmPasswordResetRequest struct {
+    Token       string `json:"token" binding:"required"`
+    NewPassword string `json:"new_password" binding:"required"`
+}
+
+type VerifyMFARequest struct {
+    MFAToken     string `json:"mfa_token" binding:"required"`
+    Code         string `json:"code" binding:"omitempty,len=6,numeric"`
+    RecoveryCode string `json:"recovery_code"`
+}
+
+type ActivateTOTPRequest struct {
+    Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type TOTPEnrollmentResponse struct {
+    Secret     string `json:"secret"`
+    OtpauthURL string `json:"otpauth_url"`
+}
+
+type RecoveryCodesResponse struct {
+    RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type CreateAPIKeyRequest struct {
+    Name        string   `json:"name" binding:"required,max=100"`
+    Permissions []string `json:"permissions" binding:"required,min=1,dive,required"`
+    RateLimit   int32    `json:"rate_limit" binding:"omitempty,min=1"`
+    ExpiresIn   int64    `json:"expires_in" binding:"omitempty,min=1"`
+}
+
+type APIKeyResponse struct {
+    ID          string   `json:"id"`
+    Name        string   `json:"name"`
+    Prefix      string   `json:"prefix"`
+    Permissions []string `json:"permissions"`
+    RateLimit   int32    `json:"rate_limit"`
+    CreatedBy   int64    `json:"created_by,omitempty"`
+    CreatedAt   int64    `json:"created_at"`
+    ExpiresAt   int64    `json:"expires_at,omitempty"`
+    LastUsedAt  int64    `json:"last_used_at,omitempty"`
+    LastUsedIP  string   `json:"last_used_ip,omitempty"`
+    RevokedAt   int64    `json:"revoked_at,omitempty"`
+}
+
+type CreateAPIKeyResponse struct {
+    APIKey string         `json:"api_key"`
+    Key    APIKeyResponse `json:"key"`
+}
+
+type ListAPIKeysResponse struct {
+    Keys []APIKeyResponse `json:"keys"`
+}
@@ -15,6 +15,9 @@ type CarData struct {
 	Activated         bool    `json:"activated"`
 	Rpm               int32   `json:"rpm"`
 	Handbrake         bool    `json:"handbrake"`
+	RecordedAt        int64   `json:"recorded_at"`
+	Sequence          uint64  `json:"sequence"`
+	IdempotencyKey    string  `json:"idempotency_key"`
 }
 
 type CarInfoRequest struct {
@@ -32,4 +35,33 @@ type CarInfoRequest struct {
 	Activated         bool    `json:"activated"`
 	Rpm               int     `json:"rpm" binding:"min=0"`
 	Handbrake         bool    `json:"handbrake"`
+	RecordedAt        int64   `json:"recorded_at" binding:"min=0"`
+	Sequence          uint64  `json:"sequence"`
+	IdempotencyKey    string  `json:"idempotency_key" binding:"max=128"`
+}
+
+type CarInfoStreamMessage struct {
+	MessageID uint64 `json:"message_id"`
+	CarInfoRequest
+}
+
+type CarInfoAck struct {
+	MessageID uint64 `json:"message_id"`
+	Accepted  bool   `json:"accepted"`
+	Error     string `json:"error,omitempty"`
+}
+
+type CarInfoBatchRequest struct {
+	Items []CarInfoRequest `json:"items" binding:"required,min=1,max=500"`
+}
+
+type BatchItemResult struct {
+	Index  int32  `json:"index"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type CarInfoBatchResponse struct {
+	Results  []BatchItemResult `json:"results"`
+	Accepted int32             `json:"accepted"`
 }
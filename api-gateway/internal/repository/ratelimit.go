@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jekiti/citydrive/api-gateway/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+const rateLimitPrefix = "gateway:ratelimit:"
+
+type RateLimiter interface {
+	// Allow counts a request against key in a fixed window and reports how long to wait when the limit is hit.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error)
+	Close() error
+}
+
+type RedisRateLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisRateLimiter(cfg *config.RedisConfig) (*RedisRateLimiter, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:        cfg.Host + ":" + cfg.Port,
+		Password:    cfg.Password,
+		DB:          cfg.DB,
+		ReadTimeout: cfg.ReadTimeout,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis at %s:%s: %w", cfg.Host, cfg.Port, err)
+	}
+
+	return &RedisRateLimiter{client: client}, nil
+}
+
+func (r *RedisRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	now := time.Now()
+	windowStart := now.Truncate(window)
+	redisKey := rateLimitPrefix + key + ":" + strconv.FormatInt(windowStart.Unix(), 10)
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, redisKey)
+	pipe.Expire(ctx, redisKey, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, 0, fmt.Errorf("failed to count request: %w", err)
+	}
+
+	if incr.Val() > int64(limit) {
+		return false, windowStart.Add(window).Sub(now), nil
+	}
+	return true, 0, nil
+}
+
+func (r *RedisRateLimiter) Close() error {
+	return r.client.Close()
+}
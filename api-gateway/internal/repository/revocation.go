@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jekiti/citydrive/api-gateway/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+const revokedTokenPrefix = "auth:revoked:"
+
+type RevocationRepository interface {
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+	Close() error
+}
+
+type RedisRevocationRepository struct {
+	client *redis.Client
+}
+
+func NewRedisRevocationRepository(cfg *config.RedisConfig) (*RedisRevocationRepository, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:        cfg.Host + ":" + cfg.Port,
+		Password:    cfg.Password,
+		DB:          cfg.DB,
+		ReadTimeout: cfg.ReadTimeout,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis at %s:%s: %w", cfg.Host, cfg.Port, err)
+	}
+
+	return &RedisRevocationRepository{client: client}, nil
+}
+
+func (r *RedisRevocationRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	err := r.client.Get(ctx, revokedTokenPrefix+tokenID).Err()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	return true, nil
+}
+
+func (r *RedisRevocationRepository) Close() error {
+	return r.client.Close()
+}
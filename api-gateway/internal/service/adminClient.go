@@ -116,6 +116,204 @@ func (c *AdminClient) GetCarsNow(ctx context.Context, traceID string, req *admin
 	return response, nil
 }
 
+func (c *AdminClient) ListRefuels(ctx context.Context, traceID string, req *adminpb.ListRefuelsRequest) (*adminpb.ListRefuelsResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ListRefuels(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ListRefuels: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) ListViolationRules(ctx context.Context, traceID string, req *adminpb.ListViolationRulesRequest) (*adminpb.ListViolationRulesResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ListViolationRules(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ListViolationRules: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) GetViolationRule(ctx context.Context, traceID string, req *adminpb.GetViolationRuleRequest) (*adminpb.GetViolationRuleResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.GetViolationRule(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetViolationRule: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) CreateViolationRule(ctx context.Context, traceID string, req *adminpb.CreateViolationRuleRequest) (*adminpb.CreateViolationRuleResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.CreateViolationRule(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to CreateViolationRule: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) UpdateViolationRule(ctx context.Context, traceID string, req *adminpb.UpdateViolationRuleRequest) (*adminpb.UpdateViolationRuleResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.UpdateViolationRule(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to UpdateViolationRule: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) DeleteViolationRule(ctx context.Context, traceID string, req *adminpb.DeleteViolationRuleRequest) (*adminpb.DeleteViolationRuleResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.DeleteViolationRule(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to DeleteViolationRule: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) ListGeozones(ctx context.Context, traceID string, req *adminpb.ListGeozonesRequest) (*adminpb.ListGeozonesResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ListGeozones(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ListGeozones: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) GetGeozone(ctx context.Context, traceID string, req *adminpb.GetGeozoneRequest) (*adminpb.GetGeozoneResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.GetGeozone(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetGeozone: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) CreateGeozone(ctx context.Context, traceID string, req *adminpb.CreateGeozoneRequest) (*adminpb.CreateGeozoneResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.CreateGeozone(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to CreateGeozone: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) UpdateGeozone(ctx context.Context, traceID string, req *adminpb.UpdateGeozoneRequest) (*adminpb.UpdateGeozoneResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.UpdateGeozone(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to UpdateGeozone: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AdminClient) DeleteGeozone(ctx context.Context, traceID string, req *adminpb.DeleteGeozoneRequest) (*adminpb.DeleteGeozoneResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.DeleteGeozone(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to DeleteGeozone: %w", err)
+	}
+	return response, nil
+}
+
 func (c *AdminClient) Close() error {
 	if c.conn != nil {
 		return c.conn.Close()
@@ -9,8 +9,10 @@ import (
 	"github.com/jekiti/citydrive/api-gateway/internal/config"
 	authpb "github.com/jekiti/citydrive/gen/proto/auth"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type AuthClient struct {
@@ -50,7 +52,7 @@ func (c *AuthClient) Login(ctx context.Context, traceID string, req *authpb.Logi
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
 	if req.Email == "" || req.Password == "" {
-		return nil, fmt.Errorf("email and password cannot be empty")
+		return nil, status.Error(codes.InvalidArgument, "email and password cannot be empty")
 	}
 	response, err := c.client.Login(ctx, req)
 	if err != nil {
@@ -71,7 +73,7 @@ func (c *AuthClient) Register(ctx context.Context, traceID string, req *authpb.R
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
 	if req.Email == "" || req.Name == "" || req.Surname == "" || req.Department == "" {
-		return nil, fmt.Errorf("email, name, surname, and department cannot be empty")
+		return nil, status.Error(codes.InvalidArgument, "email, name, surname, and department cannot be empty")
 	}
 	response, err := c.client.Register(ctx, req)
 	if err != nil {
@@ -80,6 +82,384 @@ func (c *AuthClient) Register(ctx context.Context, traceID string, req *authpb.R
 	return response, nil
 }
 
+func (c *AuthClient) Refresh(ctx context.Context, traceID string, req *authpb.RefreshRequest) (*authpb.RefreshResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.Refresh(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) Logout(ctx context.Context, traceID string, req *authpb.LogoutRequest) (*authpb.LogoutResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.Logout(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to logout: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) ListSessions(ctx context.Context, traceID string, req *authpb.ListSessionsRequest) (*authpb.ListSessionsResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ListSessions(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) IssueCarToken(ctx context.Context, traceID string, req *authpb.IssueCarTokenRequest) (*authpb.IssueCarTokenResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.IssueCarToken(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue car token: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) RevokeCarToken(ctx context.Context, traceID string, req *authpb.RevokeCarTokenRequest) (*authpb.RevokeCarTokenResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.RevokeCarToken(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke car token: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) UnlockUser(ctx context.Context, traceID string, req *authpb.UnlockUserRequest) (*authpb.UnlockUserResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.UnlockUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unlock user: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) ListUsers(ctx context.Context, traceID string, req *authpb.ListUsersRequest) (*authpb.ListUsersResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ListUsers(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) GetUser(ctx context.Context, traceID string, req *authpb.GetUserRequest) (*authpb.User, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.GetUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) UpdateUser(ctx context.Context, traceID string, req *authpb.UpdateUserRequest) (*authpb.User, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.UpdateUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) DisableUser(ctx context.Context, traceID string, req *authpb.DisableUserRequest) (*authpb.User, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.DisableUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to disable user: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) DeleteUser(ctx context.Context, traceID string, req *authpb.DeleteUserRequest) (*authpb.DeleteUserResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.DeleteUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) ChangePassword(ctx context.Context, traceID string, req *authpb.ChangePasswordRequest) (*authpb.ChangePasswordResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ChangePassword(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change password: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) RequestPasswordReset(ctx context.Context, traceID string, req *authpb.RequestPasswordResetRequest) (*authpb.RequestPasswordResetResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.RequestPasswordReset(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request password reset: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) ConfirmPasswordReset(ctx context.Context, traceID string, req *authpb.ConfirmPasswordResetRequest) (*authpb.ConfirmPasswordResetResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ConfirmPasswordReset(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm password reset: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) EnrollTOTP(ctx context.Context, traceID string, req *authpb.EnrollTOTPRequest) (*authpb.EnrollTOTPResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.EnrollTOTP(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enroll totp: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) ActivateTOTP(ctx context.Context, traceID string, req *authpb.ActivateTOTPRequest) (*authpb.ActivateTOTPResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ActivateTOTP(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate totp: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) VerifyTOTP(ctx context.Context, traceID string, req *authpb.VerifyTOTPRequest) (*authpb.LoginResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.VerifyTOTP(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify totp: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) CreateAPIKey(ctx context.Context, traceID string, req *authpb.CreateAPIKeyRequest) (*authpb.CreateAPIKeyResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.CreateAPIKey(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) ListAPIKeys(ctx context.Context, traceID string, req *authpb.ListAPIKeysRequest) (*authpb.ListAPIKeysResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.ListAPIKeys(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) RevokeAPIKey(ctx context.Context, traceID string, req *authpb.RevokeAPIKeyRequest) (*authpb.ApiKey, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.RevokeAPIKey(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return response, nil
+}
+
+func (c *AuthClient) AuthenticateAPIKey(ctx context.Context, traceID string, req *authpb.AuthenticateAPIKeyRequest) (*authpb.ApiKey, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	md := metadata.Pairs("x-trace-id", traceID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	response, err := c.client.AuthenticateAPIKey(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+	return response, nil
+}
+
 func (c *AuthClient) Close() error {
 	if c.conn != nil {
 		return c.conn.Close()
@@ -60,27 +60,7 @@ func (c *TelemetryClient) PutTelemetry(ctx context.Context, traceID string, carI
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	if err := ValidateCarData(data); err != nil {
-		log.Error("invalid car data", "error", err)
-		return nil, err
-	}
-
-	req := &telemetrypb.PutRequest{
-		Brand:             data.Brand,
-		Model:             data.Model,
-		YearOfManufacture: data.YearOfManufacture,
-		Odo:               data.Odo,
-		Lat:               data.Lat,
-		Lon:               data.Lon,
-		Fuel:              data.Fuel,
-		FuelType:          data.FuelType,
-		Speed:             data.Speed,
-		EngineOn:          data.EngineOn,
-		Locked:            data.Locked,
-		Activated:         data.Activated,
-		Rpm:               data.Rpm,
-		Handbrake:         data.Handbrake,
-	}
+	req := ToPutRequest(data)
 	log.Info("request prepared, calling PutTelemetry on gRPC client")
 
 	response, err := c.client.PutTelemetry(ctx, req)
@@ -92,64 +72,75 @@ func (c *TelemetryClient) PutTelemetry(ctx context.Context, traceID string, carI
 	return response, nil
 }
 
-func (c *TelemetryClient) Close() error {
-	return c.conn.Close()
-}
-
-func ValidateCarData(data *model.CarData) error {
-	if data == nil {
-		return fmt.Errorf("car data cannot be nil")
-	}
-	if data.Brand == "" {
-		return fmt.Errorf("brand is required")
-	}
-	if len(data.Brand) > 100 {
-		return fmt.Errorf("brand too long")
+func (c *TelemetryClient) PutTelemetryBatch(ctx context.Context, traceID string, carID string, items []*model.CarData) (*telemetrypb.PutBatchResponse, error) {
+	log := c.log.With(
+		"module", "telemetry.client",
+		"function", "PutTelemetryBatch",
+		"car_id", carID,
+		"trace_id", traceID,
+	)
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
 	}
 
-	if data.Model == "" {
-		return fmt.Errorf("model is required")
-	}
-	if len(data.Model) > 100 {
-		return fmt.Errorf("model too long")
-	}
+	md := metadata.Pairs("trace_id", traceID, "car_id", carID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
-	currentYear := time.Now().Year()
-	if data.YearOfManufacture < 1900 || data.YearOfManufacture > int32(currentYear+1) {
-		return fmt.Errorf("year of manufacture must be between 1900 and %d", currentYear+1)
+	req := &telemetrypb.PutBatchRequest{Items: make([]*telemetrypb.PutRequest, len(items))}
+	for i, data := range items {
+		req.Items[i] = ToPutRequest(data)
 	}
+	log.Info("calling PutTelemetryBatch on gRPC client", "items", len(items))
 
-	if data.Odo < 0 || data.Odo > 1000000 {
-		return fmt.Errorf("odometer must be between 0 and 1,000,000 km")
+	response, err := c.client.PutTelemetryBatch(ctx, req)
+	if err != nil {
+		log.Error("error calling PutTelemetryBatch on gRPC client", "error", err)
+		return nil, fmt.Errorf("failed to send telemetry batch: %w", err)
 	}
+	return response, nil
+}
 
-	if data.Lat < -90 || data.Lat > 90 {
-		return fmt.Errorf("latitude must be between -90 and 90")
-	}
-	if data.Lon < -180 || data.Lon > 180 {
-		return fmt.Errorf("longitude must be between -180 and 180")
+// OpenStream opens a long-lived StreamTelemetry stream for one car, it lives as long as ctx.
+func (c *TelemetryClient) OpenStream(ctx context.Context, traceID string, carID string) (telemetrypb.TelemetryService_StreamTelemetryClient, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("traceID cannot be empty")
 	}
 
-	if data.Fuel < 0 || data.Fuel > 100 {
-		return fmt.Errorf("fuel level must be between 0 and 100 percent")
-	}
+	md := metadata.Pairs("trace_id", traceID, "car_id", carID)
+	ctx = metadata.NewOutgoingContext(ctx, md)
 
-	validFuelTypes := map[string]bool{
-		"diesel": true,
-		"92":     true,
-		"95":     true,
-		"98":     true,
-	}
-	if !validFuelTypes[data.FuelType] {
-		return fmt.Errorf("fuel type must be one of: diesel, 92, 95, 98")
+	stream, err := c.client.StreamTelemetry(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open telemetry stream: %w", err)
 	}
+	return stream, nil
+}
 
-	if data.Speed < 0 || data.Speed > 300 {
-		return fmt.Errorf("speed must be between 0 and 300 km/h")
+func ToPutRequest(data *model.CarData) *telemetrypb.PutRequest {
+	return &telemetrypb.PutRequest{
+		Brand:             data.Brand,
+		Model:             data.Model,
+		YearOfManufacture: data.YearOfManufacture,
+		Odo:               data.Odo,
+		Lat:               data.Lat,
+		Lon:               data.Lon,
+		Fuel:              data.Fuel,
+		FuelType:          data.FuelType,
+		Speed:             data.Speed,
+		EngineOn:          data.EngineOn,
+		Locked:            data.Locked,
+		Activated:         data.Activated,
+		Rpm:               data.Rpm,
+		Handbrake:         data.Handbrake,
+		RecordedAt:        data.RecordedAt,
+		Sequence:          data.Sequence,
+		IdempotencyKey:    data.IdempotencyKey,
 	}
+}
 
-	if data.Rpm < 0 || data.Rpm > 10000 {
-		return fmt.Errorf("RPM must be between 0 and 10,000")
-	}
-	return nil
+func (c *TelemetryClient) Close() error {
+	return c.conn.Close()
 }
+
@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/jekiti/citydrive/auth/internal/app"
+	"github.com/jekiti/citydrive/auth/internal/models"
 	"github.com/jekiti/citydrive/pkg/logger"
 )
 
@@ -16,13 +19,28 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	superuser := len(os.Args) > 1 && os.Args[1] == "create-superuser"
+
 	app, err := app.NewApp(log, "./.env")
 	if err != nil {
 		log.Error("failed to create app:", slog.Any("error", err))
+		if superuser {
+			os.Exit(1)
+		}
 		return
 	}
 	defer app.Close()
 
+	if superuser {
+		// provisioning scripts rely on the exit status
+		if err := createSuperuser(ctx, app, os.Args[2:]); err != nil {
+			log.Error("failed to create superuser:", slog.Any("error", err))
+			app.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.Info("starting app...")
 	if err := app.Run(ctx); err != nil {
 		log.Error("failed to run app:", slog.Any("error", err))
@@ -31,3 +49,20 @@ func main() {
 	log.Info("app stopped")
 }
 
+// createSuperuser bootstraps the first superuser: auth create-superuser -email ... -name ... -surname ... -department ...
+func createSuperuser(ctx context.Context, app *app.App, args []string) error {
+	var req models.RegisterRequest
+	flags := flag.NewFlagSet("create-superuser", flag.ExitOnError)
+	flags.StringVar(&req.Email, "email", "", "superuser email")
+	flags.StringVar(&req.Name, "name", "", "first name")
+	flags.StringVar(&req.Surname, "surname", "", "last name")
+	flags.StringVar(&req.Department, "department", "", "department")
+	flags.Parse(args)
+
+	resp, err := app.CreateSuperuser(ctx, &req)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("superuser %s created, id %d, password %s\n", req.Email, resp.ID, resp.Password)
+	return nil
+}
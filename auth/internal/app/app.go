@@ -3,22 +3,33 @@ package app
 import (
 	"context"
 	"log/slog"
+	"net/http"
 
 	"github.com/jekiti/citydrive/auth/internal/config"
 	"github.com/jekiti/citydrive/auth/internal/handler"
+	"github.com/jekiti/citydrive/auth/internal/models"
+	"github.com/jekiti/citydrive/auth/internal/notifier"
 	authrepository "github.com/jekiti/citydrive/auth/internal/repository"
+	"github.com/jekiti/citydrive/auth/internal/secretbox"
 	"github.com/jekiti/citydrive/auth/internal/server"
 	authservice "github.com/jekiti/citydrive/auth/internal/service"
+	"github.com/jekiti/citydrive/auth/internal/signer"
 	"github.com/jekiti/citydrive/auth/postgres"
+	authredis "github.com/jekiti/citydrive/auth/redis"
 	auth "github.com/jekiti/citydrive/gen/proto/auth"
+	goredis "github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 )
 
 type App struct {
 	log      *slog.Logger
 	db       *postgres.Postgres
+	redis    *goredis.Client
 	port     string
+	httpPort string
+	jwks     http.Handler
 	register func(*grpc.Server)
+	users    authservice.UserService
 }
 
 func NewApp(log *slog.Logger, envPath string) (*App, error) {
@@ -29,23 +40,119 @@ func NewApp(log *slog.Logger, envPath string) (*App, error) {
 		return nil, err
 	}
 
+	redis, err := authredis.NewRedis(cfg.Redis)
+	if err != nil {
+		log.Error("failed to connect to redis:", slog.Any("error", err))
+		db.Close()
+		return nil, err
+	}
+
+	var userSigner, carSigner signer.Signer
+	var jwks http.Handler
+	if cfg.JWT.Algorithm == signer.AlgHS256 {
+		userSigner = signer.NewHMAC(cfg.JWT.SecretKey)
+		carSigner = signer.NewHMAC(cfg.JWT.CarSecretKey)
+	} else {
+		keys, err := signer.LoadKeySet(cfg.JWT.Algorithm, cfg.JWT.PrivateKeyPath, cfg.JWT.PublicKeyPath)
+		if err != nil {
+			log.Error("failed to load signing keys:", slog.Any("error", err))
+			redis.Close()
+			db.Close()
+			return nil, err
+		}
+		log.Info("signing keys loaded", "alg", cfg.JWT.Algorithm, "kid", keys.KeyID())
+		userSigner = keys
+		carSigner = keys
+		jwks = handler.NewJWKSHandler(keys, log)
+	}
+
+	var box *secretbox.Box
+	if cfg.MFA.EncryptionKey != "" {
+		box, err = secretbox.New(cfg.MFA.EncryptionKey)
+		if err != nil {
+			log.Error("failed to init totp encryption:", slog.Any("error", err))
+			redis.Close()
+			db.Close()
+			return nil, err
+		}
+	} else {
+		log.Warn("TOTP_ENCRYPTION_KEY is not set, two-factor enrollment is disabled")
+	}
+
 	pool := db.Master()
 	repo := authrepository.NewUserRepository(pool, log)
-	service := authservice.NewUserService(repo, log, cfg.JWT.SecretKey)
-	authHandler := handler.NewAuthHandler(service, log)
+	roleRepo := authrepository.NewRoleRepository(pool, log)
+	sessionRepo := authrepository.NewSessionRepository(pool, log)
+	carRepo := authrepository.NewCarRepository(pool, log)
+	revocations := authrepository.NewRevocationRepository(redis, log)
+	mfaRepo := authrepository.NewMFARepository(redis, log)
+	attempts := authrepository.NewLoginAttemptRepository(redis, log)
+	limiter := authservice.NewLoginLimiter(attempts, cfg.Login, log)
+	totpService := authservice.NewTOTPService(repo, mfaRepo, box, log, cfg.MFA)
+	service := authservice.NewUserService(repo, roleRepo, sessionRepo, revocations, limiter, log,
+		userSigner, cfg.JWT.Expiration, cfg.JWT.RefreshTTL,
+		cfg.App.DefaultRole,
+		totpService, mfaRepo, cfg.MFA)
+	carService := authservice.NewCarTokenService(carRepo, revocations, log, carSigner, cfg.JWT.CarExpiration)
+	var resetNotifier notifier.Notifier
+	if cfg.Notifier.Kind == "smtp" {
+		resetNotifier = notifier.NewSMTPNotifier(cfg.Notifier, log)
+	} else {
+		// a reset link in the logs is as good as the password, it is logged only in development
+		development := cfg.App.Env == "development"
+		if !development {
+			log.Warn("NOTIFIER is not smtp, password reset links are not delivered")
+		}
+		resetNotifier = notifier.NewLogNotifier(log, development)
+	}
+	passwordService := authservice.NewPasswordService(repo, authrepository.NewPasswordResetRepository(pool, log),
+		sessionRepo, revocations, limiter, attempts, resetNotifier, log, cfg.Password)
+	apiKeyService := authservice.NewAPIKeyService(authrepository.NewAPIKeyRepository(pool, log), roleRepo, log, cfg.APIKey)
+	authHandler := handler.NewAuthHandler(service, carService, passwordService, totpService, apiKeyService, log)
 	reg := func(s *grpc.Server) {
 		auth.RegisterAuthServiceServer(s, authHandler)
 	}
 	return &App{
 		log:      log,
 		db:       db,
+		redis:    redis,
 		port:     cfg.Server.GRPCPort,
+		httpPort: cfg.Server.HTTPPort,
+		jwks:     jwks,
 		register: reg,
+		users:    service,
 	}, nil
 }
 
 func (a *App) Run(ctx context.Context) error {
-	return server.Run(ctx, a.log, a.port, a.register)
+	if a.jwks == nil {
+		return server.Run(ctx, a.log, a.port, a.register)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	httpErrCh := make(chan error, 1)
+	go func() {
+		err := server.RunHTTP(ctx, a.log, a.httpPort, a.jwks)
+		if err != nil {
+			cancel()
+		}
+		httpErrCh <- err
+	}()
+
+	err := server.Run(ctx, a.log, a.port, a.register)
+	cancel()
+	if httpErr := <-httpErrCh; err == nil {
+		err = httpErr
+	}
+	return err
+}
+
+// CreateSuperuser registers a superuser, Register over gRPC never grants the role on its own.
+func (a *App) CreateSuperuser(ctx context.Context, req *models.RegisterRequest) (*models.RegisterResponse, error) {
+	req.Role = models.RoleSuperuser
+	return a.users.Register(ctx, req)
 }
 
 func (a *App) Close() {
@@ -55,4 +162,11 @@ func (a *App) Close() {
 		a.db.Close()
 		a.log.Info("db connection closed")
 	}
+	if a.redis != nil {
+		a.log.Info("redis connection closing...")
+		if err := a.redis.Close(); err != nil {
+			a.log.Error("failed to close redis:", slog.Any("error", err))
+		}
+		a.log.Info("redis connection closed")
+	}
 }
@@ -12,12 +12,19 @@ import (
 type Config struct {
 	Server   ServerConfig
 	Database DatabaseConfig
+	Redis    RedisConfig
 	JWT      JWTConfig
+	Login    LoginConfig
+	Password PasswordConfig
+	Notifier NotifierConfig
+	MFA      MFAConfig
+	APIKey   APIKeyConfig
 	App      AppConfig
 }
 
 type ServerConfig struct {
 	GRPCPort string
+	HTTPPort string
 }
 
 type DatabaseConfig struct {
@@ -31,26 +38,80 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+type RedisConfig struct {
+	Host     string
+	Port     string
+	Password string
+	DB       int
+}
+
 type JWTConfig struct {
-	Algorithm  string
-	SecretKey  string
-	Expiration time.Duration
-	PublicKeyPath string
-	JWKSURL       string
+	Algorithm      string
+	SecretKey      string
+	Expiration     time.Duration
+	PrivateKeyPath string
+	PublicKeyPath  string
+	JWKSURL        string
+	RefreshTTL     time.Duration
+	CarSecretKey   string
+	CarExpiration  time.Duration
+}
+
+type LoginConfig struct {
+	MaxAttempts     int
+	IPMaxAttempts   int
+	AttemptWindow   time.Duration
+	BackoffBase     time.Duration
+	BackoffMax      time.Duration
+	LockoutDuration time.Duration
+}
+
+type PasswordConfig struct {
+	MinLength int
+	ResetTTL  time.Duration
+	ResetURL  string
+	// reset requests allowed per email and per IP within ResetWindow
+	ResetMaxRequests   int
+	ResetIPMaxRequests int
+	ResetWindow        time.Duration
+}
+
+type NotifierConfig struct {
+	Kind         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+}
+
+type APIKeyConfig struct {
+	DefaultRateLimit int
+	MaxRateLimit     int
+}
+
+type MFAConfig struct {
+	EncryptionKey     string
+	Issuer            string
+	ChallengeTTL      time.Duration
+	MaxAttempts       int
+	RecoveryCodeCount int
 }
 
 type AppConfig struct {
-	Env      string
-	LogLevel string
+	Env         string
+	LogLevel    string
+	DefaultRole string
 }
 
 func LoadConfig(path string) *Config {
 	if err := godotenv.Load(path); err != nil {
 		log.Printf("Warning: could not load .env file from %s: %v", path, err)
 	}
-	return &Config{
+	cfg := &Config{
 		Server: ServerConfig{
 			GRPCPort: mustGet("GRPC_PORT"),
+			HTTPPort: getDefault("JWKS_HTTP_PORT", "8081"),
 		},
 		Database: DatabaseConfig{
 			URL:      mustGet("DB_URL"),
@@ -62,18 +123,79 @@ func LoadConfig(path string) *Config {
 			MaxConn:  mustGetInt("DB_MAX_CONN"),
 			SSLMode:  mustGet("DB_SSL_MODE"),
 		},
+		Redis: RedisConfig{
+			Host:     getDefault("REDIS_HOST", "localhost"),
+			Port:     getDefault("REDIS_PORT", "6379"),
+			Password: getDefault("REDIS_PASSWORD", ""),
+			DB:       getIntDefault("REDIS_DB", 0),
+		},
 		JWT: JWTConfig{
-			Algorithm:     getDefault("JWT_ALG", "HS256"),
-			SecretKey:     mustGet("JWT_SECRET_KEY"),
-			Expiration:    mustGetDuration("JWT_EXPIRATION"),
-			PublicKeyPath: getDefault("JWT_PUBLIC_KEY_PATH", ""),
-			JWKSURL:       getDefault("AUTH_JWKS_URL", ""),
+			Algorithm:      getDefault("JWT_ALG", "HS256"),
+			SecretKey:      getDefault("JWT_SECRET_KEY", ""),
+			Expiration:     mustGetDuration("JWT_EXPIRATION"),
+			PrivateKeyPath: getDefault("JWT_PRIVATE_KEY_PATH", ""),
+			PublicKeyPath:  getDefault("JWT_PUBLIC_KEY_PATH", ""),
+			JWKSURL:        getDefault("AUTH_JWKS_URL", ""),
+			RefreshTTL:     getDurationDefault("JWT_REFRESH_EXPIRATION", "720h"),
+			CarSecretKey:   getDefault("JWT_CAR_SECRET_KEY", ""),
+			CarExpiration:  getDurationDefault("JWT_CAR_EXPIRATION", "720h"),
+		},
+		Login: LoginConfig{
+			MaxAttempts:     getIntDefault("LOGIN_MAX_ATTEMPTS", 5),
+			IPMaxAttempts:   getIntDefault("LOGIN_IP_MAX_ATTEMPTS", 50),
+			AttemptWindow:   getDurationDefault("LOGIN_ATTEMPT_WINDOW", "15m"),
+			BackoffBase:     getDurationDefault("LOGIN_BACKOFF_BASE", "1s"),
+			BackoffMax:      getDurationDefault("LOGIN_BACKOFF_MAX", "1m"),
+			LockoutDuration: getDurationDefault("LOGIN_LOCKOUT_DURATION", "15m"),
+		},
+		Password: PasswordConfig{
+			MinLength:          getIntDefault("PASSWORD_MIN_LENGTH", 10),
+			ResetTTL:           getDurationDefault("PASSWORD_RESET_TTL", "1h"),
+			ResetURL:           getDefault("PASSWORD_RESET_URL", "http://localhost:8080/reset-password?token="),
+			ResetMaxRequests:   getIntDefault("PASSWORD_RESET_MAX_REQUESTS", 3),
+			ResetIPMaxRequests: getIntDefault("PASSWORD_RESET_IP_MAX_REQUESTS", 20),
+			ResetWindow:        getDurationDefault("PASSWORD_RESET_WINDOW", "1h"),
+		},
+		Notifier: NotifierConfig{
+			Kind:         getDefault("NOTIFIER", "log"),
+			SMTPHost:     getDefault("SMTP_HOST", "localhost"),
+			SMTPPort:     getDefault("SMTP_PORT", "25"),
+			SMTPUsername: getDefault("SMTP_USERNAME", ""),
+			SMTPPassword: getDefault("SMTP_PASSWORD", ""),
+			SMTPFrom:     getDefault("SMTP_FROM", "noreply@citydrive.local"),
+		},
+		MFA: MFAConfig{
+			EncryptionKey:     getDefault("TOTP_ENCRYPTION_KEY", ""),
+			Issuer:            getDefault("TOTP_ISSUER", "CityDrive"),
+			ChallengeTTL:      getDurationDefault("MFA_CHALLENGE_TTL", "5m"),
+			MaxAttempts:       getIntDefault("MFA_MAX_ATTEMPTS", 5),
+			RecoveryCodeCount: getIntDefault("MFA_RECOVERY_CODES", 10),
+		},
+		APIKey: APIKeyConfig{
+			DefaultRateLimit: getIntDefault("API_KEY_DEFAULT_RATE_LIMIT", 600),
+			MaxRateLimit:     getIntDefault("API_KEY_MAX_RATE_LIMIT", 6000),
 		},
 		App: AppConfig{
-			Env:      mustGet("ENV"),
-			LogLevel: mustGet("LOG_LEVEL"),
+			Env:         mustGet("ENV"),
+			LogLevel:    mustGet("LOG_LEVEL"),
+			DefaultRole: getDefault("AUTH_DEFAULT_ROLE", "viewer"),
 		},
 	}
+
+	switch cfg.JWT.Algorithm {
+	case "HS256":
+		if cfg.JWT.SecretKey == "" || cfg.JWT.CarSecretKey == "" {
+			log.Fatal("JWT_SECRET_KEY and JWT_CAR_SECRET_KEY are required for HS256")
+		}
+	case "RS256", "EdDSA":
+		if cfg.JWT.PrivateKeyPath == "" {
+			log.Fatalf("JWT_PRIVATE_KEY_PATH is required for %s", cfg.JWT.Algorithm)
+		}
+	default:
+		log.Fatalf("unsupported JWT_ALG: %s", cfg.JWT.Algorithm)
+	}
+
+	return cfg
 }
 
 func mustGet(key string) string {
@@ -93,6 +215,18 @@ func getDefault(key, def string) string {
 
 	return v
 }
+func getIntDefault(key string, def int) int {
+	s := os.Getenv(key)
+	if s == "" {
+		return def
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		log.Fatalf("bad int %s: %v", key, err)
+	}
+	return i
+}
+
 func mustGetInt(key string) int {
 	s := mustGet(key)
 	i, err := strconv.Atoi(s)
@@ -109,3 +243,16 @@ func mustGetDuration(key string) time.Duration {
 	}
 	return d
 }
+
+func getDurationDefault(key, def string) time.Duration {
+	s := os.Getenv(key)
+	if s == "" {
+		d, _ := time.ParseDuration(def)
+		return d
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		log.Fatalf("bad duration %s: %v", key, err)
+	}
+	return d
+}
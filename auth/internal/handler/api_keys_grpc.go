@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jekiti/citydrive/auth/internal/models"
+	auth "github.com/jekiti/citydrive/gen/proto/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *AuthHandler) CreateAPIKey(ctx context.Context, req *auth.CreateAPIKeyRequest) (*auth.CreateAPIKeyResponse, error) {
+	op := "auth.handler.CreateAPIKey"
+	log := h.log.With("op", op)
+	log.Info("CreateAPIKey request received", slog.String("name", req.Name), slog.Int64("created_by", req.CreatedBy))
+
+	rawKey, key, err := h.apiKeyService.CreateAPIKey(ctx, &models.CreateAPIKeyRequest{
+		Name:        req.Name,
+		Permissions: req.Permissions,
+		RateLimit:   int(req.RateLimit),
+		TTL:         time.Duration(req.ExpiresIn) * time.Second,
+		CreatedBy:   req.CreatedBy,
+	})
+	if err != nil {
+		log.Error("error in CreateAPIKey handler:", slog.Any("error", err))
+		return nil, apiKeyError(err)
+	}
+	return &auth.CreateAPIKeyResponse{ApiKey: rawKey, Key: toProtoAPIKey(key)}, nil
+}
+
+func (h *AuthHandler) ListAPIKeys(ctx context.Context, req *auth.ListAPIKeysRequest) (*auth.ListAPIKeysResponse, error) {
+	op := "auth.handler.ListAPIKeys"
+	log := h.log.With("op", op)
+	log.Info("ListAPIKeys request received")
+
+	keys, err := h.apiKeyService.ListAPIKeys(ctx)
+	if err != nil {
+		log.Error("error in ListAPIKeys handler:", slog.Any("error", err))
+		return nil, apiKeyError(err)
+	}
+	resp := &auth.ListAPIKeysResponse{Keys: make([]*auth.ApiKey, 0, len(keys))}
+	for i := range keys {
+		resp.Keys = append(resp.Keys, toProtoAPIKey(&keys[i]))
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) RevokeAPIKey(ctx context.Context, req *auth.RevokeAPIKeyRequest) (*auth.ApiKey, error) {
+	op := "auth.handler.RevokeAPIKey"
+	log := h.log.With("op", op)
+	log.Info("RevokeAPIKey request received", slog.String("key_id", req.Id))
+
+	key, err := h.apiKeyService.RevokeAPIKey(ctx, req.Id)
+	if err != nil {
+		log.Error("error in RevokeAPIKey handler:", slog.Any("error", err))
+		return nil, apiKeyError(err)
+	}
+	return toProtoAPIKey(key), nil
+}
+
+func (h *AuthHandler) AuthenticateAPIKey(ctx context.Context, req *auth.AuthenticateAPIKeyRequest) (*auth.ApiKey, error) {
+	op := "auth.handler.AuthenticateAPIKey"
+	log := h.log.With("op", op)
+
+	key, err := h.apiKeyService.AuthenticateAPIKey(ctx, req.ApiKey, req.ClientIp)
+	if err != nil {
+		log.Warn("api key rejected", slog.Any("error", err))
+		return nil, apiKeyError(err)
+	}
+	return toProtoAPIKey(key), nil
+}
+
+func apiKeyError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrInvalidAPIKey):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrAPIKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, models.ErrValidationFailed):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func toProtoAPIKey(key *models.APIKey) *auth.ApiKey {
+	resp := &auth.ApiKey{
+		Id:          key.ID,
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Permissions: key.Permissions,
+		RateLimit:   int32(key.RateLimit),
+		CreatedAt:   key.CreatedAt.Unix(),
+	}
+	if key.CreatedBy != nil {
+		resp.CreatedBy = *key.CreatedBy
+	}
+	if key.ExpiresAt != nil {
+		resp.ExpiresAt = key.ExpiresAt.Unix()
+	}
+	if key.LastUsedAt != nil {
+		resp.LastUsedAt = key.LastUsedAt.Unix()
+	}
+	if key.LastUsedIP != nil {
+		resp.LastUsedIp = *key.LastUsedIP
+	}
+	if key.RevokedAt != nil {
+		resp.RevokedAt = key.RevokedAt.Unix()
+	}
+	return resp
+}
@@ -2,21 +2,42 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/jekiti/citydrive/auth/internal/models"
 	authservice "github.com/jekiti/citydrive/auth/internal/service"
 	auth "github.com/jekiti/citydrive/gen/proto/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type AuthHandler struct {
 	auth.UnimplementedAuthServiceServer
-	service authservice.UserService
-	log     *slog.Logger
+	service         authservice.UserService
+	carService      authservice.CarTokenService
+	passwordService authservice.PasswordService
+	totpService     authservice.TOTPService
+	apiKeyService   authservice.APIKeyService
+	log             *slog.Logger
 }
 
-func NewAuthHandler(service authservice.UserService, logger *slog.Logger) *AuthHandler {
-	return &AuthHandler{service: service, log: logger}
+func NewAuthHandler(service authservice.UserService,
+	carService authservice.CarTokenService,
+	passwordService authservice.PasswordService,
+	totpService authservice.TOTPService,
+	apiKeyService authservice.APIKeyService,
+	logger *slog.Logger) *AuthHandler {
+	return &AuthHandler{
+		service:         service,
+		carService:      carService,
+		passwordService: passwordService,
+		totpService:     totpService,
+		apiKeyService:   apiKeyService,
+		log:             logger,
+	}
 }
 
 func (h *AuthHandler) Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error) {
@@ -28,15 +49,17 @@ func (h *AuthHandler) Register(ctx context.Context, req *auth.RegisterRequest) (
 		Name:       req.Name,
 		Surname:    req.Surname,
 		Department: req.Department,
+		Role:       req.Role,
 	}
 	res, err := h.service.Register(ctx, modelReq)
 	if err != nil {
 		log.Error("error in Register handler:", slog.Any("error", err))
-		return nil, err
+		return nil, userError(err)
 	}
 	return &auth.RegisterResponse{
 		Password: res.Password,
 		Id:       res.ID,
+		Role:     res.Role,
 	}, nil
 }
 
@@ -47,13 +70,168 @@ func (h *AuthHandler) Login(ctx context.Context, req *auth.LoginRequest) (*auth.
 	modelReq := &models.LoginRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		ClientIP: req.ClientIp,
 	}
 	res, err := h.service.Login(ctx, modelReq)
 	if err != nil {
 		log.Error("error in Login handler:", slog.Any("error", err))
-		return nil, err
+		return nil, userError(err)
+	}
+	return toProtoLoginResponse(res), nil
+}
+
+func (h *AuthHandler) Refresh(ctx context.Context, req *auth.RefreshRequest) (*auth.RefreshResponse, error) {
+	op := "auth.handler.Refresh"
+	log := h.log.With("op", op)
+	log.Info("Refresh request received")
+	res, err := h.service.Refresh(ctx, &models.RefreshRequest{RefreshToken: req.RefreshToken})
+	if err != nil {
+		log.Error("error in Refresh handler:", slog.Any("error", err))
+		return nil, sessionError(err)
+	}
+	return &auth.RefreshResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		ExpiresIn:    res.ExpiresIn,
+	}, nil
+}
+
+func (h *AuthHandler) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
+	op := "auth.handler.Logout"
+	log := h.log.With("op", op)
+	log.Info("Logout request received", slog.Int64("user_id", req.UserId), slog.String("session_id", req.SessionId))
+	err := h.service.Logout(ctx, &models.LogoutRequest{
+		UserID:    req.UserId,
+		SessionID: req.SessionId,
+	})
+	if err != nil {
+		log.Error("error in Logout handler:", slog.Any("error", err))
+		return nil, sessionError(err)
+	}
+	return &auth.LogoutResponse{SessionId: req.SessionId}, nil
+}
+
+func (h *AuthHandler) ListSessions(ctx context.Context, req *auth.ListSessionsRequest) (*auth.ListSessionsResponse, error) {
+	op := "auth.handler.ListSessions"
+	log := h.log.With("op", op)
+	log.Info("ListSessions request received", slog.Int64("user_id", req.UserId))
+	sessions, err := h.service.ListSessions(ctx, req.UserId)
+	if err != nil {
+		log.Error("error in ListSessions handler:", slog.Any("error", err))
+		return nil, sessionError(err)
+	}
+	resp := &auth.ListSessionsResponse{}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &auth.Session{
+			Id:         session.ID,
+			CreatedAt:  session.CreatedAt.Unix(),
+			LastUsedAt: session.LastUsedAt.Unix(),
+			ExpiresAt:  session.ExpiresAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) IssueCarToken(ctx context.Context, req *auth.IssueCarTokenRequest) (*auth.IssueCarTokenResponse, error) {
+	op := "auth.handler.IssueCarToken"
+	log := h.log.With("op", op)
+	log.Info("IssueCarToken request received", slog.String("car_id", req.CarId))
+	res, err := h.carService.IssueCarToken(ctx, &models.IssueCarTokenRequest{CarID: req.CarId})
+	if err != nil {
+		log.Error("error in IssueCarToken handler:", slog.Any("error", err))
+		return nil, carTokenError(err)
 	}
-	return &auth.LoginResponse{
+	return &auth.IssueCarTokenResponse{
+		CarId:       res.CarID,
 		AccessToken: res.AccessToken,
+		TokenId:     res.TokenID,
+		ExpiresAt:   res.ExpiresAt,
+		Rotated:     res.Rotated,
 	}, nil
 }
+
+func (h *AuthHandler) RevokeCarToken(ctx context.Context, req *auth.RevokeCarTokenRequest) (*auth.RevokeCarTokenResponse, error) {
+	op := "auth.handler.RevokeCarToken"
+	log := h.log.With("op", op)
+	log.Info("RevokeCarToken request received", slog.String("car_id", req.CarId))
+	res, err := h.carService.RevokeCarToken(ctx, &models.RevokeCarTokenRequest{CarID: req.CarId})
+	if err != nil {
+		log.Error("error in RevokeCarToken handler:", slog.Any("error", err))
+		return nil, carTokenError(err)
+	}
+	return &auth.RevokeCarTokenResponse{
+		CarId:   res.CarID,
+		TokenId: res.TokenID,
+	}, nil
+}
+
+func (h *AuthHandler) UnlockUser(ctx context.Context, req *auth.UnlockUserRequest) (*auth.UnlockUserResponse, error) {
+	op := "auth.handler.UnlockUser"
+	log := h.log.With("op", op)
+	log.Info("UnlockUser request received", slog.String("email", req.Email))
+
+	err := h.service.UnlockUser(ctx, req.Email)
+	if err != nil {
+		log.Error("error in UnlockUser handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return &auth.UnlockUserResponse{Email: req.Email}, nil
+}
+
+func userError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrInvalidCredentials), errors.Is(err, models.ErrInvalidResetToken),
+		errors.Is(err, models.ErrInvalidTOTPCode), errors.Is(err, models.ErrInvalidMFAChallenge):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrTOTPAlreadyEnabled), errors.Is(err, models.ErrTOTPNotEnrolled),
+		errors.Is(err, models.ErrTOTPUnavailable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, models.ErrEmailTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, models.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrValidationFailed), errors.Is(err, models.ErrUnknownRole):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrUserDisabled):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, models.ErrTooManyAttempts):
+		st := status.New(codes.ResourceExhausted, err.Error())
+		var tooMany *models.TooManyAttemptsError
+		if errors.As(err, &tooMany) {
+			if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{
+				RetryDelay: durationpb.New(tooMany.RetryAfter),
+			}); detailErr == nil {
+				st = detailed
+			}
+		}
+		return st.Err()
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func sessionError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrInvalidRefreshToken):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrSessionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrUserDisabled):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func carTokenError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrInvalidCarID):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrCarNotFound), errors.Is(err, models.ErrCarTokenNotIssued):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrCarTokenConflict):
+		return status.Error(codes.Aborted, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
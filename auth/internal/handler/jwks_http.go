@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/jekiti/citydrive/auth/internal/signer"
+)
+
+func NewJWKSHandler(keys *signer.KeySet, log *slog.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		if err := json.NewEncoder(w).Encode(keys.JWKS()); err != nil {
+			log.Error("error writing jwks:", slog.Any("error", err))
+		}
+	})
+	return mux
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jekiti/citydrive/auth/internal/models"
+	auth "github.com/jekiti/citydrive/gen/proto/auth"
+)
+
+func (h *AuthHandler) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error) {
+	op := "auth.handler.ChangePassword"
+	log := h.log.With("op", op)
+	log.Info("ChangePassword request received", slog.Int64("user_id", req.UserId))
+
+	revoked, err := h.passwordService.ChangePassword(ctx, &models.ChangePasswordRequest{
+		UserID:      req.UserId,
+		SessionID:   req.SessionId,
+		OldPassword: req.OldPassword,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		log.Error("error in ChangePassword handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return &auth.ChangePasswordResponse{RevokedSessions: int32(revoked)}, nil
+}
+
+func (h *AuthHandler) RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error) {
+	op := "auth.handler.RequestPasswordReset"
+	log := h.log.With("op", op)
+	log.Info("RequestPasswordReset request received", slog.String("email", req.Email))
+
+	err := h.passwordService.RequestPasswordReset(ctx, req.Email, req.ClientIp)
+	if err != nil {
+		log.Error("error in RequestPasswordReset handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return &auth.RequestPasswordResetResponse{}, nil
+}
+
+func (h *AuthHandler) ConfirmPasswordReset(ctx context.Context, req *auth.ConfirmPasswordResetRequest) (*auth.ConfirmPasswordResetResponse, error) {
+	op := "auth.handler.ConfirmPasswordReset"
+	log := h.log.With("op", op)
+	log.Info("ConfirmPasswordReset request received")
+
+	err := h.passwordService.ConfirmPasswordReset(ctx, &models.ConfirmPasswordResetRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		log.Error("error in ConfirmPasswordReset handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return &auth.ConfirmPasswordResetResponse{}, nil
+}
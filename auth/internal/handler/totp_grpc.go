@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jekiti/citydrive/auth/internal/models"
+	auth "github.com/jekiti/citydrive/gen/proto/auth"
+)
+
+func (h *AuthHandler) EnrollTOTP(ctx context.Context, req *auth.EnrollTOTPRequest) (*auth.EnrollTOTPResponse, error) {
+	op := "auth.handler.EnrollTOTP"
+	log := h.log.With("op", op)
+	log.Info("EnrollTOTP request received", slog.Int64("user_id", req.UserId))
+
+	res, err := h.totpService.EnrollTOTP(ctx, req.UserId)
+	if err != nil {
+		log.Error("error in EnrollTOTP handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return &auth.EnrollTOTPResponse{Secret: res.Secret, OtpauthUrl: res.URL}, nil
+}
+
+func (h *AuthHandler) ActivateTOTP(ctx context.Context, req *auth.ActivateTOTPRequest) (*auth.ActivateTOTPResponse, error) {
+	op := "auth.handler.ActivateTOTP"
+	log := h.log.With("op", op)
+	log.Info("ActivateTOTP request received", slog.Int64("user_id", req.UserId))
+
+	codes, err := h.totpService.ActivateTOTP(ctx, req.UserId, req.Code)
+	if err != nil {
+		log.Error("error in ActivateTOTP handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return &auth.ActivateTOTPResponse{RecoveryCodes: codes}, nil
+}
+
+func (h *AuthHandler) VerifyTOTP(ctx context.Context, req *auth.VerifyTOTPRequest) (*auth.LoginResponse, error) {
+	op := "auth.handler.VerifyTOTP"
+	log := h.log.With("op", op)
+	log.Info("VerifyTOTP request received")
+
+	res, err := h.service.VerifyTOTP(ctx, &models.VerifyTOTPRequest{
+		MFAToken:     req.MfaToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		ClientIP:     req.ClientIp,
+	})
+	if err != nil {
+		log.Error("error in VerifyTOTP handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return toProtoLoginResponse(res), nil
+}
+
+func toProtoLoginResponse(res *models.LoginResponse) *auth.LoginResponse {
+	return &auth.LoginResponse{
+		AccessToken:  res.AccessToken,
+		RefreshToken: res.RefreshToken,
+		ExpiresIn:    res.ExpiresIn,
+		MfaRequired:  res.MFARequired,
+		MfaToken:     res.MFAToken,
+	}
+}
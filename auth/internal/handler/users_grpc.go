@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jekiti/citydrive/auth/internal/models"
+	auth "github.com/jekiti/citydrive/gen/proto/auth"
+)
+
+func (h *AuthHandler) ListUsers(ctx context.Context, req *auth.ListUsersRequest) (*auth.ListUsersResponse, error) {
+	op := "auth.handler.ListUsers"
+	log := h.log.With("op", op)
+	log.Info("ListUsers request received", slog.String("department", req.Department))
+
+	users, total, err := h.service.ListUsers(ctx, models.ListUsersFilter{
+		Limit:      req.Limit,
+		Offset:     req.Offset,
+		Department: req.Department,
+	})
+	if err != nil {
+		log.Error("error in ListUsers handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+
+	resp := &auth.ListUsersResponse{
+		Users: make([]*auth.User, len(users)),
+		Total: total,
+	}
+	for i := range users {
+		resp.Users[i] = toProtoUser(&users[i])
+	}
+	return resp, nil
+}
+
+func (h *AuthHandler) GetUser(ctx context.Context, req *auth.GetUserRequest) (*auth.User, error) {
+	op := "auth.handler.GetUser"
+	log := h.log.With("op", op)
+	log.Info("GetUser request received", slog.Int64("user_id", req.Id))
+
+	user, err := h.service.GetUser(ctx, req.Id)
+	if err != nil {
+		log.Error("error in GetUser handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return toProtoUser(user), nil
+}
+
+func (h *AuthHandler) UpdateUser(ctx context.Context, req *auth.UpdateUserRequest) (*auth.User, error) {
+	op := "auth.handler.UpdateUser"
+	log := h.log.With("op", op)
+	log.Info("UpdateUser request received", slog.Int64("user_id", req.Id))
+
+	user, err := h.service.UpdateUser(ctx, &models.UpdateUserRequest{
+		ID:         req.Id,
+		Name:       req.Name,
+		Surname:    req.Surname,
+		Department: req.Department,
+		Role:       req.Role,
+	})
+	if err != nil {
+		log.Error("error in UpdateUser handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return toProtoUser(user), nil
+}
+
+func (h *AuthHandler) DisableUser(ctx context.Context, req *auth.DisableUserRequest) (*auth.User, error) {
+	op := "auth.handler.DisableUser"
+	log := h.log.With("op", op)
+	log.Info("DisableUser request received", slog.Int64("user_id", req.Id))
+
+	user, err := h.service.DisableUser(ctx, req.Id)
+	if err != nil {
+		log.Error("error in DisableUser handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return toProtoUser(user), nil
+}
+
+func (h *AuthHandler) DeleteUser(ctx context.Context, req *auth.DeleteUserRequest) (*auth.DeleteUserResponse, error) {
+	op := "auth.handler.DeleteUser"
+	log := h.log.With("op", op)
+	log.Info("DeleteUser request received", slog.Int64("user_id", req.Id))
+
+	err := h.service.DeleteUser(ctx, req.Id)
+	if err != nil {
+		log.Error("error in DeleteUser handler:", slog.Any("error", err))
+		return nil, userError(err)
+	}
+	return &auth.DeleteUserResponse{Id: req.Id}, nil
+}
+
+func toProtoUser(user *models.User) *auth.User {
+	resp := &auth.User{
+		Id:         user.ID,
+		Email:      user.Email,
+		Name:       user.Name,
+		Surname:    user.Surname,
+		Department: user.Department,
+		Roles:      user.Roles,
+		CreatedAt:  user.CreatedAt.Unix(),
+		UpdatedAt:  user.UpdatedAt.Unix(),
+	}
+	if user.DisabledAt != nil {
+		resp.DisabledAt = user.DisabledAt.Unix()
+	}
+	return resp
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type APIKey struct {
+	ID          string     `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name"`
+	Prefix      string     `json:"prefix" db:"prefix"`
+	KeyHash     string     `json:"-" db:"key_hash"`
+	Permissions []string   `json:"permissions" db:"permissions"`
+	RateLimit   int        `json:"rate_limit" db:"rate_limit"`
+	CreatedBy   *int64     `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at" db:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at" db:"last_used_at"`
+	LastUsedIP  *string    `json:"last_used_ip" db:"last_used_ip"`
+	RevokedAt   *time.Time `json:"revoked_at" db:"revoked_at"`
+	// set only by GetByHash: the creator still exists and is not disabled
+	CreatorActive bool `json:"-" db:"-"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string        `json:"name"`
+	Permissions []string      `json:"permissions"`
+	RateLimit   int           `json:"rate_limit"`
+	TTL         time.Duration `json:"ttl"`
+	CreatedBy   int64         `json:"created_by"`
+}
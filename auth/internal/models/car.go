@@ -1,11 +1,22 @@
 package models
 
+import "time"
+
 type Car struct {
-	ID   int64  `json:"id"`
-	Brand string `json:"brand"`
-	Model string `json:"model"`
-	Plate string `json:"plate"`
-	Year  int    `json:"year"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
-}
\ No newline at end of file
+	ID                string    `json:"id" db:"id"`
+	Brand             string    `json:"brand" db:"brand"`
+	Model             string    `json:"model" db:"model"`
+	YearOfManufacture int       `json:"year_of_manufacture" db:"year_of_manufacture"`
+	FuelType          string    `json:"fuel_type" db:"fuel_type"`
+	LicensePlate      string    `json:"license_plate" db:"license_plate"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CarCredential struct {
+	CarID     string     `json:"car_id" db:"car_id"`
+	TokenID   string     `json:"token_id" db:"token_id"`
+	IssuedAt  time.Time  `json:"issued_at" db:"issued_at"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
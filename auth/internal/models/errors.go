@@ -0,0 +1,43 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCarNotFound         = errors.New("car not found")
+	ErrInvalidCarID        = errors.New("invalid car id")
+	ErrCarTokenNotIssued   = errors.New("car token not issued")
+	ErrCarTokenConflict    = errors.New("car token was issued concurrently")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrUnknownRole         = errors.New("unknown role")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrEmailTaken          = errors.New("email already taken")
+	ErrValidationFailed    = errors.New("validation failed")
+	ErrUserDisabled        = errors.New("user disabled")
+	ErrTooManyAttempts     = errors.New("too many login attempts")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidResetToken   = errors.New("invalid password reset token")
+	ErrTOTPAlreadyEnabled  = errors.New("totp already enabled")
+	ErrTOTPNotEnrolled     = errors.New("totp not enrolled")
+	ErrTOTPUnavailable     = errors.New("totp is not configured")
+	ErrInvalidTOTPCode     = errors.New("invalid totp code")
+	ErrInvalidMFAChallenge = errors.New("invalid mfa challenge")
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrInvalidAPIKey       = errors.New("invalid api key")
+	ErrPermissionDenied    = errors.New("permission not granted to the caller")
+)
+
+type TooManyAttemptsError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TooManyAttemptsError) Error() string {
+	return ErrTooManyAttempts.Error()
+}
+
+func (e *TooManyAttemptsError) Unwrap() error {
+	return ErrTooManyAttempts
+}
@@ -5,9 +5,61 @@ type RegisterRequest struct {
 	Name       string `json:"name"`
 	Surname    string `json:"surname"`
 	Department string `json:"department"`
+	Role       string `json:"role"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	ClientIP string `json:"client_ip"`
+}
+
+type VerifyTOTPRequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+	ClientIP     string `json:"client_ip"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LogoutRequest struct {
+	UserID    int64  `json:"user_id"`
+	SessionID string `json:"session_id"`
+}
+
+type IssueCarTokenRequest struct {
+	CarID string `json:"car_id"`
+}
+
+type RevokeCarTokenRequest struct {
+	CarID string `json:"car_id"`
+}
+
+type ListUsersFilter struct {
+	Limit      int32  `json:"limit"`
+	Offset     int32  `json:"offset"`
+	Department string `json:"department"`
+}
+
+type UpdateUserRequest struct {
+	ID         int64   `json:"id"`
+	Name       *string `json:"name"`
+	Surname    *string `json:"surname"`
+	Department *string `json:"department"`
+	Role       *string `json:"role"`
+}
+
+type ChangePasswordRequest struct {
+	UserID      int64  `json:"user_id"`
+	SessionID   string `json:"session_id"`
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+type ConfirmPasswordResetRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
 }
@@ -3,8 +3,31 @@ package models
 type RegisterResponse struct {
 	Password string `json:"password"`
 	ID       int64  `json:"id"`
+	Role     string `json:"role"`
 }
 
 type LoginResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	MFARequired  bool   `json:"mfa_required"`
+	MFAToken     string `json:"mfa_token,omitempty"`
+}
+
+type IssueCarTokenResponse struct {
+	CarID       string `json:"car_id"`
 	AccessToken string `json:"access_token"`
+	TokenID     string `json:"token_id"`
+	ExpiresAt   int64  `json:"expires_at"`
+	Rotated     bool   `json:"rotated"`
+}
+
+type RevokeCarTokenResponse struct {
+	CarID   string `json:"car_id"`
+	TokenID string `json:"token_id"`
+}
+
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URL    string `json:"url"`
 }
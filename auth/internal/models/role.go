@@ -0,0 +1,8 @@
+package models
+
+const (
+	RoleViewer     = "viewer"
+	RoleDispatcher = "dispatcher"
+	RoleFleetAdmin = "fleet-admin"
+	RoleSuperuser  = "superuser"
+)
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Session struct {
+	ID               string     `json:"id" db:"id"`
+	UserID           int64      `json:"user_id" db:"user_id"`
+	RefreshTokenHash string     `json:"-" db:"refresh_token_hash"`
+	AccessTokenID    string     `json:"-" db:"access_token_id"`
+	AccessExpiresAt  time.Time  `json:"-" db:"access_expires_at"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt       time.Time  `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
@@ -3,12 +3,17 @@ package models
 import "time"
 
 type User struct {
-	ID           int64     `json:"id" db:"id"`
-	Email        string    `json:"email" db:"email"`
-	Name         string    `json:"name" db:"name"`
-	Surname      string    `json:"surname" db:"surname"`
-	Department   string    `json:"department" db:"department"`
-	PasswordHash string    `json:"-" db:"password_hash"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	ID            int64      `json:"id" db:"id"`
+	Email         string     `json:"email" db:"email"`
+	Name          string     `json:"name" db:"name"`
+	Surname       string     `json:"surname" db:"surname"`
+	Department    string     `json:"department" db:"department"`
+	PasswordHash  string     `json:"-" db:"password_hash"`
+	Roles         []string   `json:"roles" db:"-"`
+	Permissions   []string   `json:"permissions" db:"-"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+	DisabledAt    *time.Time `json:"disabled_at" db:"disabled_at"`
+	TOTPSecret    *string    `json:"-" db:"totp_secret"`
+	TOTPEnabledAt *time.Time `json:"totp_enabled_at" db:"totp_enabled_at"`
 }
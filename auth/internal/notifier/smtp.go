@@ -0,0 +1,57 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/jekiti/citydrive/auth/internal/config"
+)
+
+type smtpNotifier struct {
+	cfg config.NotifierConfig
+	log *slog.Logger
+}
+
+func NewSMTPNotifier(cfg config.NotifierConfig, log *slog.Logger) Notifier {
+	return &smtpNotifier{cfg: cfg, log: log}
+}
+
+func (n *smtpNotifier) SendPasswordReset(ctx context.Context, email, link string) error {
+	op := "auth.notifier.smtp.SendPasswordReset"
+	log := n.log.With("op", op)
+
+	if strings.ContainsAny(email, "\r\n") {
+		return fmt.Errorf("invalid recipient address")
+	}
+
+	msg := strings.Join([]string{
+		"From: " + n.cfg.SMTPFrom,
+		"To: " + email,
+		"Subject: CityDrive password reset",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		"To set a new password follow the link:",
+		link,
+		"",
+		"If you did not request a password reset, ignore this email.",
+	}, "\r\n")
+
+	var auth smtp.Auth
+	if n.cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", n.cfg.SMTPUsername, n.cfg.SMTPPassword, n.cfg.SMTPHost)
+	}
+
+	addr := net.JoinHostPort(n.cfg.SMTPHost, n.cfg.SMTPPort)
+	err := smtp.SendMail(addr, auth, n.cfg.SMTPFrom, []string{email}, []byte(msg))
+	if err != nil {
+		log.Error("error sending password reset email", slog.Any("error", err))
+		return err
+	}
+
+	log.Info("password reset email sent", "email", email)
+	return nil
+}
@@ -0,0 +1,167 @@
+package authrepository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jekiti/citydrive/auth/internal/models"
+)
+
+const apiKeyColumns = `id, name, prefix, key_hash, permissions, rate_limit, created_by,
+	created_at, expires_at, last_used_at, last_used_ip, revoked_at`
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	List(ctx context.Context) ([]models.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	Revoke(ctx context.Context, id string) (*models.APIKey, error)
+	TouchLastUsed(ctx context.Context, id, ip string) error
+}
+
+type apiKeyRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewAPIKeyRepository(db *pgxpool.Pool, log *slog.Logger) APIKeyRepository {
+	return &apiKeyRepository{db: db, log: log}
+}
+
+func scanAPIKey(row pgx.Row, extra ...any) (*models.APIKey, error) {
+	var key models.APIKey
+	dest := []any{
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&key.Permissions,
+		&key.RateLimit,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.LastUsedIP,
+		&key.RevokedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	op := "auth.api_key_repository.Create"
+	log := r.log.With("op", op)
+
+	query := `INSERT INTO api_keys (name, prefix, key_hash, permissions, rate_limit, created_by, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, created_at`
+
+	err := r.db.QueryRow(ctx, query,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Permissions,
+		key.RateLimit,
+		key.CreatedBy,
+		key.ExpiresAt,
+	).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		log.Error("error creating api key", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	op := "auth.api_key_repository.List"
+	log := r.log.With("op", op)
+
+	rows, err := r.db.Query(ctx, `SELECT `+apiKeyColumns+` FROM api_keys ORDER BY created_at DESC`)
+	if err != nil {
+		log.Error("error querying api keys", slog.Any("error", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			log.Error("error scanning api key", slog.Any("error", err))
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating api keys", slog.Any("error", err))
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	op := "auth.api_key_repository.GetByHash"
+	log := r.log.With("op", op)
+
+	query := `SELECT ` + apiKeyColumns + `,
+		EXISTS (SELECT 1 FROM users u WHERE u.id = api_keys.created_by AND u.disabled_at IS NULL)
+	FROM api_keys
+	WHERE key_hash = $1`
+
+	var creatorActive bool
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, hash), &creatorActive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error("error fetching api key", slog.Any("error", err))
+		return nil, err
+	}
+	key.CreatorActive = creatorActive
+	return key, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string) (*models.APIKey, error) {
+	op := "auth.api_key_repository.Revoke"
+	log := r.log.With("op", op)
+
+	query := `UPDATE api_keys
+	SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+	WHERE id = $1
+	RETURNING ` + apiKeyColumns
+
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error("error revoking api key", slog.Any("error", err))
+		return nil, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id, ip string) error {
+	op := "auth.api_key_repository.TouchLastUsed"
+	log := r.log.With("op", op)
+
+	// keys are checked on every request, so the timestamp is only bumped once a minute
+	query := `UPDATE api_keys
+	SET last_used_at = CURRENT_TIMESTAMP, last_used_ip = NULLIF($2, '')
+	WHERE id = $1
+	  AND (last_used_at IS NULL
+	       OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute'
+	       OR last_used_ip IS DISTINCT FROM NULLIF($2, ''))`
+
+	_, err := r.db.Exec(ctx, query, id, ip)
+	if err != nil {
+		log.Error("error updating api key last use", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
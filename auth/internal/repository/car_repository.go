@@ -0,0 +1,145 @@
+package authrepository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jekiti/citydrive/auth/internal/models"
+)
+
+type CarRepository interface {
+	GetByID(ctx context.Context, carID string) (*models.Car, error)
+	GetCredential(ctx context.Context, carID string) (*models.CarCredential, error)
+	// ReplaceCredential saves cred only if the car's credential is still prevTokenID,
+	// empty for a car that has none. false means another issue got there first.
+	ReplaceCredential(ctx context.Context, cred *models.CarCredential, prevTokenID string) (bool, error)
+	RevokeCredential(ctx context.Context, carID, tokenID string) (*models.CarCredential, error)
+}
+
+type carRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewCarRepository(db *pgxpool.Pool, log *slog.Logger) CarRepository {
+	return &carRepository{db: db, log: log}
+}
+
+func (r *carRepository) GetByID(ctx context.Context, carID string) (*models.Car, error) {
+	op := "auth.car_repository.GetByID"
+	log := r.log.With("op", op)
+
+	var car models.Car
+
+	query := `SELECT id, brand, model, year_of_manufacture, fuel_type, license_plate, created_at, updated_at
+	FROM cars
+	WHERE id = $1`
+
+	err := r.db.QueryRow(ctx, query, carID).Scan(
+		&car.ID,
+		&car.Brand,
+		&car.Model,
+		&car.YearOfManufacture,
+		&car.FuelType,
+		&car.LicensePlate,
+		&car.CreatedAt,
+		&car.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Info("car not found by id", "car_id", carID)
+			return nil, nil
+		}
+		log.Error("error getting car by id", slog.Any("error", err))
+		return nil, err
+	}
+
+	return &car, nil
+}
+
+func (r *carRepository) GetCredential(ctx context.Context, carID string) (*models.CarCredential, error) {
+	op := "auth.car_repository.GetCredential"
+	log := r.log.With("op", op)
+
+	var cred models.CarCredential
+
+	query := `SELECT car_id, token_id, issued_at, expires_at, revoked_at
+	FROM car_credentials
+	WHERE car_id = $1`
+
+	err := r.db.QueryRow(ctx, query, carID).Scan(
+		&cred.CarID,
+		&cred.TokenID,
+		&cred.IssuedAt,
+		&cred.ExpiresAt,
+		&cred.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error("error getting car credential", slog.Any("error", err))
+		return nil, err
+	}
+
+	return &cred, nil
+}
+
+func (r *carRepository) ReplaceCredential(ctx context.Context, cred *models.CarCredential, prevTokenID string) (bool, error) {
+	op := "auth.car_repository.ReplaceCredential"
+	log := r.log.With("op", op)
+
+	query := `INSERT INTO car_credentials (
+	car_id, token_id, issued_at, expires_at
+	) VALUES ($1, $2, $3, $4)
+	ON CONFLICT (car_id) DO NOTHING`
+	args := []any{cred.CarID, cred.TokenID, cred.IssuedAt, cred.ExpiresAt}
+	if prevTokenID != "" {
+		query = `UPDATE car_credentials
+		SET token_id = $2, issued_at = $3, expires_at = $4, revoked_at = NULL
+		WHERE car_id = $1 AND token_id = $5`
+		args = append(args, prevTokenID)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		log.Error("error saving car credential", slog.Any("error", err))
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// RevokeCredential marks the credential tokenID revoked, nil means it is already revoked or was replaced.
+func (r *carRepository) RevokeCredential(ctx context.Context, carID, tokenID string) (*models.CarCredential, error) {
+	op := "auth.car_repository.RevokeCredential"
+	log := r.log.With("op", op)
+
+	var cred models.CarCredential
+
+	query := `UPDATE car_credentials
+	SET revoked_at = CURRENT_TIMESTAMP
+	WHERE car_id = $1 AND token_id = $2 AND revoked_at IS NULL
+	RETURNING car_id, token_id, issued_at, expires_at, revoked_at`
+
+	err := r.db.QueryRow(ctx, query, carID, tokenID).Scan(
+		&cred.CarID,
+		&cred.TokenID,
+		&cred.IssuedAt,
+		&cred.ExpiresAt,
+		&cred.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Info("no active credential to revoke", "car_id", carID)
+			return nil, nil
+		}
+		log.Error("error revoking car credential", slog.Any("error", err))
+		return nil, err
+	}
+
+	return &cred, nil
+}
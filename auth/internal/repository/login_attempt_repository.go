@@ -0,0 +1,95 @@
+package authrepository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	loginFailuresPrefix = "auth:login:failures:"
+	loginBlockPrefix    = "auth:login:block:"
+)
+
+type LoginAttemptRepository interface {
+	BlockedFor(ctx context.Context, keys ...string) (time.Duration, error)
+	RegisterFailure(ctx context.Context, key string, window time.Duration) (int64, error)
+	Block(ctx context.Context, key string, d time.Duration) error
+	Reset(ctx context.Context, key string) error
+}
+
+type loginAttemptRepository struct {
+	client *redis.Client
+	log    *slog.Logger
+}
+
+func NewLoginAttemptRepository(client *redis.Client, log *slog.Logger) LoginAttemptRepository {
+	return &loginAttemptRepository{client: client, log: log}
+}
+
+func (r *loginAttemptRepository) BlockedFor(ctx context.Context, keys ...string) (time.Duration, error) {
+	op := "auth.login_attempt_repository.BlockedFor"
+	log := r.log.With("op", op)
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.PTTL(ctx, loginBlockPrefix+key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Error("error reading login blocks", slog.Any("error", err))
+		return 0, err
+	}
+
+	var blocked time.Duration
+	for _, cmd := range cmds {
+		// PTTL returns a negative duration for missing keys
+		if ttl := cmd.Val(); ttl > blocked {
+			blocked = ttl
+		}
+	}
+	return blocked, nil
+}
+
+func (r *loginAttemptRepository) RegisterFailure(ctx context.Context, key string, window time.Duration) (int64, error) {
+	op := "auth.login_attempt_repository.RegisterFailure"
+	log := r.log.With("op", op)
+
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(ctx, loginFailuresPrefix+key)
+	pipe.ExpireNX(ctx, loginFailuresPrefix+key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Error("error counting login failure", slog.Any("error", err))
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (r *loginAttemptRepository) Block(ctx context.Context, key string, d time.Duration) error {
+	op := "auth.login_attempt_repository.Block"
+	log := r.log.With("op", op)
+
+	if d <= 0 {
+		return nil
+	}
+	err := r.client.Set(ctx, loginBlockPrefix+key, 1, d).Err()
+	if err != nil {
+		log.Error("error blocking login", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	op := "auth.login_attempt_repository.Reset"
+	log := r.log.With("op", op)
+
+	err := r.client.Del(ctx, loginFailuresPrefix+key, loginBlockPrefix+key).Err()
+	if err != nil {
+		log.Error("error resetting login attempts", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
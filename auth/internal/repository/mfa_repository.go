@@ -0,0 +1,105 @@
+package authrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	mfaChallengePrefix = "auth:mfa:challenge:"
+	totpUsedPrefix     = "auth:mfa:totp_used:"
+)
+
+type MFARepository interface {
+	CreateChallenge(ctx context.Context, tokenHash string, userID int64, ttl time.Duration) error
+	GetChallenge(ctx context.Context, tokenHash string) (int64, bool, error)
+	RegisterFailure(ctx context.Context, tokenHash string) (int64, error)
+	DeleteChallenge(ctx context.Context, tokenHash string) (bool, error)
+	MarkCodeUsed(ctx context.Context, userID int64, step int64, ttl time.Duration) (bool, error)
+}
+
+type mfaRepository struct {
+	client *redis.Client
+	log    *slog.Logger
+}
+
+func NewMFARepository(client *redis.Client, log *slog.Logger) MFARepository {
+	return &mfaRepository{client: client, log: log}
+}
+
+func (r *mfaRepository) CreateChallenge(ctx context.Context, tokenHash string, userID int64, ttl time.Duration) error {
+	op := "auth.mfa_repository.CreateChallenge"
+	log := r.log.With("op", op)
+
+	key := mfaChallengePrefix + tokenHash
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, "user_id", userID, "attempts", 0)
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Error("error creating mfa challenge", slog.Any("error", err))
+		return err
+	}
+	return nil
+}
+
+func (r *mfaRepository) GetChallenge(ctx context.Context, tokenHash string) (int64, bool, error) {
+	op := "auth.mfa_repository.GetChallenge"
+	log := r.log.With("op", op)
+
+	value, err := r.client.HGet(ctx, mfaChallengePrefix+tokenHash, "user_id").Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, false, nil
+		}
+		log.Error("error reading mfa challenge", slog.Any("error", err))
+		return 0, false, err
+	}
+	userID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("corrupted mfa challenge: %w", err)
+	}
+	return userID, true, nil
+}
+
+func (r *mfaRepository) RegisterFailure(ctx context.Context, tokenHash string) (int64, error) {
+	op := "auth.mfa_repository.RegisterFailure"
+	log := r.log.With("op", op)
+
+	attempts, err := r.client.HIncrBy(ctx, mfaChallengePrefix+tokenHash, "attempts", 1).Result()
+	if err != nil {
+		log.Error("error counting mfa failure", slog.Any("error", err))
+		return 0, err
+	}
+	return attempts, nil
+}
+
+func (r *mfaRepository) DeleteChallenge(ctx context.Context, tokenHash string) (bool, error) {
+	op := "auth.mfa_repository.DeleteChallenge"
+	log := r.log.With("op", op)
+
+	deleted, err := r.client.Del(ctx, mfaChallengePrefix+tokenHash).Result()
+	if err != nil {
+		log.Error("error deleting mfa challenge", slog.Any("error", err))
+		return false, err
+	}
+	return deleted == 1, nil
+}
+
+func (r *mfaRepository) MarkCodeUsed(ctx context.Context, userID int64, step int64, ttl time.Duration) (bool, error) {
+	op := "auth.mfa_repository.MarkCodeUsed"
+	log := r.log.With("op", op)
+
+	key := fmt.Sprintf("%s%d:%d", totpUsedPrefix, userID, step)
+	ok, err := r.client.SetNX(ctx, key, 1, ttl).Result()
+	if err != nil {
+		log.Error("error marking totp code used", slog.Any("error", err))
+		return false, err
+	}
+	return ok, nil
+}
@@ -0,0 +1,137 @@
+package authrepository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jekiti/citydrive/auth/internal/models"
+)
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *models.PasswordResetToken) error
+	GetActive(ctx context.Context, hash string) (*models.PasswordResetToken, error)
+	// Consume marks the token used and sets the new password of its user in one transaction,
+	// false means the token was used or expired in the meantime.
+	Consume(ctx context.Context, token *models.PasswordResetToken, passwordHash string) (bool, error)
+}
+
+type passwordResetRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewPasswordResetRepository(db *pgxpool.Pool, log *slog.Logger) PasswordResetRepository {
+	return &passwordResetRepository{db: db, log: log}
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	op := "auth.password_reset_repository.Create"
+	log := r.log.With("op", op)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error("error starting transaction:", slog.Any("error", err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// only the most recent link stays valid
+	_, err = tx.Exec(ctx, `UPDATE password_reset_tokens
+	SET used_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND used_at IS NULL`, token.UserID)
+	if err != nil {
+		log.Error("error invalidating previous reset tokens", slog.Any("error", err))
+		return err
+	}
+
+	query := `INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at`
+
+	err = tx.QueryRow(ctx, query, token.UserID, token.TokenHash, token.ExpiresAt).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		log.Error("error creating reset token", slog.Any("error", err))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error("error committing transaction:", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *passwordResetRepository) GetActive(ctx context.Context, hash string) (*models.PasswordResetToken, error) {
+	op := "auth.password_reset_repository.GetActive"
+	log := r.log.With("op", op)
+
+	var token models.PasswordResetToken
+
+	query := `SELECT id, user_id, token_hash, created_at, expires_at, used_at
+	FROM password_reset_tokens
+	WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+
+	err := r.db.QueryRow(ctx, query, hash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error("error getting reset token", slog.Any("error", err))
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *passwordResetRepository) Consume(ctx context.Context, token *models.PasswordResetToken, passwordHash string) (bool, error) {
+	op := "auth.password_reset_repository.Consume"
+	log := r.log.With("op", op)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error("error starting transaction:", slog.Any("error", err))
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE password_reset_tokens
+	SET used_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP`, token.ID)
+	if err != nil {
+		log.Error("error marking reset token used", slog.Any("error", err))
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	tag, err = tx.Exec(ctx, `UPDATE users
+	SET password_hash = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`, token.UserID, passwordHash)
+	if err != nil {
+		log.Error("error updating password", slog.Any("error", err))
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, models.ErrUserNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error("error committing transaction:", slog.Any("error", err))
+		return false, err
+	}
+
+	return true, nil
+}
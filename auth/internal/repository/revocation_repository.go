@@ -0,0 +1,40 @@
+package authrepository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const revokedTokenPrefix = "auth:revoked:"
+
+type RevocationRepository interface {
+	Revoke(ctx context.Context, tokenID string, ttl time.Duration) error
+}
+
+type revocationRepository struct {
+	client *redis.Client
+	log    *slog.Logger
+}
+
+func NewRevocationRepository(client *redis.Client, log *slog.Logger) RevocationRepository {
+	return &revocationRepository{client: client, log: log}
+}
+
+func (r *revocationRepository) Revoke(ctx context.Context, tokenID string, ttl time.Duration) error {
+	op := "auth.revocation_repository.Revoke"
+	log := r.log.With("op", op)
+
+	if ttl <= 0 {
+		return nil
+	}
+	err := r.client.Set(ctx, revokedTokenPrefix+tokenID, 1, ttl).Err()
+	if err != nil {
+		log.Error("error adding token to denylist", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
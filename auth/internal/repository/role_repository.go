@@ -0,0 +1,66 @@
+package authrepository
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RoleRepository interface {
+	GetUserRoles(ctx context.Context, userID int64) (roles []string, permissions []string, err error)
+}
+
+type roleRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewRoleRepository(db *pgxpool.Pool, log *slog.Logger) RoleRepository {
+	return &roleRepository{db: db, log: log}
+}
+
+func (r *roleRepository) GetUserRoles(ctx context.Context, userID int64) ([]string, []string, error) {
+	op := "auth.role_repository.GetUserRoles"
+	log := r.log.With("op", op)
+
+	query := `SELECT r.name, COALESCE(rp.permission, '')
+	FROM user_roles ur
+	JOIN roles r ON r.id = ur.role_id
+	LEFT JOIN role_permissions rp ON rp.role_id = r.id
+	WHERE ur.user_id = $1
+	ORDER BY r.name, rp.permission`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		log.Error("error querying user roles", slog.Any("error", err))
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	permissions := []string{}
+	seenRoles := map[string]bool{}
+	seenPermissions := map[string]bool{}
+	for rows.Next() {
+		var role, permission string
+		if err := rows.Scan(&role, &permission); err != nil {
+			log.Error("error scanning user role", slog.Any("error", err))
+			return nil, nil, err
+		}
+		if !seenRoles[role] {
+			seenRoles[role] = true
+			roles = append(roles, role)
+		}
+		if permission != "" && !seenPermissions[permission] {
+			seenPermissions[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating user roles", slog.Any("error", err))
+		return nil, nil, err
+	}
+
+	return roles, permissions, nil
+}
@@ -0,0 +1,222 @@
+package authrepository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jekiti/citydrive/auth/internal/models"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	GetByRefreshHash(ctx context.Context, hash string) (*models.Session, error)
+	Rotate(ctx context.Context, session *models.Session, prevHash string) error
+	ListActiveByUser(ctx context.Context, userID int64) ([]models.Session, error)
+	Revoke(ctx context.Context, userID int64, sessionID string) (*models.Session, error)
+	RevokeAllByUser(ctx context.Context, userID int64, exceptSessionID string) ([]models.Session, error)
+}
+
+type sessionRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewSessionRepository(db *pgxpool.Pool, log *slog.Logger) SessionRepository {
+	return &sessionRepository{db: db, log: log}
+}
+
+const sessionColumns = `id, user_id, refresh_token_hash, access_token_id, access_expires_at,
+	created_at, last_used_at, expires_at, revoked_at`
+
+func scanSession(row pgx.Row, session *models.Session) error {
+	return row.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.RefreshTokenHash,
+		&session.AccessTokenID,
+		&session.AccessExpiresAt,
+		&session.CreatedAt,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
+	op := "auth.session_repository.Create"
+	log := r.log.With("op", op)
+
+	query := `INSERT INTO user_sessions (
+	user_id, refresh_token_hash, access_token_id, access_expires_at, expires_at
+	) VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, created_at, last_used_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		session.UserID,
+		session.RefreshTokenHash,
+		session.AccessTokenID,
+		session.AccessExpiresAt,
+		session.ExpiresAt,
+	).Scan(&session.ID, &session.CreatedAt, &session.LastUsedAt)
+	if err != nil {
+		log.Error("error creating session", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) GetByRefreshHash(ctx context.Context, hash string) (*models.Session, error) {
+	op := "auth.session_repository.GetByRefreshHash"
+	log := r.log.With("op", op)
+
+	var session models.Session
+
+	// the previous token is matched too, so that its reuse can be told from an unknown token
+	query := `SELECT ` + sessionColumns + `
+	FROM user_sessions
+	WHERE refresh_token_hash = $1 OR previous_refresh_token_hash = $1
+	LIMIT 1`
+
+	err := scanSession(r.db.QueryRow(ctx, query, hash), &session)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error("error getting session by refresh token", slog.Any("error", err))
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// Rotate replaces the refresh token prevHash, ErrSessionNotFound means the session is revoked
+// or prevHash was already rotated by another request.
+func (r *sessionRepository) Rotate(ctx context.Context, session *models.Session, prevHash string) error {
+	op := "auth.session_repository.Rotate"
+	log := r.log.With("op", op)
+
+	query := `UPDATE user_sessions
+	SET previous_refresh_token_hash = refresh_token_hash,
+		refresh_token_hash = $2,
+		access_token_id = $3,
+		access_expires_at = $4,
+		expires_at = $5,
+		last_used_at = $6
+	WHERE id = $1 AND revoked_at IS NULL AND refresh_token_hash = $7`
+
+	session.LastUsedAt = time.Now()
+	tag, err := r.db.Exec(ctx, query,
+		session.ID,
+		session.RefreshTokenHash,
+		session.AccessTokenID,
+		session.AccessExpiresAt,
+		session.ExpiresAt,
+		session.LastUsedAt,
+		prevHash,
+	)
+	if err != nil {
+		log.Error("error rotating session", slog.Any("error", err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID int64) ([]models.Session, error) {
+	op := "auth.session_repository.ListActiveByUser"
+	log := r.log.With("op", op)
+
+	query := `SELECT ` + sessionColumns + `
+	FROM user_sessions
+	WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+	ORDER BY last_used_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		log.Error("error listing sessions", slog.Any("error", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []models.Session
+	for rows.Next() {
+		var session models.Session
+		if err := scanSession(rows, &session); err != nil {
+			log.Error("error scanning session", slog.Any("error", err))
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating sessions", slog.Any("error", err))
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, userID int64, sessionID string) (*models.Session, error) {
+	op := "auth.session_repository.Revoke"
+	log := r.log.With("op", op)
+
+	var session models.Session
+
+	query := `UPDATE user_sessions
+	SET revoked_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	RETURNING ` + sessionColumns
+
+	err := scanSession(r.db.QueryRow(ctx, query, sessionID, userID), &session)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error("error revoking session", slog.Any("error", err))
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) RevokeAllByUser(ctx context.Context, userID int64, exceptSessionID string) ([]models.Session, error) {
+	op := "auth.session_repository.RevokeAllByUser"
+	log := r.log.With("op", op)
+
+	query := `UPDATE user_sessions
+	SET revoked_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		AND ($2 = '' OR id::text <> $2)
+	RETURNING ` + sessionColumns
+
+	rows, err := r.db.Query(ctx, query, userID, exceptSessionID)
+	if err != nil {
+		log.Error("error revoking user sessions", slog.Any("error", err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []models.Session
+	for rows.Next() {
+		var session models.Session
+		if err := scanSession(rows, &session); err != nil {
+			log.Error("error scanning session", slog.Any("error", err))
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating sessions", slog.Any("error", err))
+		return nil, err
+	}
+
+	return sessions, nil
+}
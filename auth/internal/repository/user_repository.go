@@ -2,16 +2,29 @@ package authrepository
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"log/slog"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jekiti/citydrive/auth/internal/models"
 )
 
+const uniqueViolation = "23505"
+
 type UserRepository interface {
-	Create(ctx context.Context, user *models.User) error
+	Create(ctx context.Context, user *models.User, role string) error
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByID(ctx context.Context, id int64) (*models.User, error)
+	List(ctx context.Context, filter models.ListUsersFilter) ([]models.User, int64, error)
+	Update(ctx context.Context, req *models.UpdateUserRequest) error
+	Disable(ctx context.Context, id int64) error
+	Delete(ctx context.Context, id int64) error
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) error
+	SetTOTPSecret(ctx context.Context, id int64, encryptedSecret string) error
+	EnableTOTP(ctx context.Context, id int64, recoveryCodeHashes []string) error
+	UseRecoveryCode(ctx context.Context, id int64, codeHash string) (bool, error)
 }
 
 type userRepository struct {
@@ -23,22 +36,71 @@ func NewUserRepository(db *pgxpool.Pool, log *slog.Logger) UserRepository {
 	return &userRepository{db: db, log: log}
 }
 
-func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+const userColumns = `id, email, name, surname, department, password_hash, created_at, updated_at, disabled_at,
+	totp_secret, totp_enabled_at`
+
+func scanUser(row pgx.Row, user *models.User) error {
+	return row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&user.Surname,
+		&user.Department,
+		&user.PasswordHash,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DisabledAt,
+		&user.TOTPSecret,
+		&user.TOTPEnabledAt,
+	)
+}
+
+func (r *userRepository) Create(ctx context.Context, user *models.User, role string) error {
 	op := "auth.user_repository"
 	log := r.log.With("op", op)
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error("error starting transaction:", slog.Any("error", err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `INSERT INTO users (
 	email, name, surname, department, password_hash
 	) VALUES ($1, $2, $3, $4, $5)
 	RETURNING id
 	`
 
-	err := r.db.QueryRow(ctx, query, user.Email, user.Name, user.Surname, user.Department, user.PasswordHash).Scan(&user.ID)
+	err = tx.QueryRow(ctx, query, user.Email, user.Name, user.Surname, user.Department, user.PasswordHash).Scan(&user.ID)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			log.Warn("email already taken", "email", user.Email)
+			return models.ErrEmailTaken
+		}
 		log.Error("error saving user:", slog.Any("error", err))
 		return err
 	}
 
+	roleQuery := `INSERT INTO user_roles (user_id, role_id)
+	SELECT $1, id FROM roles WHERE name = $2`
+
+	tag, err := tx.Exec(ctx, roleQuery, user.ID, role)
+	if err != nil {
+		log.Error("error assigning role:", slog.Any("error", err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("unknown role", "role", role)
+		return models.ErrUnknownRole
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error("error committing transaction:", slog.Any("error", err))
+		return err
+	}
+
 	return nil
 }
 
@@ -48,23 +110,14 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 
 	var user models.User
 
-	query := `SELECT id, email, name, surname, department, password_hash, created_at, updated_at
+	query := `SELECT ` + userColumns + `
 	FROM users
 	WHERE email = $1`
 
-	err := r.db.QueryRow(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.Name,
-		&user.Surname,
-		&user.Department,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	err := scanUser(r.db.QueryRow(ctx, query, email), &user)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			log.Info("user not found by email", "email", email)
 			return nil, nil
 		}
@@ -74,3 +127,278 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 
 	return &user, nil
 }
+
+func (r *userRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	op := "auth.user_repository"
+	log := r.log.With("op", op)
+
+	var user models.User
+
+	query := `SELECT ` + userColumns + `
+	FROM users
+	WHERE id = $1`
+
+	err := scanUser(r.db.QueryRow(ctx, query, id), &user)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Info("user not found by id", "id", id)
+			return nil, nil
+		}
+		log.Error("error getting user by id", slog.Any("error", err))
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *userRepository) List(ctx context.Context, filter models.ListUsersFilter) ([]models.User, int64, error) {
+	op := "auth.user_repository.List"
+	log := r.log.With("op", op)
+
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM users WHERE ($1 = '' OR department = $1)`
+	err := r.db.QueryRow(ctx, countQuery, filter.Department).Scan(&total)
+	if err != nil {
+		log.Error("error counting users", slog.Any("error", err))
+		return nil, 0, err
+	}
+
+	query := `SELECT u.id, u.email, u.name, u.surname, u.department, u.password_hash,
+		u.created_at, u.updated_at, u.disabled_at,
+		COALESCE(array_agg(r.name ORDER BY r.name) FILTER (WHERE r.name IS NOT NULL), '{}')
+	FROM users u
+	LEFT JOIN user_roles ur ON ur.user_id = u.id
+	LEFT JOIN roles r ON r.id = ur.role_id
+	WHERE ($1 = '' OR u.department = $1)
+	GROUP BY u.id
+	ORDER BY u.id
+	LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, query, filter.Department, filter.Limit, filter.Offset)
+	if err != nil {
+		log.Error("error listing users", slog.Any("error", err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var user models.User
+		err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.Name,
+			&user.Surname,
+			&user.Department,
+			&user.PasswordHash,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.DisabledAt,
+			&user.Roles,
+		)
+		if err != nil {
+			log.Error("error scanning user", slog.Any("error", err))
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("error iterating users", slog.Any("error", err))
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *userRepository) Update(ctx context.Context, req *models.UpdateUserRequest) error {
+	op := "auth.user_repository.Update"
+	log := r.log.With("op", op, "user_id", req.ID)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error("error starting transaction:", slog.Any("error", err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE users
+	SET name = COALESCE($2, name),
+		surname = COALESCE($3, surname),
+		department = COALESCE($4, department),
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	tag, err := tx.Exec(ctx, query, req.ID, req.Name, req.Surname, req.Department)
+	if err != nil {
+		log.Error("error updating user", slog.Any("error", err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrUserNotFound
+	}
+
+	if req.Role != nil {
+		_, err = tx.Exec(ctx, `DELETE FROM user_roles WHERE user_id = $1`, req.ID)
+		if err != nil {
+			log.Error("error clearing user roles", slog.Any("error", err))
+			return err
+		}
+		tag, err = tx.Exec(ctx, `INSERT INTO user_roles (user_id, role_id)
+		SELECT $1, id FROM roles WHERE name = $2`, req.ID, *req.Role)
+		if err != nil {
+			log.Error("error assigning role", slog.Any("error", err))
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			log.Warn("unknown role", "role", *req.Role)
+			return models.ErrUnknownRole
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error("error committing transaction:", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepository) Disable(ctx context.Context, id int64) error {
+	op := "auth.user_repository.Disable"
+	log := r.log.With("op", op, "user_id", id)
+
+	query := `UPDATE users
+	SET disabled_at = COALESCE(disabled_at, CURRENT_TIMESTAMP),
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		log.Error("error disabling user", slog.Any("error", err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id int64) error {
+	op := "auth.user_repository.Delete"
+	log := r.log.With("op", op, "user_id", id)
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		log.Error("error deleting user", slog.Any("error", err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) error {
+	op := "auth.user_repository.UpdatePassword"
+	log := r.log.With("op", op, "user_id", id)
+
+	query := `UPDATE users
+	SET password_hash = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, query, id, passwordHash)
+	if err != nil {
+		log.Error("error updating password", slog.Any("error", err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *userRepository) SetTOTPSecret(ctx context.Context, id int64, encryptedSecret string) error {
+	op := "auth.user_repository.SetTOTPSecret"
+	log := r.log.With("op", op, "user_id", id)
+
+	query := `UPDATE users
+	SET totp_secret = $2,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND totp_enabled_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query, id, encryptedSecret)
+	if err != nil {
+		log.Error("error saving totp secret", slog.Any("error", err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrTOTPAlreadyEnabled
+	}
+
+	return nil
+}
+
+func (r *userRepository) EnableTOTP(ctx context.Context, id int64, recoveryCodeHashes []string) error {
+	op := "auth.user_repository.EnableTOTP"
+	log := r.log.With("op", op, "user_id", id)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		log.Error("error starting transaction:", slog.Any("error", err))
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE users
+	SET totp_enabled_at = CURRENT_TIMESTAMP,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL`, id)
+	if err != nil {
+		log.Error("error enabling totp", slog.Any("error", err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.ErrTOTPAlreadyEnabled
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, id)
+	if err != nil {
+		log.Error("error clearing recovery codes", slog.Any("error", err))
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO user_recovery_codes (user_id, code_hash)
+	SELECT $1, unnest($2::text[])`, id, recoveryCodeHashes)
+	if err != nil {
+		log.Error("error saving recovery codes", slog.Any("error", err))
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Error("error committing transaction:", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepository) UseRecoveryCode(ctx context.Context, id int64, codeHash string) (bool, error) {
+	op := "auth.user_repository.UseRecoveryCode"
+	log := r.log.With("op", op, "user_id", id)
+
+	query := `UPDATE user_recovery_codes
+	SET used_at = CURRENT_TIMESTAMP
+	WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, query, id, codeHash)
+	if err != nil {
+		log.Error("error using recovery code", slog.Any("error", err))
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
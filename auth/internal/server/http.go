@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+func RunHTTP(ctx context.Context, log *slog.Logger, port string, handler http.Handler) error {
+	httpServer := &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	serverErrCh := make(chan error, 1)
+	go func() {
+		log.Info("HTTP server listening on " + port)
+		serverErrCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErrCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Error("failed to serve HTTP server:", slog.Any("error", err))
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Error("HTTP server shutdown error:", slog.Any("error", err))
+		return err
+	}
+	log.Info("HTTP server stopped")
+	return nil
+}
@@ -0,0 +1,234 @@
+package authservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jekiti/citydrive/auth/internal/config"
+	"github.com/jekiti/citydrive/auth/internal/models"
+	authrepository "github.com/jekiti/citydrive/auth/internal/repository"
+)
+
+const (
+	apiKeyScheme    = "cdk_"
+	apiKeyPrefixLen = len(apiKeyScheme) + 8
+	// last use is recorded at most this often per key and client IP
+	apiKeyTouchInterval = time.Minute
+	apiKeyTouchTimeout  = 5 * time.Second
+)
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, req *models.CreateAPIKeyRequest) (string, *models.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (*models.APIKey, error)
+	AuthenticateAPIKey(ctx context.Context, rawKey, clientIP string) (*models.APIKey, error)
+}
+
+type apiKeyService struct {
+	repo  authrepository.APIKeyRepository
+	roles authrepository.RoleRepository
+	log   *slog.Logger
+	cfg   config.APIKeyConfig
+
+	mu      sync.Mutex
+	touched map[string]time.Time
+}
+
+func NewAPIKeyService(repo authrepository.APIKeyRepository,
+	roles authrepository.RoleRepository,
+	log *slog.Logger,
+	cfg config.APIKeyConfig) APIKeyService {
+	return &apiKeyService{repo: repo, roles: roles, log: log, cfg: cfg, touched: make(map[string]time.Time)}
+}
+
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, req *models.CreateAPIKeyRequest) (string, *models.APIKey, error) {
+	op := "auth.api_key_service.CreateAPIKey"
+	log := s.log.With("op", op, "created_by", req.CreatedBy)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", nil, fmt.Errorf("%w: name is required", models.ErrValidationFailed)
+	}
+	if len(req.Permissions) == 0 {
+		return "", nil, fmt.Errorf("%w: at least one permission is required", models.ErrValidationFailed)
+	}
+	if req.RateLimit < 0 || req.RateLimit > s.cfg.MaxRateLimit {
+		return "", nil, fmt.Errorf("%w: rate_limit must be between 0 and %d", models.ErrValidationFailed, s.cfg.MaxRateLimit)
+	}
+	if req.TTL < 0 {
+		return "", nil, fmt.Errorf("%w: expires_in must not be negative", models.ErrValidationFailed)
+	}
+
+	// a key can't carry more than the person who issues it
+	_, granted, err := s.roles.GetUserRoles(ctx, req.CreatedBy)
+	if err != nil {
+		log.Error("error loading creator permissions:", slog.Any("error", err))
+		return "", nil, err
+	}
+	grantedSet := make(map[string]bool, len(granted))
+	for _, p := range granted {
+		grantedSet[p] = true
+	}
+	permissions := make([]string, 0, len(req.Permissions))
+	seen := make(map[string]bool, len(req.Permissions))
+	for _, p := range req.Permissions {
+		if !grantedSet[p] {
+			log.Warn("permission is not granted to creator", slog.String("permission", p))
+			return "", nil, fmt.Errorf("%w: %s", models.ErrPermissionDenied, p)
+		}
+		if !seen[p] {
+			seen[p] = true
+			permissions = append(permissions, p)
+		}
+	}
+
+	secret, err := newRefreshToken()
+	if err != nil {
+		log.Error("error generating api key:", slog.Any("error", err))
+		return "", nil, err
+	}
+	rawKey := apiKeyScheme + secret
+
+	rateLimit := req.RateLimit
+	if rateLimit == 0 {
+		rateLimit = s.cfg.DefaultRateLimit
+	}
+	createdBy := req.CreatedBy
+	key := &models.APIKey{
+		Name:        name,
+		Prefix:      rawKey[:apiKeyPrefixLen],
+		KeyHash:     hashToken(rawKey),
+		Permissions: permissions,
+		RateLimit:   rateLimit,
+		CreatedBy:   &createdBy,
+	}
+	if req.TTL > 0 {
+		expiresAt := time.Now().Add(req.TTL)
+		key.ExpiresAt = &expiresAt
+	}
+
+	err = s.repo.Create(ctx, key)
+	if err != nil {
+		log.Error("error saving api key:", slog.Any("error", err))
+		return "", nil, err
+	}
+
+	log.Info("api key created", slog.String("key_id", key.ID), slog.Any("permissions", permissions))
+	return rawKey, key, nil
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, id string) (*models.APIKey, error) {
+	op := "auth.api_key_service.RevokeAPIKey"
+	log := s.log.With("op", op, "key_id", id)
+
+	if !uuidRegexp.MatchString(id) {
+		return nil, models.ErrAPIKeyNotFound
+	}
+	key, err := s.repo.Revoke(ctx, id)
+	if err != nil {
+		log.Error("error revoking api key:", slog.Any("error", err))
+		return nil, err
+	}
+	if key == nil {
+		return nil, models.ErrAPIKeyNotFound
+	}
+
+	log.Info("api key revoked")
+	return key, nil
+}
+
+func (s *apiKeyService) AuthenticateAPIKey(ctx context.Context, rawKey, clientIP string) (*models.APIKey, error) {
+	op := "auth.api_key_service.AuthenticateAPIKey"
+	log := s.log.With("op", op)
+
+	if !strings.HasPrefix(rawKey, apiKeyScheme) || len(rawKey) <= apiKeyPrefixLen {
+		return nil, models.ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByHash(ctx, hashToken(rawKey))
+	if err != nil {
+		log.Error("error fetching api key:", slog.Any("error", err))
+		return nil, err
+	}
+	if key == nil {
+		log.Warn("unknown api key", slog.String("prefix", rawKey[:apiKeyPrefixLen]))
+		return nil, models.ErrInvalidAPIKey
+	}
+	log = log.With("key_id", key.ID)
+	if key.RevokedAt != nil {
+		log.Warn("revoked api key used")
+		return nil, models.ErrInvalidAPIKey
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		log.Warn("expired api key used")
+		return nil, models.ErrInvalidAPIKey
+	}
+	if !key.CreatorActive {
+		log.Warn("api key of a disabled or deleted user used")
+		return nil, models.ErrInvalidAPIKey
+	}
+
+	// the creator may have lost permissions since the key was issued
+	_, granted, err := s.roles.GetUserRoles(ctx, *key.CreatedBy)
+	if err != nil {
+		log.Error("error loading creator permissions:", slog.Any("error", err))
+		return nil, err
+	}
+	grantedSet := make(map[string]bool, len(granted))
+	for _, p := range granted {
+		grantedSet[p] = true
+	}
+	permissions := make([]string, 0, len(key.Permissions))
+	for _, p := range key.Permissions {
+		if grantedSet[p] {
+			permissions = append(permissions, p)
+		}
+	}
+	if len(permissions) < len(key.Permissions) {
+		log.Warn("api key permissions narrowed to creator permissions", slog.Any("permissions", permissions))
+	}
+	key.Permissions = permissions
+
+	s.touchLastUsed(key.ID, clientIP)
+	return key, nil
+}
+
+// touchLastUsed records the last use off the request path and at most once per
+// apiKeyTouchInterval, keys are checked on every integration request.
+func (s *apiKeyService) touchLastUsed(id, clientIP string) {
+	op := "auth.api_key_service.touchLastUsed"
+	log := s.log.With("op", op, "key_id", id)
+
+	touchKey := id + "|" + clientIP
+	now := time.Now()
+	s.mu.Lock()
+	if now.Sub(s.touched[touchKey]) < apiKeyTouchInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.touched[touchKey] = now
+	// drop entries of keys that went quiet, the map stays at the number of active keys
+	for k, at := range s.touched {
+		if now.Sub(at) >= apiKeyTouchInterval {
+			delete(s.touched, k)
+		}
+	}
+	s.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), apiKeyTouchTimeout)
+		defer cancel()
+		err := s.repo.TouchLastUsed(ctx, id, clientIP)
+		if err != nil {
+			log.Error("error updating api key last use:", slog.Any("error", err))
+		}
+	}()
+}
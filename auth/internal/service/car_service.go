@@ -0,0 +1,184 @@
+package authservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/jekiti/citydrive/auth/internal/models"
+	authrepository "github.com/jekiti/citydrive/auth/internal/repository"
+	"github.com/jekiti/citydrive/auth/internal/signer"
+)
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type CarTokenService interface {
+	IssueCarToken(ctx context.Context, req *models.IssueCarTokenRequest) (*models.IssueCarTokenResponse, error)
+	RevokeCarToken(ctx context.Context, req *models.RevokeCarTokenRequest) (*models.RevokeCarTokenResponse, error)
+}
+
+type carTokenService struct {
+	repo        authrepository.CarRepository
+	revocations authrepository.RevocationRepository
+	log         *slog.Logger
+	signer      signer.Signer
+	expiration  time.Duration
+}
+
+func NewCarTokenService(repo authrepository.CarRepository,
+	revocations authrepository.RevocationRepository,
+	log *slog.Logger,
+	tokenSigner signer.Signer,
+	expiration time.Duration) CarTokenService {
+	return &carTokenService{
+		repo:        repo,
+		revocations: revocations,
+		log:         log,
+		signer:      tokenSigner,
+		expiration:  expiration,
+	}
+}
+
+func (s *carTokenService) IssueCarToken(ctx context.Context, req *models.IssueCarTokenRequest) (*models.IssueCarTokenResponse, error) {
+	op := "auth.car_service.IssueCarToken"
+	log := s.log.With("op", op, "car_id", req.CarID)
+
+	if !uuidRegexp.MatchString(req.CarID) {
+		log.Warn("invalid car id")
+		return nil, models.ErrInvalidCarID
+	}
+
+	car, err := s.repo.GetByID(ctx, req.CarID)
+	if err != nil {
+		log.Error("error fetching car:", slog.Any("error", err))
+		return nil, err
+	}
+	if car == nil {
+		log.Warn("car not found")
+		return nil, models.ErrCarNotFound
+	}
+
+	prev, err := s.repo.GetCredential(ctx, car.ID)
+	if err != nil {
+		log.Error("error fetching car credential:", slog.Any("error", err))
+		return nil, err
+	}
+
+	now := time.Now()
+	rotated := false
+	if prev != nil && prev.RevokedAt == nil && prev.ExpiresAt.After(now) {
+		err = s.revocations.Revoke(ctx, prev.TokenID, prev.ExpiresAt.Sub(now))
+		if err != nil {
+			log.Error("error revoking previous car token:", slog.Any("error", err))
+			return nil, err
+		}
+		rotated = true
+	}
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		log.Error("error generating token id:", slog.Any("error", err))
+		return nil, err
+	}
+	expiresAt := now.Add(s.expiration)
+
+	claims := jwt.MapClaims{
+		"iss":    "auth.citydrive",
+		"sub":    fmt.Sprintf("car:%s", car.ID),
+		"car_id": car.ID,
+		"roles":  []string{"car"},
+		"jti":    tokenID,
+		"iat":    now.Unix(),
+		"exp":    expiresAt.Unix(),
+	}
+
+	tokenString, err := s.signer.Sign(claims)
+	if err != nil {
+		log.Error("error signing car token:", slog.Any("error", err))
+		return nil, err
+	}
+
+	// the swap is conditional on the credential read above: of two concurrent issues only
+	// one is saved, the other token is never handed out
+	var prevTokenID string
+	if prev != nil {
+		prevTokenID = prev.TokenID
+	}
+	saved, err := s.repo.ReplaceCredential(ctx, &models.CarCredential{
+		CarID:     car.ID,
+		TokenID:   tokenID,
+		IssuedAt:  now,
+		ExpiresAt: expiresAt,
+	}, prevTokenID)
+	if err != nil {
+		log.Error("error saving car credential:", slog.Any("error", err))
+		return nil, err
+	}
+	if !saved {
+		log.Warn("car credential changed while issuing a token")
+		return nil, models.ErrCarTokenConflict
+	}
+
+	log.Info("car token issued", slog.Bool("rotated", rotated))
+	return &models.IssueCarTokenResponse{
+		CarID:       car.ID,
+		AccessToken: tokenString,
+		TokenID:     tokenID,
+		ExpiresAt:   expiresAt.Unix(),
+		Rotated:     rotated,
+	}, nil
+}
+
+func (s *carTokenService) RevokeCarToken(ctx context.Context, req *models.RevokeCarTokenRequest) (*models.RevokeCarTokenResponse, error) {
+	op := "auth.car_service.RevokeCarToken"
+	log := s.log.With("op", op, "car_id", req.CarID)
+
+	if !uuidRegexp.MatchString(req.CarID) {
+		log.Warn("invalid car id")
+		return nil, models.ErrInvalidCarID
+	}
+
+	cred, err := s.repo.GetCredential(ctx, req.CarID)
+	if err != nil {
+		log.Error("error fetching car credential:", slog.Any("error", err))
+		return nil, err
+	}
+	if cred == nil || !cred.ExpiresAt.After(time.Now()) {
+		return nil, models.ErrCarTokenNotIssued
+	}
+
+	// the denylist goes first: if it fails nothing is marked revoked and the call can be
+	// retried, an already revoked credential is put on the denylist again
+	err = s.revocations.Revoke(ctx, cred.TokenID, time.Until(cred.ExpiresAt))
+	if err != nil {
+		log.Error("error adding car token to denylist:", slog.Any("error", err))
+		return nil, err
+	}
+
+	if cred.RevokedAt == nil {
+		_, err = s.repo.RevokeCredential(ctx, cred.CarID, cred.TokenID)
+		if err != nil {
+			log.Error("error revoking car credential:", slog.Any("error", err))
+			return nil, err
+		}
+	}
+
+	log.Info("car token revoked", slog.String("token_id", cred.TokenID))
+	return &models.RevokeCarTokenResponse{
+		CarID:   cred.CarID,
+		TokenID: cred.TokenID,
+	}, nil
+}
+
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,119 @@
+package authservice
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jekiti/citydrive/auth/internal/config"
+	"github.com/jekiti/citydrive/auth/internal/models"
+	authrepository "github.com/jekiti/citydrive/auth/internal/repository"
+)
+
+type LoginLimiter interface {
+	Check(ctx context.Context, email, ip string) error
+	Fail(ctx context.Context, email, ip string) error
+	Succeed(ctx context.Context, email string)
+	Unlock(ctx context.Context, email string) error
+}
+
+type loginLimiter struct {
+	repo authrepository.LoginAttemptRepository
+	cfg  config.LoginConfig
+	log  *slog.Logger
+}
+
+func NewLoginLimiter(repo authrepository.LoginAttemptRepository, cfg config.LoginConfig, log *slog.Logger) LoginLimiter {
+	return &loginLimiter{repo: repo, cfg: cfg, log: log}
+}
+
+func (l *loginLimiter) Check(ctx context.Context, email, ip string) error {
+	op := "auth.login_limiter.Check"
+	log := l.log.With("op", op)
+
+	blocked, err := l.repo.BlockedFor(ctx, l.keys(email, ip)...)
+	if err != nil {
+		// redis being down must not lock everyone out
+		log.Error("error checking login block, allowing attempt:", slog.Any("error", err))
+		return nil
+	}
+	if blocked > 0 {
+		log.Warn("login attempt while blocked", "retry_after", blocked)
+		return &models.TooManyAttemptsError{RetryAfter: blocked}
+	}
+	return nil
+}
+
+func (l *loginLimiter) Fail(ctx context.Context, email, ip string) error {
+	op := "auth.login_limiter.Fail"
+	log := l.log.With("op", op)
+
+	var retryAfter time.Duration
+	for i, key := range l.keys(email, ip) {
+		maxAttempts := l.cfg.MaxAttempts
+		if i > 0 {
+			maxAttempts = l.cfg.IPMaxAttempts
+		}
+
+		failures, err := l.repo.RegisterFailure(ctx, key, l.cfg.AttemptWindow)
+		if err != nil {
+			log.Error("error registering login failure:", slog.Any("error", err))
+			return nil
+		}
+
+		delay := l.backoff(failures)
+		if failures >= int64(maxAttempts) {
+			delay = l.cfg.LockoutDuration
+			log.Warn("login locked out", "key", key, "failures", failures)
+		}
+		if err := l.repo.Block(ctx, key, delay); err != nil {
+			log.Error("error blocking login:", slog.Any("error", err))
+			return nil
+		}
+		if delay > retryAfter {
+			retryAfter = delay
+		}
+	}
+
+	if retryAfter >= l.cfg.LockoutDuration {
+		return &models.TooManyAttemptsError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+func (l *loginLimiter) Succeed(ctx context.Context, email string) {
+	op := "auth.login_limiter.Succeed"
+	log := l.log.With("op", op)
+
+	if err := l.repo.Reset(ctx, emailKey(email)); err != nil {
+		log.Error("error resetting login attempts:", slog.Any("error", err))
+	}
+}
+
+func (l *loginLimiter) Unlock(ctx context.Context, email string) error {
+	return l.repo.Reset(ctx, emailKey(email))
+}
+
+func (l *loginLimiter) backoff(failures int64) time.Duration {
+	delay := l.cfg.BackoffBase
+	for i := int64(1); i < failures && delay < l.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > l.cfg.BackoffMax {
+		delay = l.cfg.BackoffMax
+	}
+	return delay
+}
+
+func (l *loginLimiter) keys(email, ip string) []string {
+	keys := []string{emailKey(email)}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+func emailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
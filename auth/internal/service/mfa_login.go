@@ -0,0 +1,110 @@
+package authservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jekiti/citydrive/auth/internal/models"
+)
+
+func (s *userService) startMFAChallenge(ctx context.Context, user *models.User) (*models.LoginResponse, error) {
+	op := "auth.user_service.startMFAChallenge"
+	log := s.log.With("op", op, "user_id", user.ID)
+
+	token, err := newRefreshToken()
+	if err != nil {
+		log.Error("error generating mfa token:", slog.Any("error", err))
+		return nil, err
+	}
+	err = s.challenges.CreateChallenge(ctx, hashToken(token), user.ID, s.mfa.ChallengeTTL)
+	if err != nil {
+		log.Error("error creating mfa challenge:", slog.Any("error", err))
+		return nil, err
+	}
+
+	log.Info("mfa challenge issued")
+	return &models.LoginResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   int64(s.mfa.ChallengeTTL.Seconds()),
+	}, nil
+}
+
+func (s *userService) VerifyTOTP(ctx context.Context, req *models.VerifyTOTPRequest) (*models.LoginResponse, error) {
+	op := "auth.user_service.VerifyTOTP"
+	log := s.log.With("op", op)
+
+	if req.MFAToken == "" || (req.Code == "") == (req.RecoveryCode == "") {
+		return nil, fmt.Errorf("%w: mfa_token and exactly one of code or recovery_code are required", models.ErrValidationFailed)
+	}
+
+	tokenHash := hashToken(req.MFAToken)
+	userID, found, err := s.challenges.GetChallenge(ctx, tokenHash)
+	if err != nil {
+		log.Error("error fetching mfa challenge:", slog.Any("error", err))
+		return nil, err
+	}
+	if !found {
+		log.Warn("mfa challenge is unknown or expired")
+		return nil, models.ErrInvalidMFAChallenge
+	}
+	log = log.With("user_id", userID)
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching user:", slog.Any("error", err))
+		return nil, err
+	}
+	if user == nil || user.TOTPEnabledAt == nil {
+		s.challenges.DeleteChallenge(ctx, tokenHash)
+		return nil, models.ErrInvalidMFAChallenge
+	}
+	if user.DisabledAt != nil {
+		s.challenges.DeleteChallenge(ctx, tokenHash)
+		return nil, models.ErrUserDisabled
+	}
+	err = s.limiter.Check(ctx, user.Email, req.ClientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.totp.CheckCode(ctx, user, req.Code, req.RecoveryCode)
+	if errors.Is(err, models.ErrInvalidTOTPCode) {
+		// counted against the user too, so a new challenge doesn't start the count over
+		if lerr := s.limiter.Fail(ctx, user.Email, req.ClientIP); lerr != nil {
+			log.Warn("mfa locked out")
+			s.challenges.DeleteChallenge(ctx, tokenHash)
+			return nil, lerr
+		}
+		attempts, ferr := s.challenges.RegisterFailure(ctx, tokenHash)
+		if ferr != nil {
+			log.Error("error registering mfa failure:", slog.Any("error", ferr))
+			return nil, ferr
+		}
+		if attempts >= int64(s.mfa.MaxAttempts) {
+			log.Warn("mfa attempts exhausted")
+			s.challenges.DeleteChallenge(ctx, tokenHash)
+			return nil, models.ErrInvalidMFAChallenge
+		}
+		log.Warn("invalid second factor", slog.Int64("attempts", attempts))
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.challenges.DeleteChallenge(ctx, tokenHash)
+	if err != nil {
+		log.Error("error deleting mfa challenge:", slog.Any("error", err))
+		return nil, err
+	}
+	if !deleted {
+		// another request already completed this challenge
+		return nil, models.ErrInvalidMFAChallenge
+	}
+
+	s.limiter.Succeed(ctx, user.Email)
+	return s.issueSession(ctx, user)
+}
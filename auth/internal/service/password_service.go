@@ -0,0 +1,274 @@
+package authservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jekiti/citydrive/auth/internal/config"
+	"github.com/jekiti/citydrive/auth/internal/models"
+	"github.com/jekiti/citydrive/auth/internal/notifier"
+	authrepository "github.com/jekiti/citydrive/auth/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordService interface {
+	ChangePassword(ctx context.Context, req *models.ChangePasswordRequest) (int, error)
+	RequestPasswordReset(ctx context.Context, email, clientIP string) error
+	ConfirmPasswordReset(ctx context.Context, req *models.ConfirmPasswordResetRequest) error
+}
+
+type passwordService struct {
+	users       authrepository.UserRepository
+	resets      authrepository.PasswordResetRepository
+	sessions    authrepository.SessionRepository
+	revocations authrepository.RevocationRepository
+	limiter     LoginLimiter
+	attempts    authrepository.LoginAttemptRepository
+	notifier    notifier.Notifier
+	log         *slog.Logger
+	cfg         config.PasswordConfig
+}
+
+func NewPasswordService(users authrepository.UserRepository,
+	resets authrepository.PasswordResetRepository,
+	sessions authrepository.SessionRepository,
+	revocations authrepository.RevocationRepository,
+	limiter LoginLimiter,
+	attempts authrepository.LoginAttemptRepository,
+	notifier notifier.Notifier,
+	log *slog.Logger,
+	cfg config.PasswordConfig) PasswordService {
+	return &passwordService{
+		users:       users,
+		resets:      resets,
+		sessions:    sessions,
+		revocations: revocations,
+		limiter:     limiter,
+		attempts:    attempts,
+		notifier:    notifier,
+		log:         log,
+		cfg:         cfg,
+	}
+}
+
+func (s *passwordService) ChangePassword(ctx context.Context, req *models.ChangePasswordRequest) (int, error) {
+	op := "auth.password_service.ChangePassword"
+	log := s.log.With("op", op, "user_id", req.UserID)
+
+	user, err := s.users.GetByID(ctx, req.UserID)
+	if err != nil {
+		log.Error("error fetching user:", slog.Any("error", err))
+		return 0, err
+	}
+	if user == nil {
+		return 0, models.ErrUserNotFound
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.OldPassword))
+	if err != nil {
+		log.Warn("invalid old password")
+		return 0, models.ErrInvalidCredentials
+	}
+	if req.OldPassword == req.NewPassword {
+		return 0, fmt.Errorf("%w: new password must differ from the old one", models.ErrValidationFailed)
+	}
+
+	err = s.setPassword(ctx, user, req.NewPassword)
+	if err != nil {
+		log.Error("error setting password:", slog.Any("error", err))
+		return 0, err
+	}
+
+	revoked, err := revokeUserSessions(ctx, s.sessions, s.revocations, user.ID, req.SessionID)
+	if err != nil {
+		log.Error("error revoking other sessions:", slog.Any("error", err))
+		return 0, err
+	}
+
+	log.Info("password changed", "revoked_sessions", revoked)
+	return revoked, nil
+}
+
+func (s *passwordService) RequestPasswordReset(ctx context.Context, email, clientIP string) error {
+	op := "auth.password_service.RequestPasswordReset"
+	log := s.log.With("op", op)
+
+	if strings.TrimSpace(email) == "" {
+		return fmt.Errorf("%w: email is required", models.ErrValidationFailed)
+	}
+	// counted before the lookup, so unknown emails are throttled the same way
+	err := s.throttleReset(ctx, email, clientIP)
+	if err != nil {
+		log.Warn("too many password reset requests", "email", email, "client_ip", clientIP)
+		return err
+	}
+
+	user, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		log.Error("error fetching user by email:", slog.Any("error", err))
+		return err
+	}
+	// the caller gets the same answer either way, so emails can't be enumerated
+	if user == nil || user.DisabledAt != nil {
+		log.Warn("password reset for unknown or disabled user")
+		return nil
+	}
+
+	token, err := newRefreshToken()
+	if err != nil {
+		log.Error("error generating reset token:", slog.Any("error", err))
+		return err
+	}
+
+	err = s.resets.Create(ctx, &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(s.cfg.ResetTTL),
+	})
+	if err != nil {
+		log.Error("error saving reset token:", slog.Any("error", err))
+		return err
+	}
+
+	err = s.notifier.SendPasswordReset(ctx, user.Email, s.cfg.ResetURL+token)
+	if err != nil {
+		log.Error("error sending reset link:", slog.Any("error", err))
+		return err
+	}
+
+	return nil
+}
+
+// throttleReset limits reset requests per email, so a mailbox can't be flooded,
+// and per IP, so emails can't be sprayed from one address.
+func (s *passwordService) throttleReset(ctx context.Context, email, clientIP string) error {
+	op := "auth.password_service.throttleReset"
+	log := s.log.With("op", op)
+
+	limits := map[string]int{"reset:" + emailKey(email): s.cfg.ResetMaxRequests}
+	if clientIP != "" {
+		limits["reset:ip:"+clientIP] = s.cfg.ResetIPMaxRequests
+	}
+	for key, maxRequests := range limits {
+		requests, err := s.attempts.RegisterFailure(ctx, key, s.cfg.ResetWindow)
+		if err != nil {
+			// redis being down must not block resets
+			log.Error("error counting reset request, allowing it:", slog.Any("error", err))
+			return nil
+		}
+		if requests > int64(maxRequests) {
+			return &models.TooManyAttemptsError{RetryAfter: s.cfg.ResetWindow}
+		}
+	}
+	return nil
+}
+
+func (s *passwordService) ConfirmPasswordReset(ctx context.Context, req *models.ConfirmPasswordResetRequest) error {
+	op := "auth.password_service.ConfirmPasswordReset"
+	log := s.log.With("op", op)
+
+	if req.Token == "" {
+		return models.ErrInvalidResetToken
+	}
+
+	token, err := s.resets.GetActive(ctx, hashToken(req.Token))
+	if err != nil {
+		log.Error("error fetching reset token:", slog.Any("error", err))
+		return err
+	}
+	if token == nil {
+		log.Warn("reset token is unknown, used or expired")
+		return models.ErrInvalidResetToken
+	}
+
+	user, err := s.users.GetByID(ctx, token.UserID)
+	if err != nil {
+		log.Error("error fetching user:", slog.Any("error", err))
+		return err
+	}
+	if user == nil || user.DisabledAt != nil {
+		return models.ErrInvalidResetToken
+	}
+
+	// a rejected password must not burn the link, so the policy is checked
+	// before the token is marked used
+	err = s.validatePassword(user, req.NewPassword)
+	if err != nil {
+		return err
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error("error hashing password:", slog.Any("error", err))
+		return err
+	}
+
+	// the link is spent only together with the password change
+	used, err := s.resets.Consume(ctx, token, string(passwordHash))
+	if err != nil {
+		log.Error("error setting password:", slog.Any("error", err))
+		return err
+	}
+	if !used {
+		log.Warn("reset token was used concurrently")
+		return models.ErrInvalidResetToken
+	}
+
+	_, err = revokeUserSessions(ctx, s.sessions, s.revocations, user.ID, "")
+	if err != nil {
+		log.Error("error revoking sessions:", slog.Any("error", err))
+		return err
+	}
+	if err := s.limiter.Unlock(ctx, user.Email); err != nil {
+		log.Error("error clearing login lockout:", slog.Any("error", err))
+	}
+
+	log.Info("password reset", "user_id", user.ID)
+	return nil
+}
+
+func (s *passwordService) setPassword(ctx context.Context, user *models.User, password string) error {
+	err := s.validatePassword(user, password)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	return s.users.UpdatePassword(ctx, user.ID, string(passwordHash))
+}
+
+func (s *passwordService) validatePassword(user *models.User, password string) error {
+	if len([]rune(password)) < s.cfg.MinLength {
+		return fmt.Errorf("%w: password must be at least %d characters", models.ErrValidationFailed, s.cfg.MinLength)
+	}
+	// bcrypt ignores everything past 72 bytes
+	if len(password) > 72 {
+		return fmt.Errorf("%w: password must be at most 72 bytes", models.ErrValidationFailed)
+	}
+
+	var upper, lower, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	if !upper || !lower || !digit {
+		return fmt.Errorf("%w: password must contain upper and lower case letters and a digit", models.ErrValidationFailed)
+	}
+
+	if strings.Contains(strings.ToLower(password), strings.ToLower(strings.Split(user.Email, "@")[0])) {
+		return fmt.Errorf("%w: password must not contain the email", models.ErrValidationFailed)
+	}
+	return nil
+}
@@ -2,13 +2,22 @@ package authservice
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/jekiti/citydrive/auth/internal/config"
 	"github.com/jekiti/citydrive/auth/internal/models"
 	authrepository "github.com/jekiti/citydrive/auth/internal/repository"
+	"github.com/jekiti/citydrive/auth/internal/signer"
 	"github.com/sethvargo/go-password/password"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -16,22 +25,74 @@ import (
 type UserService interface {
 	Register(ctx context.Context, req *models.RegisterRequest) (*models.RegisterResponse, error)
 	Login(ctx context.Context, req *models.LoginRequest) (*models.LoginResponse, error)
-
+	Refresh(ctx context.Context, req *models.RefreshRequest) (*models.LoginResponse, error)
+	Logout(ctx context.Context, req *models.LogoutRequest) error
+	ListSessions(ctx context.Context, userID int64) ([]models.Session, error)
+	UnlockUser(ctx context.Context, email string) error
+	ListUsers(ctx context.Context, filter models.ListUsersFilter) ([]models.User, int64, error)
+	GetUser(ctx context.Context, id int64) (*models.User, error)
+	UpdateUser(ctx context.Context, req *models.UpdateUserRequest) (*models.User, error)
+	DisableUser(ctx context.Context, id int64) (*models.User, error)
+	DeleteUser(ctx context.Context, id int64) error
+	VerifyTOTP(ctx context.Context, req *models.VerifyTOTPRequest) (*models.LoginResponse, error)
 }
 
 type userService struct {
-	repo         authrepository.UserRepository
-	log          *slog.Logger
-	jwtSecretKey string
+	repo        authrepository.UserRepository
+	roles       authrepository.RoleRepository
+	sessions    authrepository.SessionRepository
+	revocations authrepository.RevocationRepository
+	limiter     LoginLimiter
+	log         *slog.Logger
+	signer      signer.Signer
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	defaultRole string
+	totp        TOTPService
+	challenges  authrepository.MFARepository
+	mfa         config.MFAConfig
 }
 
-func NewUserService(repo authrepository.UserRepository, log *slog.Logger, jwtSecretKey string) UserService {
-	return &userService{repo: repo, log: log, jwtSecretKey: jwtSecretKey}
+func NewUserService(repo authrepository.UserRepository,
+	roles authrepository.RoleRepository,
+	sessions authrepository.SessionRepository,
+	revocations authrepository.RevocationRepository,
+	limiter LoginLimiter,
+	log *slog.Logger,
+	tokenSigner signer.Signer,
+	accessTTL time.Duration,
+	refreshTTL time.Duration,
+	defaultRole string,
+	totp TOTPService,
+	challenges authrepository.MFARepository,
+	mfa config.MFAConfig) UserService {
+	return &userService{
+		repo:        repo,
+		roles:       roles,
+		sessions:    sessions,
+		revocations: revocations,
+		limiter:     limiter,
+		log:         log,
+		signer:      tokenSigner,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
+		defaultRole: defaultRole,
+		totp:        totp,
+		challenges:  challenges,
+		mfa:         mfa,
+	}
 }
 
 func (s *userService) Register(ctx context.Context, req *models.RegisterRequest) (*models.RegisterResponse, error) {
 	op := "auth.user_service.Register"
 	log := s.log.With("op", op)
+
+	err := validateRegister(req)
+	if err != nil {
+		log.Warn("invalid register request", slog.Any("error", err))
+		return nil, err
+	}
+
 	var user models.User
 	password, err := password.Generate(15, 3, 2, false, false)
 	if err != nil {
@@ -51,10 +112,15 @@ func (s *userService) Register(ctx context.Context, req *models.RegisterRequest)
 		Name:         req.Name,
 		Surname:      req.Surname,
 		Department:   req.Department,
-		PasswordHash: passwordHashString, 
+		PasswordHash: passwordHashString,
+	}
+
+	role := req.Role
+	if role == "" {
+		role = s.defaultRole
 	}
 
-	err = s.repo.Create(ctx, &user)
+	err = s.repo.Create(ctx, &user, role)
 	if err != nil {
 		log.Error("error creating user:", slog.Any("error", err))
 		return nil, err
@@ -63,6 +129,7 @@ func (s *userService) Register(ctx context.Context, req *models.RegisterRequest)
 	return &models.RegisterResponse{
 		Password: password,
 		ID:       user.ID,
+		Role:     role,
 	}, nil
 
 }
@@ -71,6 +138,16 @@ func (s *userService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	op := "auth.user_service.Login"
 	log := s.log.With("op", op)
 
+	if req.Email == "" || req.Password == "" {
+		log.Warn("empty email or password")
+		return nil, fmt.Errorf("%w: email and password are required", models.ErrValidationFailed)
+	}
+
+	err := s.limiter.Check(ctx, req.Email, req.ClientIP)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		log.Error("error fetching user by email:", slog.Any("error", err))
@@ -79,29 +156,292 @@ func (s *userService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 
 	if user == nil {
 		log.Warn("user not found")
-		return nil, fmt.Errorf("invalid credentials")
+		return nil, s.loginFailed(ctx, req)
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password))
 	if err != nil {
-		log.Error("invalid password:", slog.Any("error", err))
-		return nil, fmt.Errorf("invalid credentials")
+		log.Warn("invalid password")
+		return nil, s.loginFailed(ctx, req)
 	}
-	claims := jwt.MapClaims{
-		"iss":   "auth.citydrive",
-		"sub":   fmt.Sprintf("user:%d", user.ID),
-		"email": user.Email,
-		"roles": []string{"admin"},
-		"iat":   time.Now().Unix(),
-		"exp":   time.Now().Add(time.Hour * 1).Unix(),
+
+	if user.DisabledAt != nil {
+		log.Warn("login attempt by disabled user", slog.Int64("user_id", user.ID))
+		return nil, models.ErrUserDisabled
+	}
+
+	// the attempts are reset only once the second factor passes, otherwise the password
+	// alone would buy a fresh set of guesses at the code with every challenge
+	if user.TOTPEnabledAt != nil {
+		return s.startMFAChallenge(ctx, user)
+	}
+
+	s.limiter.Succeed(ctx, req.Email)
+	return s.issueSession(ctx, user)
+}
+
+func (s *userService) issueSession(ctx context.Context, user *models.User) (*models.LoginResponse, error) {
+	op := "auth.user_service.issueSession"
+	log := s.log.With("op", op, "user_id", user.ID)
+
+	err := s.loadRoles(ctx, user)
+	if err != nil {
+		log.Error("error loading user roles:", slog.Any("error", err))
+		return nil, err
+	}
+
+	now := time.Now()
+	accessID, err := newTokenID()
+	if err != nil {
+		log.Error("error generating token id:", slog.Any("error", err))
+		return nil, err
+	}
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		log.Error("error generating refresh token:", slog.Any("error", err))
+		return nil, err
+	}
+
+	session := &models.Session{
+		UserID:           user.ID,
+		RefreshTokenHash: hashToken(refreshToken),
+		AccessTokenID:    accessID,
+		AccessExpiresAt:  now.Add(s.accessTTL),
+		ExpiresAt:        now.Add(s.refreshTTL),
+	}
+	err = s.sessions.Create(ctx, session)
+	if err != nil {
+		log.Error("error creating session:", slog.Any("error", err))
+		return nil, err
+	}
+
+	tokenString, err := s.signAccessToken(user, session, now)
+	if err != nil {
+		log.Error("error signing token:", slog.Any("error", err))
+		return nil, err
+	}
+	return &models.LoginResponse{
+		AccessToken:  tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.accessTTL.Seconds()),
+	}, nil
+}
+
+func (s *userService) Refresh(ctx context.Context, req *models.RefreshRequest) (*models.LoginResponse, error) {
+	op := "auth.user_service.Refresh"
+	log := s.log.With("op", op)
+
+	prevHash := hashToken(req.RefreshToken)
+	session, err := s.sessions.GetByRefreshHash(ctx, prevHash)
+	if err != nil {
+		log.Error("error fetching session:", slog.Any("error", err))
+		return nil, err
+	}
+	now := time.Now()
+	if session == nil || session.RevokedAt != nil || !session.ExpiresAt.After(now) {
+		log.Warn("refresh token is unknown, revoked or expired")
+		return nil, models.ErrInvalidRefreshToken
+	}
+	if session.RefreshTokenHash != prevHash {
+		log.Warn("rotated refresh token reused, revoking session", "session_id", session.ID)
+		return nil, s.revokeReusedSession(ctx, session)
+	}
+
+	user, err := s.repo.GetByID(ctx, session.UserID)
+	if err != nil {
+		log.Error("error fetching user by id:", slog.Any("error", err))
+		return nil, err
+	}
+	if user == nil {
+		log.Warn("session user not found", slog.Int64("user_id", session.UserID))
+		return nil, models.ErrInvalidRefreshToken
+	}
+	if user.DisabledAt != nil {
+		log.Warn("refresh by disabled user", slog.Int64("user_id", user.ID))
+		return nil, models.ErrUserDisabled
+	}
+
+	err = s.loadRoles(ctx, user)
+	if err != nil {
+		log.Error("error loading user roles:", slog.Any("error", err))
+		return nil, err
+	}
+
+	prevAccessID, prevAccessExpiresAt := session.AccessTokenID, session.AccessExpiresAt
+
+	accessID, err := newTokenID()
+	if err != nil {
+		log.Error("error generating token id:", slog.Any("error", err))
+		return nil, err
+	}
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		log.Error("error generating refresh token:", slog.Any("error", err))
+		return nil, err
 	}
+	session.RefreshTokenHash = hashToken(refreshToken)
+	session.AccessTokenID = accessID
+	session.AccessExpiresAt = now.Add(s.accessTTL)
+	session.ExpiresAt = now.Add(s.refreshTTL)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	err = s.sessions.Rotate(ctx, session, prevHash)
+	if err != nil {
+		if errors.Is(err, models.ErrSessionNotFound) {
+			// a concurrent refresh won with the same token, only one of them can be legitimate
+			log.Warn("refresh token used concurrently, revoking session", "session_id", session.ID)
+			return nil, s.revokeReusedSession(ctx, session)
+		}
+		log.Error("error rotating session:", slog.Any("error", err))
+		return nil, err
+	}
 
-	tokenString, err := token.SignedString([]byte(s.jwtSecretKey))
+	err = s.revocations.Revoke(ctx, prevAccessID, prevAccessExpiresAt.Sub(now))
+	if err != nil {
+		log.Error("error revoking previous access token:", slog.Any("error", err))
+		return nil, err
+	}
+
+	tokenString, err := s.signAccessToken(user, session, now)
 	if err != nil {
 		log.Error("error signing token:", slog.Any("error", err))
 		return nil, err
 	}
-	return &models.LoginResponse{AccessToken: tokenString}, nil
+	return &models.LoginResponse{
+		AccessToken:  tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.accessTTL.Seconds()),
+	}, nil
+}
+
+// revokeReusedSession revokes a session whose refresh token was presented twice
+// together with its current access token.
+func (s *userService) revokeReusedSession(ctx context.Context, session *models.Session) error {
+	op := "auth.user_service.revokeReusedSession"
+	log := s.log.With("op", op, "user_id", session.UserID, "session_id", session.ID)
+
+	revoked, err := s.sessions.Revoke(ctx, session.UserID, session.ID)
+	if err != nil {
+		log.Error("error revoking session:", slog.Any("error", err))
+		return err
+	}
+	if revoked == nil {
+		return models.ErrInvalidRefreshToken
+	}
+	err = s.revocations.Revoke(ctx, revoked.AccessTokenID, time.Until(revoked.AccessExpiresAt))
+	if err != nil {
+		log.Error("error revoking access token:", slog.Any("error", err))
+		return err
+	}
+	return models.ErrInvalidRefreshToken
+}
+
+func (s *userService) Logout(ctx context.Context, req *models.LogoutRequest) error {
+	op := "auth.user_service.Logout"
+	log := s.log.With("op", op, "user_id", req.UserID, "session_id", req.SessionID)
+
+	if !uuidRegexp.MatchString(req.SessionID) {
+		return models.ErrSessionNotFound
+	}
+
+	session, err := s.sessions.Revoke(ctx, req.UserID, req.SessionID)
+	if err != nil {
+		log.Error("error revoking session:", slog.Any("error", err))
+		return err
+	}
+	if session == nil {
+		return models.ErrSessionNotFound
+	}
+
+	err = s.revocations.Revoke(ctx, session.AccessTokenID, time.Until(session.AccessExpiresAt))
+	if err != nil {
+		log.Error("error revoking access token:", slog.Any("error", err))
+		return err
+	}
+
+	log.Info("session revoked")
+	return nil
+}
+
+func (s *userService) ListSessions(ctx context.Context, userID int64) ([]models.Session, error) {
+	op := "auth.user_service.ListSessions"
+	log := s.log.With("op", op, "user_id", userID)
+
+	sessions, err := s.sessions.ListActiveByUser(ctx, userID)
+	if err != nil {
+		log.Error("error listing sessions:", slog.Any("error", err))
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *userService) signAccessToken(user *models.User, session *models.Session, now time.Time) (string, error) {
+	claims := jwt.MapClaims{
+		"iss":         "auth.citydrive",
+		"sub":         fmt.Sprintf("user:%d", user.ID),
+		"email":       user.Email,
+		"roles":       user.Roles,
+		"permissions": user.Permissions,
+		"jti":         session.AccessTokenID,
+		"sid":         session.ID,
+		"iat":         now.Unix(),
+		"exp":         session.AccessExpiresAt.Unix(),
+	}
+
+	return s.signer.Sign(claims)
+}
+
+func (s *userService) UnlockUser(ctx context.Context, email string) error {
+	op := "auth.user_service.UnlockUser"
+	log := s.log.With("op", op)
+
+	if strings.TrimSpace(email) == "" {
+		return fmt.Errorf("%w: email is required", models.ErrValidationFailed)
+	}
+	err := s.limiter.Unlock(ctx, email)
+	if err != nil {
+		log.Error("error unlocking user:", slog.Any("error", err))
+		return err
+	}
+	log.Info("user login unlocked", "email", email)
+	return nil
+}
+
+func (s *userService) loginFailed(ctx context.Context, req *models.LoginRequest) error {
+	if err := s.limiter.Fail(ctx, req.Email, req.ClientIP); err != nil {
+		return err
+	}
+	return models.ErrInvalidCredentials
+}
+
+func (s *userService) loadRoles(ctx context.Context, user *models.User) error {
+	roles, permissions, err := s.roles.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	user.Roles = roles
+	user.Permissions = permissions
+	return nil
+}
+
+func validateRegister(req *models.RegisterRequest) error {
+	if _, err := mail.ParseAddress(req.Email); err != nil || strings.ContainsAny(req.Email, "<> ") {
+		return fmt.Errorf("%w: invalid email", models.ErrValidationFailed)
+	}
+	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Surname) == "" || strings.TrimSpace(req.Department) == "" {
+		return fmt.Errorf("%w: name, surname and department are required", models.ErrValidationFailed)
+	}
+	return nil
+}
+
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
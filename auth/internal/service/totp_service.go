@@ -0,0 +1,196 @@
+package authservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jekiti/citydrive/auth/internal/config"
+	"github.com/jekiti/citydrive/auth/internal/models"
+	authrepository "github.com/jekiti/citydrive/auth/internal/repository"
+	"github.com/jekiti/citydrive/auth/internal/secretbox"
+	"github.com/jekiti/citydrive/auth/internal/totp"
+)
+
+const totpSkew = 1
+
+type TOTPService interface {
+	EnrollTOTP(ctx context.Context, userID int64) (*models.TOTPEnrollment, error)
+	ActivateTOTP(ctx context.Context, userID int64, code string) ([]string, error)
+	CheckCode(ctx context.Context, user *models.User, code, recoveryCode string) error
+}
+
+type totpService struct {
+	users authrepository.UserRepository
+	mfa   authrepository.MFARepository
+	box   *secretbox.Box
+	log   *slog.Logger
+	cfg   config.MFAConfig
+}
+
+func NewTOTPService(users authrepository.UserRepository,
+	mfa authrepository.MFARepository,
+	box *secretbox.Box,
+	log *slog.Logger,
+	cfg config.MFAConfig) TOTPService {
+	return &totpService{users: users, mfa: mfa, box: box, log: log, cfg: cfg}
+}
+
+func (s *totpService) EnrollTOTP(ctx context.Context, userID int64) (*models.TOTPEnrollment, error) {
+	op := "auth.totp_service.EnrollTOTP"
+	log := s.log.With("op", op, "user_id", userID)
+
+	if s.box == nil {
+		return nil, models.ErrTOTPUnavailable
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching user:", slog.Any("error", err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, models.ErrUserNotFound
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, models.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Error("error generating totp secret:", slog.Any("error", err))
+		return nil, err
+	}
+	encrypted, err := s.box.Seal(secret)
+	if err != nil {
+		log.Error("error encrypting totp secret:", slog.Any("error", err))
+		return nil, err
+	}
+	err = s.users.SetTOTPSecret(ctx, userID, encrypted)
+	if err != nil {
+		log.Error("error saving totp secret:", slog.Any("error", err))
+		return nil, err
+	}
+
+	log.Info("totp enrollment started")
+	return &models.TOTPEnrollment{
+		Secret: secret,
+		URL:    totp.URL(s.cfg.Issuer, user.Email, secret),
+	}, nil
+}
+
+func (s *totpService) ActivateTOTP(ctx context.Context, userID int64, code string) ([]string, error) {
+	op := "auth.totp_service.ActivateTOTP"
+	log := s.log.With("op", op, "user_id", userID)
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching user:", slog.Any("error", err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, models.ErrUserNotFound
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, models.ErrTOTPAlreadyEnabled
+	}
+	if user.TOTPSecret == nil {
+		return nil, models.ErrTOTPNotEnrolled
+	}
+
+	err = s.checkTOTP(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, s.cfg.RecoveryCodeCount)
+	hashes := make([]string, s.cfg.RecoveryCodeCount)
+	for i := range codes {
+		codes[i], err = newRecoveryCode()
+		if err != nil {
+			log.Error("error generating recovery code:", slog.Any("error", err))
+			return nil, err
+		}
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+
+	err = s.users.EnableTOTP(ctx, userID, hashes)
+	if err != nil {
+		log.Error("error enabling totp:", slog.Any("error", err))
+		return nil, err
+	}
+
+	log.Info("totp enabled")
+	return codes, nil
+}
+
+func (s *totpService) CheckCode(ctx context.Context, user *models.User, code, recoveryCode string) error {
+	op := "auth.totp_service.CheckCode"
+	log := s.log.With("op", op, "user_id", user.ID)
+
+	if recoveryCode != "" {
+		used, err := s.users.UseRecoveryCode(ctx, user.ID, hashToken(normalizeRecoveryCode(recoveryCode)))
+		if err != nil {
+			log.Error("error checking recovery code:", slog.Any("error", err))
+			return err
+		}
+		if !used {
+			return models.ErrInvalidTOTPCode
+		}
+		log.Info("recovery code used")
+		return nil
+	}
+	return s.checkTOTP(ctx, user, code)
+}
+
+func (s *totpService) checkTOTP(ctx context.Context, user *models.User, code string) error {
+	op := "auth.totp_service.checkTOTP"
+	log := s.log.With("op", op, "user_id", user.ID)
+
+	if s.box == nil {
+		return models.ErrTOTPUnavailable
+	}
+	if user.TOTPSecret == nil {
+		return models.ErrTOTPNotEnrolled
+	}
+	secret, err := s.box.Open(*user.TOTPSecret)
+	if err != nil {
+		log.Error("error decrypting totp secret:", slog.Any("error", err))
+		return err
+	}
+
+	step, ok := totp.Validate(secret, strings.TrimSpace(code), time.Now(), totpSkew)
+	if !ok {
+		return models.ErrInvalidTOTPCode
+	}
+
+	// a code stays valid for the whole skew window, remember it so it can't be replayed
+	fresh, err := s.mfa.MarkCodeUsed(ctx, user.ID, step, time.Duration(2*totpSkew+1)*totp.Period)
+	if err != nil {
+		log.Error("error marking totp code used:", slog.Any("error", err))
+		return err
+	}
+	if !fresh {
+		log.Warn("totp code replayed")
+		return models.ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))
+	return code[:8] + "-" + code[8:16], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,145 @@
+package authservice
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jekiti/citydrive/auth/internal/models"
+	authrepository "github.com/jekiti/citydrive/auth/internal/repository"
+)
+
+const (
+	defaultUsersLimit = 50
+	maxUsersLimit     = 200
+)
+
+func (s *userService) ListUsers(ctx context.Context, filter models.ListUsersFilter) ([]models.User, int64, error) {
+	op := "auth.user_service.ListUsers"
+	log := s.log.With("op", op)
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, 0, fmt.Errorf("%w: limit and offset must not be negative", models.ErrValidationFailed)
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultUsersLimit
+	}
+	if filter.Limit > maxUsersLimit {
+		filter.Limit = maxUsersLimit
+	}
+
+	users, total, err := s.repo.List(ctx, filter)
+	if err != nil {
+		log.Error("error listing users:", slog.Any("error", err))
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (s *userService) GetUser(ctx context.Context, id int64) (*models.User, error) {
+	op := "auth.user_service.GetUser"
+	log := s.log.With("op", op, "user_id", id)
+
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Error("error fetching user:", slog.Any("error", err))
+		return nil, err
+	}
+	if user == nil {
+		return nil, models.ErrUserNotFound
+	}
+
+	err = s.loadRoles(ctx, user)
+	if err != nil {
+		log.Error("error loading user roles:", slog.Any("error", err))
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *userService) UpdateUser(ctx context.Context, req *models.UpdateUserRequest) (*models.User, error) {
+	op := "auth.user_service.UpdateUser"
+	log := s.log.With("op", op, "user_id", req.ID)
+
+	for field, value := range map[string]*string{
+		"name":       req.Name,
+		"surname":    req.Surname,
+		"department": req.Department,
+		"role":       req.Role,
+	} {
+		if value != nil && strings.TrimSpace(*value) == "" {
+			return nil, fmt.Errorf("%w: %s must not be empty", models.ErrValidationFailed, field)
+		}
+	}
+
+	err := s.repo.Update(ctx, req)
+	if err != nil {
+		log.Error("error updating user:", slog.Any("error", err))
+		return nil, err
+	}
+
+	log.Info("user updated")
+	return s.GetUser(ctx, req.ID)
+}
+
+func (s *userService) DisableUser(ctx context.Context, id int64) (*models.User, error) {
+	op := "auth.user_service.DisableUser"
+	log := s.log.With("op", op, "user_id", id)
+
+	err := s.repo.Disable(ctx, id)
+	if err != nil {
+		log.Error("error disabling user:", slog.Any("error", err))
+		return nil, err
+	}
+
+	_, err = revokeUserSessions(ctx, s.sessions, s.revocations, id, "")
+	if err != nil {
+		log.Error("error revoking sessions of disabled user:", slog.Any("error", err))
+		return nil, err
+	}
+
+	log.Info("user disabled")
+	return s.GetUser(ctx, id)
+}
+
+func (s *userService) DeleteUser(ctx context.Context, id int64) error {
+	op := "auth.user_service.DeleteUser"
+	log := s.log.With("op", op, "user_id", id)
+
+	// sessions are removed by cascade, so their access tokens have to be
+	// denylisted before the rows are gone
+	_, err := revokeUserSessions(ctx, s.sessions, s.revocations, id, "")
+	if err != nil {
+		log.Error("error revoking sessions of deleted user:", slog.Any("error", err))
+		return err
+	}
+
+	err = s.repo.Delete(ctx, id)
+	if err != nil {
+		log.Error("error deleting user:", slog.Any("error", err))
+		return err
+	}
+
+	log.Info("user deleted")
+	return nil
+}
+
+func revokeUserSessions(ctx context.Context,
+	sessions authrepository.SessionRepository,
+	revocations authrepository.RevocationRepository,
+	userID int64,
+	exceptSessionID string) (int, error) {
+	revoked, err := sessions.RevokeAllByUser(ctx, userID, exceptSessionID)
+	if err != nil {
+		return 0, err
+	}
+	for _, session := range revoked {
+		err = revocations.Revoke(ctx, session.AccessTokenID, time.Until(session.AccessExpiresAt))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(revoked), nil
+}
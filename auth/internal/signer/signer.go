@@ -0,0 +1,221 @@
+package signer
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+type Signer interface {
+	Sign(claims jwt.MapClaims) (string, error)
+}
+
+type hmacSigner struct {
+	secret []byte
+}
+
+func NewHMAC(secret string) Signer {
+	return &hmacSigner{secret: []byte(secret)}
+}
+
+func (s *hmacSigner) Sign(claims jwt.MapClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet signs tokens with the active private key and publishes the active
+// public key together with the previous ones, so tokens issued before a
+// rotation stay verifiable until they expire.
+type KeySet struct {
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey
+	kid        string
+	keys       []JWK
+}
+
+func LoadKeySet(alg, privateKeyPath, publicKeysPath string) (*KeySet, error) {
+	if privateKeyPath == "" {
+		return nil, fmt.Errorf("private key path is required for %s", alg)
+	}
+	data, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %w", err)
+	}
+
+	set := &KeySet{}
+	var publicKey crypto.PublicKey
+	switch alg {
+	case AlgRS256:
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse RSA private key: %w", err)
+		}
+		set.method = jwt.SigningMethodRS256
+		set.privateKey = key
+		publicKey = &key.PublicKey
+	case AlgEdDSA:
+		key, err := jwt.ParseEdPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse Ed25519 private key: %w", err)
+		}
+		edKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("private key is not Ed25519")
+		}
+		set.method = jwt.SigningMethodEdDSA
+		set.privateKey = edKey
+		publicKey = edKey.Public()
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", alg)
+	}
+
+	active, err := newJWK(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	set.kid = active.Kid
+	set.keys = append(set.keys, active)
+
+	if publicKeysPath == "" {
+		return set, nil
+	}
+	data, err = os.ReadFile(publicKeysPath)
+	if err != nil {
+		return nil, fmt.Errorf("read public keys: %w", err)
+	}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse public key: %w", err)
+		}
+		jwk, err := newJWK(key)
+		if err != nil {
+			return nil, err
+		}
+		if !set.hasKey(jwk.Kid) {
+			set.keys = append(set.keys, jwk)
+		}
+	}
+
+	return set, nil
+}
+
+func (k *KeySet) Sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(k.method, claims)
+	token.Header["kid"] = k.kid
+	return token.SignedString(k.privateKey)
+}
+
+func (k *KeySet) KeyID() string {
+	return k.kid
+}
+
+func (k *KeySet) JWKS() JWKS {
+	keys := make([]JWK, len(k.keys))
+	copy(keys, k.keys)
+	return JWKS{Keys: keys}
+}
+
+func (k *KeySet) hasKey(kid string) bool {
+	for _, key := range k.keys {
+		if key.Kid == kid {
+			return true
+		}
+	}
+	return false
+}
+
+func newJWK(key crypto.PublicKey) (JWK, error) {
+	var jwk JWK
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		jwk = JWK{
+			Kty: "RSA",
+			Alg: AlgRS256,
+			N:   encode(key.N.Bytes()),
+			E:   encode(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		jwk = JWK{
+			Kty: "OKP",
+			Alg: AlgEdDSA,
+			Crv: "Ed25519",
+			X:   encode(key),
+		}
+	default:
+		return JWK{}, fmt.Errorf("unsupported public key type %T", key)
+	}
+	jwk.Use = "sig"
+
+	kid, err := thumbprint(jwk)
+	if err != nil {
+		return JWK{}, err
+	}
+	jwk.Kid = kid
+	return jwk, nil
+}
+
+// thumbprint computes the RFC 7638 key thumbprint, used as kid.
+func thumbprint(jwk JWK) (string, error) {
+	var members any
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return encode(sum[:]), nil
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,31 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jekiti/citydrive/auth/internal/config"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+func NewRedis(cfg config.RedisConfig) (*goredis.Client, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("redis host can't be empty")
+	}
+
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     cfg.Host + ":" + cfg.Port,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("cannot ping redis: %w", err)
+	}
+
+	return client, nil
+}
@@ -943,6 +943,1579 @@ func (x *GetCarHistoryResponse) GetStates() []*CarState {
 	return nil
 }
 
+// Заправка, найденная telemetry по росту уровня топлива
+type Refuel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CarId         string                 `protobuf:"bytes,2,opt,name=car_id,json=carId,proto3" json:"car_id,omitempty"`
+	StartedAt     int64                  `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // unix timestamp (sec)
+	EndedAt       int64                  `protobuf:"varint,4,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`       // unix timestamp (sec)
+	Lat           float64                `protobuf:"fixed64,5,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,6,opt,name=lon,proto3" json:"lon,omitempty"`
+	Odo           int64                  `protobuf:"varint,7,opt,name=odo,proto3" json:"odo,omitempty"`
+	FuelBefore    float64                `protobuf:"fixed64,8,opt,name=fuel_before,json=fuelBefore,proto3" json:"fuel_before,omitempty"`        // % бака
+	FuelAfter     float64                `protobuf:"fixed64,9,opt,name=fuel_after,json=fuelAfter,proto3" json:"fuel_after,omitempty"`           // % бака
+	VolumeLiters  float64                `protobuf:"fixed64,10,opt,name=volume_liters,json=volumeLiters,proto3" json:"volume_liters,omitempty"` // оценка по объему бака модели, 0 — нет нормы для модели
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Refuel) Reset() {
+	*x = Refuel{}
+	mi := &file_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Refuel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Refuel) ProtoMessage() {}
+
+func (x *Refuel) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Refuel.ProtoReflect.Descriptor instead.
+func (*Refuel) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Refuel) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Refuel) GetCarId() string {
+	if x != nil {
+		return x.CarId
+	}
+	return ""
+}
+
+func (x *Refuel) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Refuel) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+func (x *Refuel) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Refuel) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *Refuel) GetOdo() int64 {
+	if x != nil {
+		return x.Odo
+	}
+	return 0
+}
+
+func (x *Refuel) GetFuelBefore() float64 {
+	if x != nil {
+		return x.FuelBefore
+	}
+	return 0
+}
+
+func (x *Refuel) GetFuelAfter() float64 {
+	if x != nil {
+		return x.FuelAfter
+	}
+	return 0
+}
+
+func (x *Refuel) GetVolumeLiters() float64 {
+	if x != nil {
+		return x.VolumeLiters
+	}
+	return 0
+}
+
+// GET /api/v1/cars/refuels?from=&to=&car_id=
+type ListRefuelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int64                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`               // unix timestamp (sec), по started_at
+	To            int64                  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`                   // unix timestamp (sec)
+	CarId         string                 `protobuf:"bytes,3,opt,name=car_id,json=carId,proto3" json:"car_id,omitempty"` // пусто — все машины
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefuelsRequest) Reset() {
+	*x = ListRefuelsRequest{}
+	mi := &file_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefuelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefuelsRequest) ProtoMessage() {}
+
+func (x *ListRefuelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefuelsRequest.ProtoReflect.Descriptor instead.
+func (*ListRefuelsRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListRefuelsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ListRefuelsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ListRefuelsRequest) GetCarId() string {
+	if x != nil {
+		return x.CarId
+	}
+	return ""
+}
+
+type ListRefuelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refuels       []*Refuel              `protobuf:"bytes,1,rep,name=refuels,proto3" json:"refuels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefuelsResponse) Reset() {
+	*x = ListRefuelsResponse{}
+	mi := &file_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefuelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefuelsResponse) ProtoMessage() {}
+
+func (x *ListRefuelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefuelsResponse.ProtoReflect.Descriptor instead.
+func (*ListRefuelsResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListRefuelsResponse) GetRefuels() []*Refuel {
+	if x != nil {
+		return x.Refuels
+	}
+	return nil
+}
+
+// Правило нарушения из citydrive.violation_rules. telemetry подхватывает изменения без рестарта.
+type ViolationRule struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // тип нарушения в Kafka, snake_case, уникален
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Condition       string                 `protobuf:"bytes,4,opt,name=condition,proto3" json:"condition,omitempty"`                                     // выражение над полями показания и prev, например "speed > 110"
+	Severity        string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`                                       // low, medium, high, critical
+	CooldownSeconds int32                  `protobuf:"varint,6,opt,name=cooldown_seconds,json=cooldownSeconds,proto3" json:"cooldown_seconds,omitempty"` // сколько нарушения может не быть, прежде чем эпизод закроется, 0 — сразу
+	Enabled         bool                   `protobuf:"varint,7,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CreatedAt       int64                  `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // unix timestamp (sec)
+	UpdatedAt       int64                  `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // unix timestamp (sec)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ViolationRule) Reset() {
+	*x = ViolationRule{}
+	mi := &file_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViolationRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViolationRule) ProtoMessage() {}
+
+func (x *ViolationRule) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViolationRule.ProtoReflect.Descriptor instead.
+func (*ViolationRule) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ViolationRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ViolationRule) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ViolationRule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ViolationRule) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *ViolationRule) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *ViolationRule) GetCooldownSeconds() int32 {
+	if x != nil {
+		return x.CooldownSeconds
+	}
+	return 0
+}
+
+func (x *ViolationRule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ViolationRule) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ViolationRule) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// GET /api/v1/violation-rules
+type ListViolationRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViolationRulesRequest) Reset() {
+	*x = ListViolationRulesRequest{}
+	mi := &file_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViolationRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViolationRulesRequest) ProtoMessage() {}
+
+func (x *ListViolationRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViolationRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListViolationRulesRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{17}
+}
+
+type ListViolationRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*ViolationRule       `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViolationRulesResponse) Reset() {
+	*x = ListViolationRulesResponse{}
+	mi := &file_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViolationRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViolationRulesResponse) ProtoMessage() {}
+
+func (x *ListViolationRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViolationRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListViolationRulesResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListViolationRulesResponse) GetRules() []*ViolationRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// GET /api/v1/violation-rules/{id}
+type GetViolationRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetViolationRuleRequest) Reset() {
+	*x = GetViolationRuleRequest{}
+	mi := &file_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetViolationRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetViolationRuleRequest) ProtoMessage() {}
+
+func (x *GetViolationRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetViolationRuleRequest.ProtoReflect.Descriptor instead.
+func (*GetViolationRuleRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetViolationRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetViolationRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *ViolationRule         `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetViolationRuleResponse) Reset() {
+	*x = GetViolationRuleResponse{}
+	mi := &file_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetViolationRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetViolationRuleResponse) ProtoMessage() {}
+
+func (x *GetViolationRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetViolationRuleResponse.ProtoReflect.Descriptor instead.
+func (*GetViolationRuleResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetViolationRuleResponse) GetRule() *ViolationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// POST /api/v1/violation-rules
+type CreateViolationRuleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Description     string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Condition       string                 `protobuf:"bytes,3,opt,name=condition,proto3" json:"condition,omitempty"`
+	Severity        string                 `protobuf:"bytes,4,opt,name=severity,proto3" json:"severity,omitempty"`
+	CooldownSeconds int32                  `protobuf:"varint,5,opt,name=cooldown_seconds,json=cooldownSeconds,proto3" json:"cooldown_seconds,omitempty"`
+	Enabled         *bool                  `protobuf:"varint,6,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"` // по умолчанию true
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateViolationRuleRequest) Reset() {
+	*x = CreateViolationRuleRequest{}
+	mi := &file_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateViolationRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateViolationRuleRequest) ProtoMessage() {}
+
+func (x *CreateViolationRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateViolationRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateViolationRuleRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateViolationRuleRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateViolationRuleRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateViolationRuleRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *CreateViolationRuleRequest) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *CreateViolationRuleRequest) GetCooldownSeconds() int32 {
+	if x != nil {
+		return x.CooldownSeconds
+	}
+	return 0
+}
+
+func (x *CreateViolationRuleRequest) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+type CreateViolationRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *ViolationRule         `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateViolationRuleResponse) Reset() {
+	*x = CreateViolationRuleResponse{}
+	mi := &file_admin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateViolationRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateViolationRuleResponse) ProtoMessage() {}
+
+func (x *CreateViolationRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateViolationRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateViolationRuleResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateViolationRuleResponse) GetRule() *ViolationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// PUT /api/v1/violation-rules/{id}, правило заменяется целиком
+type UpdateViolationRuleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type            string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Condition       string                 `protobuf:"bytes,4,opt,name=condition,proto3" json:"condition,omitempty"`
+	Severity        string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`
+	CooldownSeconds int32                  `protobuf:"varint,6,opt,name=cooldown_seconds,json=cooldownSeconds,proto3" json:"cooldown_seconds,omitempty"`
+	Enabled         *bool                  `protobuf:"varint,7,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"` // по умолчанию true
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateViolationRuleRequest) Reset() {
+	*x = UpdateViolationRuleRequest{}
+	mi := &file_admin_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateViolationRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateViolationRuleRequest) ProtoMessage() {}
+
+func (x *UpdateViolationRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateViolationRuleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateViolationRuleRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateViolationRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateViolationRuleRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateViolationRuleRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateViolationRuleRequest) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *UpdateViolationRuleRequest) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *UpdateViolationRuleRequest) GetCooldownSeconds() int32 {
+	if x != nil {
+		return x.CooldownSeconds
+	}
+	return 0
+}
+
+func (x *UpdateViolationRuleRequest) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+type UpdateViolationRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *ViolationRule         `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateViolationRuleResponse) Reset() {
+	*x = UpdateViolationRuleResponse{}
+	mi := &file_admin_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateViolationRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateViolationRuleResponse) ProtoMessage() {}
+
+func (x *UpdateViolationRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateViolationRuleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateViolationRuleResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateViolationRuleResponse) GetRule() *ViolationRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+// DELETE /api/v1/violation-rules/{id}
+type DeleteViolationRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteViolationRuleRequest) Reset() {
+	*x = DeleteViolationRuleRequest{}
+	mi := &file_admin_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteViolationRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViolationRuleRequest) ProtoMessage() {}
+
+func (x *DeleteViolationRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViolationRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteViolationRuleRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteViolationRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteViolationRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteViolationRuleResponse) Reset() {
+	*x = DeleteViolationRuleResponse{}
+	mi := &file_admin_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteViolationRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteViolationRuleResponse) ProtoMessage() {}
+
+func (x *DeleteViolationRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteViolationRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteViolationRuleResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{26}
+}
+
+type GeoPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeoPoint) Reset() {
+	*x = GeoPoint{}
+	mi := &file_admin_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeoPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeoPoint) ProtoMessage() {}
+
+func (x *GeoPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeoPoint.ProtoReflect.Descriptor instead.
+func (*GeoPoint) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GeoPoint) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GeoPoint) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+type Geozone struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Polygon       []*GeoPoint            `protobuf:"bytes,3,rep,name=polygon,proto3" json:"polygon,omitempty"`                                   // вершины по порядку, не меньше трёх, замыкать не нужно
+	SpeedLimit    int32                  `protobuf:"varint,4,opt,name=speed_limit,json=speedLimit,proto3" json:"speed_limit,omitempty"`          // км/ч, 0 — без ограничения
+	NoEntry       bool                   `protobuf:"varint,5,opt,name=no_entry,json=noEntry,proto3" json:"no_entry,omitempty"`                   // въезд запрещён в любое время
+	OperatingArea bool                   `protobuf:"varint,6,opt,name=operating_area,json=operatingArea,proto3" json:"operating_area,omitempty"` // зона обслуживания, выезд за все такие зоны — нарушение
+	AllowedFrom   string                 `protobuf:"bytes,7,opt,name=allowed_from,json=allowedFrom,proto3" json:"allowed_from,omitempty"`        // "HH:MM" по местному времени, пусто вместе с allowed_to — в любое время
+	AllowedTo     string                 `protobuf:"bytes,8,opt,name=allowed_to,json=allowedTo,proto3" json:"allowed_to,omitempty"`              // "HH:MM", может быть раньше allowed_from, тогда окно через полночь
+	Enabled       bool                   `protobuf:"varint,9,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // unix timestamp (sec)
+	UpdatedAt     int64                  `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // unix timestamp (sec)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Geozone) Reset() {
+	*x = Geozone{}
+	mi := &file_admin_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Geozone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Geozone) ProtoMessage() {}
+
+func (x *Geozone) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Geozone.ProtoReflect.Descriptor instead.
+func (*Geozone) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Geozone) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Geozone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Geozone) GetPolygon() []*GeoPoint {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+func (x *Geozone) GetSpeedLimit() int32 {
+	if x != nil {
+		return x.SpeedLimit
+	}
+	return 0
+}
+
+func (x *Geozone) GetNoEntry() bool {
+	if x != nil {
+		return x.NoEntry
+	}
+	return false
+}
+
+func (x *Geozone) GetOperatingArea() bool {
+	if x != nil {
+		return x.OperatingArea
+	}
+	return false
+}
+
+func (x *Geozone) GetAllowedFrom() string {
+	if x != nil {
+		return x.AllowedFrom
+	}
+	return ""
+}
+
+func (x *Geozone) GetAllowedTo() string {
+	if x != nil {
+		return x.AllowedTo
+	}
+	return ""
+}
+
+func (x *Geozone) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Geozone) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Geozone) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// GET /api/v1/geozones
+type ListGeozonesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGeozonesRequest) Reset() {
+	*x = ListGeozonesRequest{}
+	mi := &file_admin_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGeozonesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGeozonesRequest) ProtoMessage() {}
+
+func (x *ListGeozonesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGeozonesRequest.ProtoReflect.Descriptor instead.
+func (*ListGeozonesRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{29}
+}
+
+type ListGeozonesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Geozones      []*Geozone             `protobuf:"bytes,1,rep,name=geozones,proto3" json:"geozones,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGeozonesResponse) Reset() {
+	*x = ListGeozonesResponse{}
+	mi := &file_admin_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGeozonesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGeozonesResponse) ProtoMessage() {}
+
+func (x *ListGeozonesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGeozonesResponse.ProtoReflect.Descriptor instead.
+func (*ListGeozonesResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListGeozonesResponse) GetGeozones() []*Geozone {
+	if x != nil {
+		return x.Geozones
+	}
+	return nil
+}
+
+// GET /api/v1/geozones/{id}
+type GetGeozoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGeozoneRequest) Reset() {
+	*x = GetGeozoneRequest{}
+	mi := &file_admin_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGeozoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGeozoneRequest) ProtoMessage() {}
+
+func (x *GetGeozoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGeozoneRequest.ProtoReflect.Descriptor instead.
+func (*GetGeozoneRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetGeozoneRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetGeozoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Geozone       *Geozone               `protobuf:"bytes,1,opt,name=geozone,proto3" json:"geozone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGeozoneResponse) Reset() {
+	*x = GetGeozoneResponse{}
+	mi := &file_admin_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGeozoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGeozoneResponse) ProtoMessage() {}
+
+func (x *GetGeozoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGeozoneResponse.ProtoReflect.Descriptor instead.
+func (*GetGeozoneResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetGeozoneResponse) GetGeozone() *Geozone {
+	if x != nil {
+		return x.Geozone
+	}
+	return nil
+}
+
+// POST /api/v1/geozones
+type CreateGeozoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Polygon       []*GeoPoint            `protobuf:"bytes,2,rep,name=polygon,proto3" json:"polygon,omitempty"`
+	SpeedLimit    int32                  `protobuf:"varint,3,opt,name=speed_limit,json=speedLimit,proto3" json:"speed_limit,omitempty"`
+	NoEntry       bool                   `protobuf:"varint,4,opt,name=no_entry,json=noEntry,proto3" json:"no_entry,omitempty"`
+	OperatingArea bool                   `protobuf:"varint,5,opt,name=operating_area,json=operatingArea,proto3" json:"operating_area,omitempty"`
+	AllowedFrom   string                 `protobuf:"bytes,6,opt,name=allowed_from,json=allowedFrom,proto3" json:"allowed_from,omitempty"`
+	AllowedTo     string                 `protobuf:"bytes,7,opt,name=allowed_to,json=allowedTo,proto3" json:"allowed_to,omitempty"`
+	Enabled       *bool                  `protobuf:"varint,8,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"` // по умолчанию true
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGeozoneRequest) Reset() {
+	*x = CreateGeozoneRequest{}
+	mi := &file_admin_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGeozoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGeozoneRequest) ProtoMessage() {}
+
+func (x *CreateGeozoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGeozoneRequest.ProtoReflect.Descriptor instead.
+func (*CreateGeozoneRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateGeozoneRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateGeozoneRequest) GetPolygon() []*GeoPoint {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+func (x *CreateGeozoneRequest) GetSpeedLimit() int32 {
+	if x != nil {
+		return x.SpeedLimit
+	}
+	return 0
+}
+
+func (x *CreateGeozoneRequest) GetNoEntry() bool {
+	if x != nil {
+		return x.NoEntry
+	}
+	return false
+}
+
+func (x *CreateGeozoneRequest) GetOperatingArea() bool {
+	if x != nil {
+		return x.OperatingArea
+	}
+	return false
+}
+
+func (x *CreateGeozoneRequest) GetAllowedFrom() string {
+	if x != nil {
+		return x.AllowedFrom
+	}
+	return ""
+}
+
+func (x *CreateGeozoneRequest) GetAllowedTo() string {
+	if x != nil {
+		return x.AllowedTo
+	}
+	return ""
+}
+
+func (x *CreateGeozoneRequest) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+type CreateGeozoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Geozone       *Geozone               `protobuf:"bytes,1,opt,name=geozone,proto3" json:"geozone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGeozoneResponse) Reset() {
+	*x = CreateGeozoneResponse{}
+	mi := &file_admin_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGeozoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGeozoneResponse) ProtoMessage() {}
+
+func (x *CreateGeozoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGeozoneResponse.ProtoReflect.Descriptor instead.
+func (*CreateGeozoneResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateGeozoneResponse) GetGeozone() *Geozone {
+	if x != nil {
+		return x.Geozone
+	}
+	return nil
+}
+
+// PUT /api/v1/geozones/{id}, зона заменяется целиком
+type UpdateGeozoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Polygon       []*GeoPoint            `protobuf:"bytes,3,rep,name=polygon,proto3" json:"polygon,omitempty"`
+	SpeedLimit    int32                  `protobuf:"varint,4,opt,name=speed_limit,json=speedLimit,proto3" json:"speed_limit,omitempty"`
+	NoEntry       bool                   `protobuf:"varint,5,opt,name=no_entry,json=noEntry,proto3" json:"no_entry,omitempty"`
+	OperatingArea bool                   `protobuf:"varint,6,opt,name=operating_area,json=operatingArea,proto3" json:"operating_area,omitempty"`
+	AllowedFrom   string                 `protobuf:"bytes,7,opt,name=allowed_from,json=allowedFrom,proto3" json:"allowed_from,omitempty"`
+	AllowedTo     string                 `protobuf:"bytes,8,opt,name=allowed_to,json=allowedTo,proto3" json:"allowed_to,omitempty"`
+	Enabled       *bool                  `protobuf:"varint,9,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"` // по умолчанию true
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGeozoneRequest) Reset() {
+	*x = UpdateGeozoneRequest{}
+	mi := &file_admin_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGeozoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGeozoneRequest) ProtoMessage() {}
+
+func (x *UpdateGeozoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGeozoneRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGeozoneRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateGeozoneRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateGeozoneRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateGeozoneRequest) GetPolygon() []*GeoPoint {
+	if x != nil {
+		return x.Polygon
+	}
+	return nil
+}
+
+func (x *UpdateGeozoneRequest) GetSpeedLimit() int32 {
+	if x != nil {
+		return x.SpeedLimit
+	}
+	return 0
+}
+
+func (x *UpdateGeozoneRequest) GetNoEntry() bool {
+	if x != nil {
+		return x.NoEntry
+	}
+	return false
+}
+
+func (x *UpdateGeozoneRequest) GetOperatingArea() bool {
+	if x != nil {
+		return x.OperatingArea
+	}
+	return false
+}
+
+func (x *UpdateGeozoneRequest) GetAllowedFrom() string {
+	if x != nil {
+		return x.AllowedFrom
+	}
+	return ""
+}
+
+func (x *UpdateGeozoneRequest) GetAllowedTo() string {
+	if x != nil {
+		return x.AllowedTo
+	}
+	return ""
+}
+
+func (x *UpdateGeozoneRequest) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+type UpdateGeozoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Geozone       *Geozone               `protobuf:"bytes,1,opt,name=geozone,proto3" json:"geozone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGeozoneResponse) Reset() {
+	*x = UpdateGeozoneResponse{}
+	mi := &file_admin_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGeozoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGeozoneResponse) ProtoMessage() {}
+
+func (x *UpdateGeozoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGeozoneResponse.ProtoReflect.Descriptor instead.
+func (*UpdateGeozoneResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateGeozoneResponse) GetGeozone() *Geozone {
+	if x != nil {
+		return x.Geozone
+	}
+	return nil
+}
+
+// DELETE /api/v1/geozones/{id}
+type DeleteGeozoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGeozoneRequest) Reset() {
+	*x = DeleteGeozoneRequest{}
+	mi := &file_admin_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGeozoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGeozoneRequest) ProtoMessage() {}
+
+func (x *DeleteGeozoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGeozoneRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGeozoneRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteGeozoneRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteGeozoneResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGeozoneResponse) Reset() {
+	*x = DeleteGeozoneResponse{}
+	mi := &file_admin_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGeozoneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGeozoneResponse) ProtoMessage() {}
+
+func (x *DeleteGeozoneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGeozoneResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGeozoneResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{38}
+}
+
 var File_admin_proto protoreflect.FileDescriptor
 
 const file_admin_proto_rawDesc = "" +
@@ -1016,20 +2589,159 @@ const file_admin_proto_rawDesc = "" +
 	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
 	"\x02to\x18\x03 \x01(\x03R\x02to\"@\n" +
 	"\x15GetCarHistoryResponse\x12'\n" +
-	"\x06states\x18\x01 \x03(\v2\x0f.admin.CarStateR\x06states*d\n" +
+	"\x06states\x18\x01 \x03(\v2\x0f.admin.CarStateR\x06states\"\x84\x02\n" +
+	"\x06Refuel\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06car_id\x18\x02 \x01(\tR\x05carId\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\x03R\tstartedAt\x12\x19\n" +
+	"\bended_at\x18\x04 \x01(\x03R\aendedAt\x12\x10\n" +
+	"\x03lat\x18\x05 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x06 \x01(\x01R\x03lon\x12\x10\n" +
+	"\x03odo\x18\a \x01(\x03R\x03odo\x12\x1f\n" +
+	"\vfuel_before\x18\b \x01(\x01R\n" +
+	"fuelBefore\x12\x1d\n" +
+	"\n" +
+	"fuel_after\x18\t \x01(\x01R\tfuelAfter\x12#\n" +
+	"\rvolume_liters\x18\n" +
+	" \x01(\x01R\fvolumeLiters\"O\n" +
+	"\x12ListRefuelsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x03R\x02to\x12\x15\n" +
+	"\x06car_id\x18\x03 \x01(\tR\x05carId\">\n" +
+	"\x13ListRefuelsResponse\x12'\n" +
+	"\arefuels\x18\x01 \x03(\v2\r.admin.RefuelR\arefuels\"\x92\x02\n" +
+	"\rViolationRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tcondition\x18\x04 \x01(\tR\tcondition\x12\x1a\n" +
+	"\bseverity\x18\x05 \x01(\tR\bseverity\x12)\n" +
+	"\x10cooldown_seconds\x18\x06 \x01(\x05R\x0fcooldownSeconds\x12\x18\n" +
+	"\aenabled\x18\a \x01(\bR\aenabled\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\"\x1b\n" +
+	"\x19ListViolationRulesRequest\"H\n" +
+	"\x1aListViolationRulesResponse\x12*\n" +
+	"\x05rules\x18\x01 \x03(\v2\x14.admin.ViolationRuleR\x05rules\")\n" +
+	"\x17GetViolationRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
+	"\x18GetViolationRuleResponse\x12(\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.admin.ViolationRuleR\x04rule\"\xe2\x01\n" +
+	"\x1aCreateViolationRuleRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tcondition\x18\x03 \x01(\tR\tcondition\x12\x1a\n" +
+	"\bseverity\x18\x04 \x01(\tR\bseverity\x12)\n" +
+	"\x10cooldown_seconds\x18\x05 \x01(\x05R\x0fcooldownSeconds\x12\x1d\n" +
+	"\aenabled\x18\x06 \x01(\bH\x00R\aenabled\x88\x01\x01B\n" +
+	"\n" +
+	"\b_enabled\"G\n" +
+	"\x1bCreateViolationRuleResponse\x12(\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.admin.ViolationRuleR\x04rule\"\xf2\x01\n" +
+	"\x1aUpdateViolationRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tcondition\x18\x04 \x01(\tR\tcondition\x12\x1a\n" +
+	"\bseverity\x18\x05 \x01(\tR\bseverity\x12)\n" +
+	"\x10cooldown_seconds\x18\x06 \x01(\x05R\x0fcooldownSeconds\x12\x1d\n" +
+	"\aenabled\x18\a \x01(\bH\x00R\aenabled\x88\x01\x01B\n" +
+	"\n" +
+	"\b_enabled\"G\n" +
+	"\x1bUpdateViolationRuleResponse\x12(\n" +
+	"\x04rule\x18\x01 \x01(\v2\x14.admin.ViolationRuleR\x04rule\",\n" +
+	"\x1aDeleteViolationRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1d\n" +
+	"\x1bDeleteViolationRuleResponse\".\n" +
+	"\bGeoPoint\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\"\xd5\x02\n" +
+	"\aGeozone\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
+	"\apolygon\x18\x03 \x03(\v2\x0f.admin.GeoPointR\apolygon\x12\x1f\n" +
+	"\vspeed_limit\x18\x04 \x01(\x05R\n" +
+	"speedLimit\x12\x19\n" +
+	"\bno_entry\x18\x05 \x01(\bR\anoEntry\x12%\n" +
+	"\x0eoperating_area\x18\x06 \x01(\bR\roperatingArea\x12!\n" +
+	"\fallowed_from\x18\a \x01(\tR\vallowedFrom\x12\x1d\n" +
+	"\n" +
+	"allowed_to\x18\b \x01(\tR\tallowedTo\x12\x18\n" +
+	"\aenabled\x18\t \x01(\bR\aenabled\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\x03R\tupdatedAt\"\x15\n" +
+	"\x13ListGeozonesRequest\"B\n" +
+	"\x14ListGeozonesResponse\x12*\n" +
+	"\bgeozones\x18\x01 \x03(\v2\x0e.admin.GeozoneR\bgeozones\"#\n" +
+	"\x11GetGeozoneRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x12GetGeozoneResponse\x12(\n" +
+	"\ageozone\x18\x01 \x01(\v2\x0e.admin.GeozoneR\ageozone\"\xa5\x02\n" +
+	"\x14CreateGeozoneRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
+	"\apolygon\x18\x02 \x03(\v2\x0f.admin.GeoPointR\apolygon\x12\x1f\n" +
+	"\vspeed_limit\x18\x03 \x01(\x05R\n" +
+	"speedLimit\x12\x19\n" +
+	"\bno_entry\x18\x04 \x01(\bR\anoEntry\x12%\n" +
+	"\x0eoperating_area\x18\x05 \x01(\bR\roperatingArea\x12!\n" +
+	"\fallowed_from\x18\x06 \x01(\tR\vallowedFrom\x12\x1d\n" +
+	"\n" +
+	"allowed_to\x18\a \x01(\tR\tallowedTo\x12\x1d\n" +
+	"\aenabled\x18\b \x01(\bH\x00R\aenabled\x88\x01\x01B\n" +
+	"\n" +
+	"\b_enabled\"A\n" +
+	"\x15CreateGeozoneResponse\x12(\n" +
+	"\ageozone\x18\x01 \x01(\v2\x0e.admin.GeozoneR\ageozone\"\xb5\x02\n" +
+	"\x14UpdateGeozoneRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
+	"\apolygon\x18\x03 \x03(\v2\x0f.admin.GeoPointR\apolygon\x12\x1f\n" +
+	"\vspeed_limit\x18\x04 \x01(\x05R\n" +
+	"speedLimit\x12\x19\n" +
+	"\bno_entry\x18\x05 \x01(\bR\anoEntry\x12%\n" +
+	"\x0eoperating_area\x18\x06 \x01(\bR\roperatingArea\x12!\n" +
+	"\fallowed_from\x18\a \x01(\tR\vallowedFrom\x12\x1d\n" +
+	"\n" +
+	"allowed_to\x18\b \x01(\tR\tallowedTo\x12\x1d\n" +
+	"\aenabled\x18\t \x01(\bH\x00R\aenabled\x88\x01\x01B\n" +
+	"\n" +
+	"\b_enabled\"A\n" +
+	"\x15UpdateGeozoneResponse\x12(\n" +
+	"\ageozone\x18\x01 \x01(\v2\x0e.admin.GeozoneR\ageozone\"&\n" +
+	"\x14DeleteGeozoneRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeleteGeozoneResponse*d\n" +
 	"\bFuelType\x12\x19\n" +
 	"\x15FUEL_TYPE_UNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
 	"\x06DIESEL\x10\x01\x12\x0f\n" +
 	"\vGASOLINE_92\x10\x02\x12\x0f\n" +
 	"\vGASOLINE_95\x10\x03\x12\x0f\n" +
-	"\vGASOLINE_98\x10\x042\xa3\x02\n" +
+	"\vGASOLINE_98\x10\x042\xa3\t\n" +
 	"\fAdminService\x12A\n" +
 	"\n" +
 	"GetCarsNow\x12\x18.admin.GetCarsNowRequest\x1a\x19.admin.GetCarsNowResponse\x125\n" +
 	"\x06GetCar\x12\x14.admin.GetCarRequest\x1a\x15.admin.GetCarResponse\x12M\n" +
 	"\x0eGetCarsHistory\x12\x1c.admin.GetCarsHistoryRequest\x1a\x1d.admin.GetCarsHistoryResponse\x12J\n" +
-	"\rGetCarHistory\x12\x1b.admin.GetCarHistoryRequest\x1a\x1c.admin.GetCarHistoryResponseB5Z3github.com/jekiti/citydrive/gen/proto/auth; adminpbb\x06proto3"
+	"\rGetCarHistory\x12\x1b.admin.GetCarHistoryRequest\x1a\x1c.admin.GetCarHistoryResponse\x12D\n" +
+	"\vListRefuels\x12\x19.admin.ListRefuelsRequest\x1a\x1a.admin.ListRefuelsResponse\x12Y\n" +
+	"\x12ListViolationRules\x12 .admin.ListViolationRulesRequest\x1a!.admin.ListViolationRulesResponse\x12S\n" +
+	"\x10GetViolationRule\x12\x1e.admin.GetViolationRuleRequest\x1a\x1f.admin.GetViolationRuleResponse\x12\\\n" +
+	"\x13CreateViolationRule\x12!.admin.CreateViolationRuleRequest\x1a\".admin.CreateViolationRuleResponse\x12\\\n" +
+	"\x13UpdateViolationRule\x12!.admin.UpdateViolationRuleRequest\x1a\".admin.UpdateViolationRuleResponse\x12\\\n" +
+	"\x13DeleteViolationRule\x12!.admin.DeleteViolationRuleRequest\x1a\".admin.DeleteViolationRuleResponse\x12G\n" +
+	"\fListGeozones\x12\x1a.admin.ListGeozonesRequest\x1a\x1b.admin.ListGeozonesResponse\x12A\n" +
+	"\n" +
+	"GetGeozone\x12\x18.admin.GetGeozoneRequest\x1a\x19.admin.GetGeozoneResponse\x12J\n" +
+	"\rCreateGeozone\x12\x1b.admin.CreateGeozoneRequest\x1a\x1c.admin.CreateGeozoneResponse\x12J\n" +
+	"\rUpdateGeozone\x12\x1b.admin.UpdateGeozoneRequest\x1a\x1c.admin.UpdateGeozoneResponse\x12J\n" +
+	"\rDeleteGeozone\x12\x1b.admin.DeleteGeozoneRequest\x1a\x1c.admin.DeleteGeozoneResponseB6Z4github.com/jekiti/citydrive/gen/proto/admin; adminpbb\x06proto3"
 
 var (
 	file_admin_proto_rawDescOnce sync.Once
@@ -1044,45 +2756,105 @@ func file_admin_proto_rawDescGZIP() []byte {
 }
 
 var file_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_admin_proto_goTypes = []any{
-	(FuelType)(0),                  // 0: admin.FuelType
-	(*CarShort)(nil),               // 1: admin.CarShort
-	(*CarDetails)(nil),             // 2: admin.CarDetails
-	(*CarHistoryPoint)(nil),        // 3: admin.CarHistoryPoint
-	(*CarState)(nil),               // 4: admin.CarState
-	(*CarHistoryList)(nil),         // 5: admin.CarHistoryList
-	(*GetCarsNowRequest)(nil),      // 6: admin.GetCarsNowRequest
-	(*GetCarsNowResponse)(nil),     // 7: admin.GetCarsNowResponse
-	(*GetCarRequest)(nil),          // 8: admin.GetCarRequest
-	(*GetCarResponse)(nil),         // 9: admin.GetCarResponse
-	(*GetCarsHistoryRequest)(nil),  // 10: admin.GetCarsHistoryRequest
-	(*GetCarsHistoryResponse)(nil), // 11: admin.GetCarsHistoryResponse
-	(*GetCarHistoryRequest)(nil),   // 12: admin.GetCarHistoryRequest
-	(*GetCarHistoryResponse)(nil),  // 13: admin.GetCarHistoryResponse
-	nil,                            // 14: admin.GetCarsHistoryResponse.HistoryByCarEntry
+	(FuelType)(0),                       // 0: admin.FuelType
+	(*CarShort)(nil),                    // 1: admin.CarShort
+	(*CarDetails)(nil),                  // 2: admin.CarDetails
+	(*CarHistoryPoint)(nil),             // 3: admin.CarHistoryPoint
+	(*CarState)(nil),                    // 4: admin.CarState
+	(*CarHistoryList)(nil),              // 5: admin.CarHistoryList
+	(*GetCarsNowRequest)(nil),           // 6: admin.GetCarsNowRequest
+	(*GetCarsNowResponse)(nil),          // 7: admin.GetCarsNowResponse
+	(*GetCarRequest)(nil),               // 8: admin.GetCarRequest
+	(*GetCarResponse)(nil),              // 9: admin.GetCarResponse
+	(*GetCarsHistoryRequest)(nil),       // 10: admin.GetCarsHistoryRequest
+	(*GetCarsHistoryResponse)(nil),      // 11: admin.GetCarsHistoryResponse
+	(*GetCarHistoryRequest)(nil),        // 12: admin.GetCarHistoryRequest
+	(*GetCarHistoryResponse)(nil),       // 13: admin.GetCarHistoryResponse
+	(*Refuel)(nil),                      // 14: admin.Refuel
+	(*ListRefuelsRequest)(nil),          // 15: admin.ListRefuelsRequest
+	(*ListRefuelsResponse)(nil),         // 16: admin.ListRefuelsResponse
+	(*ViolationRule)(nil),               // 17: admin.ViolationRule
+	(*ListViolationRulesRequest)(nil),   // 18: admin.ListViolationRulesRequest
+	(*ListViolationRulesResponse)(nil),  // 19: admin.ListViolationRulesResponse
+	(*GetViolationRuleRequest)(nil),     // 20: admin.GetViolationRuleRequest
+	(*GetViolationRuleResponse)(nil),    // 21: admin.GetViolationRuleResponse
+	(*CreateViolationRuleRequest)(nil),  // 22: admin.CreateViolationRuleRequest
+	(*CreateViolationRuleResponse)(nil), // 23: admin.CreateViolationRuleResponse
+	(*UpdateViolationRuleRequest)(nil),  // 24: admin.UpdateViolationRuleRequest
+	(*UpdateViolationRuleResponse)(nil), // 25: admin.UpdateViolationRuleResponse
+	(*DeleteViolationRuleRequest)(nil),  // 26: admin.DeleteViolationRuleRequest
+	(*DeleteViolationRuleResponse)(nil), // 27: admin.DeleteViolationRuleResponse
+	(*GeoPoint)(nil),                    // 28: admin.GeoPoint
+	(*Geozone)(nil),                     // 29: admin.Geozone
+	(*ListGeozonesRequest)(nil),         // 30: admin.ListGeozonesRequest
+	(*ListGeozonesResponse)(nil),        // 31: admin.ListGeozonesResponse
+	(*GetGeozoneRequest)(nil),           // 32: admin.GetGeozoneRequest
+	(*GetGeozoneResponse)(nil),          // 33: admin.GetGeozoneResponse
+	(*CreateGeozoneRequest)(nil),        // 34: admin.CreateGeozoneRequest
+	(*CreateGeozoneResponse)(nil),       // 35: admin.CreateGeozoneResponse
+	(*UpdateGeozoneRequest)(nil),        // 36: admin.UpdateGeozoneRequest
+	(*UpdateGeozoneResponse)(nil),       // 37: admin.UpdateGeozoneResponse
+	(*DeleteGeozoneRequest)(nil),        // 38: admin.DeleteGeozoneRequest
+	(*DeleteGeozoneResponse)(nil),       // 39: admin.DeleteGeozoneResponse
+	nil,                                 // 40: admin.GetCarsHistoryResponse.HistoryByCarEntry
 }
 var file_admin_proto_depIdxs = []int32{
 	0,  // 0: admin.CarDetails.fuel_type:type_name -> admin.FuelType
 	3,  // 1: admin.CarHistoryList.items:type_name -> admin.CarHistoryPoint
 	1,  // 2: admin.GetCarsNowResponse.cars:type_name -> admin.CarShort
 	2,  // 3: admin.GetCarResponse.car:type_name -> admin.CarDetails
-	14, // 4: admin.GetCarsHistoryResponse.history_by_car:type_name -> admin.GetCarsHistoryResponse.HistoryByCarEntry
+	40, // 4: admin.GetCarsHistoryResponse.history_by_car:type_name -> admin.GetCarsHistoryResponse.HistoryByCarEntry
 	4,  // 5: admin.GetCarHistoryResponse.states:type_name -> admin.CarState
-	5,  // 6: admin.GetCarsHistoryResponse.HistoryByCarEntry.value:type_name -> admin.CarHistoryList
-	6,  // 7: admin.AdminService.GetCarsNow:input_type -> admin.GetCarsNowRequest
-	8,  // 8: admin.AdminService.GetCar:input_type -> admin.GetCarRequest
-	10, // 9: admin.AdminService.GetCarsHistory:input_type -> admin.GetCarsHistoryRequest
-	12, // 10: admin.AdminService.GetCarHistory:input_type -> admin.GetCarHistoryRequest
-	7,  // 11: admin.AdminService.GetCarsNow:output_type -> admin.GetCarsNowResponse
-	9,  // 12: admin.AdminService.GetCar:output_type -> admin.GetCarResponse
-	11, // 13: admin.AdminService.GetCarsHistory:output_type -> admin.GetCarsHistoryResponse
-	13, // 14: admin.AdminService.GetCarHistory:output_type -> admin.GetCarHistoryResponse
-	11, // [11:15] is the sub-list for method output_type
-	7,  // [7:11] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	14, // 6: admin.ListRefuelsResponse.refuels:type_name -> admin.Refuel
+	17, // 7: admin.ListViolationRulesResponse.rules:type_name -> admin.ViolationRule
+	17, // 8: admin.GetViolationRuleResponse.rule:type_name -> admin.ViolationRule
+	17, // 9: admin.CreateViolationRuleResponse.rule:type_name -> admin.ViolationRule
+	17, // 10: admin.UpdateViolationRuleResponse.rule:type_name -> admin.ViolationRule
+	28, // 11: admin.Geozone.polygon:type_name -> admin.GeoPoint
+	29, // 12: admin.ListGeozonesResponse.geozones:type_name -> admin.Geozone
+	29, // 13: admin.GetGeozoneResponse.geozone:type_name -> admin.Geozone
+	28, // 14: admin.CreateGeozoneRequest.polygon:type_name -> admin.GeoPoint
+	29, // 15: admin.CreateGeozoneResponse.geozone:type_name -> admin.Geozone
+	28, // 16: admin.UpdateGeozoneRequest.polygon:type_name -> admin.GeoPoint
+	29, // 17: admin.UpdateGeozoneResponse.geozone:type_name -> admin.Geozone
+	5,  // 18: admin.GetCarsHistoryResponse.HistoryByCarEntry.value:type_name -> admin.CarHistoryList
+	6,  // 19: admin.AdminService.GetCarsNow:input_type -> admin.GetCarsNowRequest
+	8,  // 20: admin.AdminService.GetCar:input_type -> admin.GetCarRequest
+	10, // 21: admin.AdminService.GetCarsHistory:input_type -> admin.GetCarsHistoryRequest
+	12, // 22: admin.AdminService.GetCarHistory:input_type -> admin.GetCarHistoryRequest
+	15, // 23: admin.AdminService.ListRefuels:input_type -> admin.ListRefuelsRequest
+	18, // 24: admin.AdminService.ListViolationRules:input_type -> admin.ListViolationRulesRequest
+	20, // 25: admin.AdminService.GetViolationRule:input_type -> admin.GetViolationRuleRequest
+	22, // 26: admin.AdminService.CreateViolationRule:input_type -> admin.CreateViolationRuleRequest
+	24, // 27: admin.AdminService.UpdateViolationRule:input_type -> admin.UpdateViolationRuleRequest
+	26, // 28: admin.AdminService.DeleteViolationRule:input_type -> admin.DeleteViolationRuleRequest
+	30, // 29: admin.AdminService.ListGeozones:input_type -> admin.ListGeozonesRequest
+	32, // 30: admin.AdminService.GetGeozone:input_type -> admin.GetGeozoneRequest
+	34, // 31: admin.AdminService.CreateGeozone:input_type -> admin.CreateGeozoneRequest
+	36, // 32: admin.AdminService.UpdateGeozone:input_type -> admin.UpdateGeozoneRequest
+	38, // 33: admin.AdminService.DeleteGeozone:input_type -> admin.DeleteGeozoneRequest
+	7,  // 34: admin.AdminService.GetCarsNow:output_type -> admin.GetCarsNowResponse
+	9,  // 35: admin.AdminService.GetCar:output_type -> admin.GetCarResponse
+	11, // 36: admin.AdminService.GetCarsHistory:output_type -> admin.GetCarsHistoryResponse
+	13, // 37: admin.AdminService.GetCarHistory:output_type -> admin.GetCarHistoryResponse
+	16, // 38: admin.AdminService.ListRefuels:output_type -> admin.ListRefuelsResponse
+	19, // 39: admin.AdminService.ListViolationRules:output_type -> admin.ListViolationRulesResponse
+	21, // 40: admin.AdminService.GetViolationRule:output_type -> admin.GetViolationRuleResponse
+	23, // 41: admin.AdminService.CreateViolationRule:output_type -> admin.CreateViolationRuleResponse
+	25, // 42: admin.AdminService.UpdateViolationRule:output_type -> admin.UpdateViolationRuleResponse
+	27, // 43: admin.AdminService.DeleteViolationRule:output_type -> admin.DeleteViolationRuleResponse
+	31, // 44: admin.AdminService.ListGeozones:output_type -> admin.ListGeozonesResponse
+	33, // 45: admin.AdminService.GetGeozone:output_type -> admin.GetGeozoneResponse
+	35, // 46: admin.AdminService.CreateGeozone:output_type -> admin.CreateGeozoneResponse
+	37, // 47: admin.AdminService.UpdateGeozone:output_type -> admin.UpdateGeozoneResponse
+	39, // 48: admin.AdminService.DeleteGeozone:output_type -> admin.DeleteGeozoneResponse
+	34, // [34:49] is the sub-list for method output_type
+	19, // [19:34] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_admin_proto_init() }
@@ -1091,13 +2863,17 @@ func file_admin_proto_init() {
 		return
 	}
 	file_admin_proto_msgTypes[9].OneofWrappers = []any{}
+	file_admin_proto_msgTypes[21].OneofWrappers = []any{}
+	file_admin_proto_msgTypes[23].OneofWrappers = []any{}
+	file_admin_proto_msgTypes[33].OneofWrappers = []any{}
+	file_admin_proto_msgTypes[35].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_admin_proto_rawDesc), len(file_admin_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   14,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
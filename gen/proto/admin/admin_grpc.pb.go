@@ -19,10 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AdminService_GetCarsNow_FullMethodName     = "/admin.AdminService/GetCarsNow"
-	AdminService_GetCar_FullMethodName         = "/admin.AdminService/GetCar"
-	AdminService_GetCarsHistory_FullMethodName = "/admin.AdminService/GetCarsHistory"
-	AdminService_GetCarHistory_FullMethodName  = "/admin.AdminService/GetCarHistory"
+	AdminService_GetCarsNow_FullMethodName          = "/admin.AdminService/GetCarsNow"
+	AdminService_GetCar_FullMethodName              = "/admin.AdminService/GetCar"
+	AdminService_GetCarsHistory_FullMethodName      = "/admin.AdminService/GetCarsHistory"
+	AdminService_GetCarHistory_FullMethodName       = "/admin.AdminService/GetCarHistory"
+	AdminService_ListRefuels_FullMethodName         = "/admin.AdminService/ListRefuels"
+	AdminService_ListViolationRules_FullMethodName  = "/admin.AdminService/ListViolationRules"
+	AdminService_GetViolationRule_FullMethodName    = "/admin.AdminService/GetViolationRule"
+	AdminService_CreateViolationRule_FullMethodName = "/admin.AdminService/CreateViolationRule"
+	AdminService_UpdateViolationRule_FullMethodName = "/admin.AdminService/UpdateViolationRule"
+	AdminService_DeleteViolationRule_FullMethodName = "/admin.AdminService/DeleteViolationRule"
+	AdminService_ListGeozones_FullMethodName        = "/admin.AdminService/ListGeozones"
+	AdminService_GetGeozone_FullMethodName          = "/admin.AdminService/GetGeozone"
+	AdminService_CreateGeozone_FullMethodName       = "/admin.AdminService/CreateGeozone"
+	AdminService_UpdateGeozone_FullMethodName       = "/admin.AdminService/UpdateGeozone"
+	AdminService_DeleteGeozone_FullMethodName       = "/admin.AdminService/DeleteGeozone"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -39,6 +50,21 @@ type AdminServiceClient interface {
 	GetCarsHistory(ctx context.Context, in *GetCarsHistoryRequest, opts ...grpc.CallOption) (*GetCarsHistoryResponse, error)
 	// GET /api/v1/cars/{id}/history
 	GetCarHistory(ctx context.Context, in *GetCarHistoryRequest, opts ...grpc.CallOption) (*GetCarHistoryResponse, error)
+	// GET /api/v1/cars/refuels
+	ListRefuels(ctx context.Context, in *ListRefuelsRequest, opts ...grpc.CallOption) (*ListRefuelsResponse, error)
+	// Правила нарушений. Неверное условие или severity — INVALID_ARGUMENT,
+	// занятый type — ALREADY_EXISTS.
+	ListViolationRules(ctx context.Context, in *ListViolationRulesRequest, opts ...grpc.CallOption) (*ListViolationRulesResponse, error)
+	GetViolationRule(ctx context.Context, in *GetViolationRuleRequest, opts ...grpc.CallOption) (*GetViolationRuleResponse, error)
+	CreateViolationRule(ctx context.Context, in *CreateViolationRuleRequest, opts ...grpc.CallOption) (*CreateViolationRuleResponse, error)
+	UpdateViolationRule(ctx context.Context, in *UpdateViolationRuleRequest, opts ...grpc.CallOption) (*UpdateViolationRuleResponse, error)
+	DeleteViolationRule(ctx context.Context, in *DeleteViolationRuleRequest, opts ...grpc.CallOption) (*DeleteViolationRuleResponse, error)
+	// Геозоны. Неверный полигон или часы — INVALID_ARGUMENT.
+	ListGeozones(ctx context.Context, in *ListGeozonesRequest, opts ...grpc.CallOption) (*ListGeozonesResponse, error)
+	GetGeozone(ctx context.Context, in *GetGeozoneRequest, opts ...grpc.CallOption) (*GetGeozoneResponse, error)
+	CreateGeozone(ctx context.Context, in *CreateGeozoneRequest, opts ...grpc.CallOption) (*CreateGeozoneResponse, error)
+	UpdateGeozone(ctx context.Context, in *UpdateGeozoneRequest, opts ...grpc.CallOption) (*UpdateGeozoneResponse, error)
+	DeleteGeozone(ctx context.Context, in *DeleteGeozoneRequest, opts ...grpc.CallOption) (*DeleteGeozoneResponse, error)
 }
 
 type adminServiceClient struct {
@@ -89,6 +115,116 @@ func (c *adminServiceClient) GetCarHistory(ctx context.Context, in *GetCarHistor
 	return out, nil
 }
 
+func (c *adminServiceClient) ListRefuels(ctx context.Context, in *ListRefuelsRequest, opts ...grpc.CallOption) (*ListRefuelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRefuelsResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListRefuels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListViolationRules(ctx context.Context, in *ListViolationRulesRequest, opts ...grpc.CallOption) (*ListViolationRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListViolationRulesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListViolationRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetViolationRule(ctx context.Context, in *GetViolationRuleRequest, opts ...grpc.CallOption) (*GetViolationRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetViolationRuleResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetViolationRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateViolationRule(ctx context.Context, in *CreateViolationRuleRequest, opts ...grpc.CallOption) (*CreateViolationRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateViolationRuleResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateViolationRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateViolationRule(ctx context.Context, in *UpdateViolationRuleRequest, opts ...grpc.CallOption) (*UpdateViolationRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateViolationRuleResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateViolationRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteViolationRule(ctx context.Context, in *DeleteViolationRuleRequest, opts ...grpc.CallOption) (*DeleteViolationRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteViolationRuleResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteViolationRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListGeozones(ctx context.Context, in *ListGeozonesRequest, opts ...grpc.CallOption) (*ListGeozonesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGeozonesResponse)
+	err := c.cc.Invoke(ctx, AdminService_ListGeozones_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetGeozone(ctx context.Context, in *GetGeozoneRequest, opts ...grpc.CallOption) (*GetGeozoneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGeozoneResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetGeozone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CreateGeozone(ctx context.Context, in *CreateGeozoneRequest, opts ...grpc.CallOption) (*CreateGeozoneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateGeozoneResponse)
+	err := c.cc.Invoke(ctx, AdminService_CreateGeozone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UpdateGeozone(ctx context.Context, in *UpdateGeozoneRequest, opts ...grpc.CallOption) (*UpdateGeozoneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateGeozoneResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateGeozone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteGeozone(ctx context.Context, in *DeleteGeozoneRequest, opts ...grpc.CallOption) (*DeleteGeozoneResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteGeozoneResponse)
+	err := c.cc.Invoke(ctx, AdminService_DeleteGeozone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations must embed UnimplementedAdminServiceServer
 // for forward compatibility.
@@ -103,6 +239,21 @@ type AdminServiceServer interface {
 	GetCarsHistory(context.Context, *GetCarsHistoryRequest) (*GetCarsHistoryResponse, error)
 	// GET /api/v1/cars/{id}/history
 	GetCarHistory(context.Context, *GetCarHistoryRequest) (*GetCarHistoryResponse, error)
+	// GET /api/v1/cars/refuels
+	ListRefuels(context.Context, *ListRefuelsRequest) (*ListRefuelsResponse, error)
+	// Правила нарушений. Неверное условие или severity — INVALID_ARGUMENT,
+	// занятый type — ALREADY_EXISTS.
+	ListViolationRules(context.Context, *ListViolationRulesRequest) (*ListViolationRulesResponse, error)
+	GetViolationRule(context.Context, *GetViolationRuleRequest) (*GetViolationRuleResponse, error)
+	CreateViolationRule(context.Context, *CreateViolationRuleRequest) (*CreateViolationRuleResponse, error)
+	UpdateViolationRule(context.Context, *UpdateViolationRuleRequest) (*UpdateViolationRuleResponse, error)
+	DeleteViolationRule(context.Context, *DeleteViolationRuleRequest) (*DeleteViolationRuleResponse, error)
+	// Геозоны. Неверный полигон или часы — INVALID_ARGUMENT.
+	ListGeozones(context.Context, *ListGeozonesRequest) (*ListGeozonesResponse, error)
+	GetGeozone(context.Context, *GetGeozoneRequest) (*GetGeozoneResponse, error)
+	CreateGeozone(context.Context, *CreateGeozoneRequest) (*CreateGeozoneResponse, error)
+	UpdateGeozone(context.Context, *UpdateGeozoneRequest) (*UpdateGeozoneResponse, error)
+	DeleteGeozone(context.Context, *DeleteGeozoneRequest) (*DeleteGeozoneResponse, error)
 	mustEmbedUnimplementedAdminServiceServer()
 }
 
@@ -125,6 +276,39 @@ func (UnimplementedAdminServiceServer) GetCarsHistory(context.Context, *GetCarsH
 func (UnimplementedAdminServiceServer) GetCarHistory(context.Context, *GetCarHistoryRequest) (*GetCarHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCarHistory not implemented")
 }
+func (UnimplementedAdminServiceServer) ListRefuels(context.Context, *ListRefuelsRequest) (*ListRefuelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRefuels not implemented")
+}
+func (UnimplementedAdminServiceServer) ListViolationRules(context.Context, *ListViolationRulesRequest) (*ListViolationRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListViolationRules not implemented")
+}
+func (UnimplementedAdminServiceServer) GetViolationRule(context.Context, *GetViolationRuleRequest) (*GetViolationRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetViolationRule not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateViolationRule(context.Context, *CreateViolationRuleRequest) (*CreateViolationRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateViolationRule not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateViolationRule(context.Context, *UpdateViolationRuleRequest) (*UpdateViolationRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateViolationRule not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteViolationRule(context.Context, *DeleteViolationRuleRequest) (*DeleteViolationRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteViolationRule not implemented")
+}
+func (UnimplementedAdminServiceServer) ListGeozones(context.Context, *ListGeozonesRequest) (*ListGeozonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGeozones not implemented")
+}
+func (UnimplementedAdminServiceServer) GetGeozone(context.Context, *GetGeozoneRequest) (*GetGeozoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGeozone not implemented")
+}
+func (UnimplementedAdminServiceServer) CreateGeozone(context.Context, *CreateGeozoneRequest) (*CreateGeozoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGeozone not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateGeozone(context.Context, *UpdateGeozoneRequest) (*UpdateGeozoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGeozone not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteGeozone(context.Context, *DeleteGeozoneRequest) (*DeleteGeozoneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGeozone not implemented")
+}
 func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
 func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
 
@@ -218,6 +402,204 @@ func _AdminService_GetCarHistory_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_ListRefuels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefuelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListRefuels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListRefuels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListRefuels(ctx, req.(*ListRefuelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListViolationRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListViolationRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListViolationRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListViolationRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListViolationRules(ctx, req.(*ListViolationRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetViolationRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetViolationRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetViolationRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetViolationRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetViolationRule(ctx, req.(*GetViolationRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateViolationRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateViolationRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateViolationRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateViolationRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateViolationRule(ctx, req.(*CreateViolationRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateViolationRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateViolationRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateViolationRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateViolationRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateViolationRule(ctx, req.(*UpdateViolationRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteViolationRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteViolationRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteViolationRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteViolationRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteViolationRule(ctx, req.(*DeleteViolationRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListGeozones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGeozonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListGeozones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListGeozones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListGeozones(ctx, req.(*ListGeozonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetGeozone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGeozoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetGeozone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetGeozone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetGeozone(ctx, req.(*GetGeozoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CreateGeozone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGeozoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CreateGeozone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_CreateGeozone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CreateGeozone(ctx, req.(*CreateGeozoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UpdateGeozone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGeozoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateGeozone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateGeozone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateGeozone(ctx, req.(*UpdateGeozoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteGeozone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGeozoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteGeozone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteGeozone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteGeozone(ctx, req.(*DeleteGeozoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +623,50 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCarHistory",
 			Handler:    _AdminService_GetCarHistory_Handler,
 		},
+		{
+			MethodName: "ListRefuels",
+			Handler:    _AdminService_ListRefuels_Handler,
+		},
+		{
+			MethodName: "ListViolationRules",
+			Handler:    _AdminService_ListViolationRules_Handler,
+		},
+		{
+			MethodName: "GetViolationRule",
+			Handler:    _AdminService_GetViolationRule_Handler,
+		},
+		{
+			MethodName: "CreateViolationRule",
+			Handler:    _AdminService_CreateViolationRule_Handler,
+		},
+		{
+			MethodName: "UpdateViolationRule",
+			Handler:    _AdminService_UpdateViolationRule_Handler,
+		},
+		{
+			MethodName: "DeleteViolationRule",
+			Handler:    _AdminService_DeleteViolationRule_Handler,
+		},
+		{
+			MethodName: "ListGeozones",
+			Handler:    _AdminService_ListGeozones_Handler,
+		},
+		{
+			MethodName: "GetGeozone",
+			Handler:    _AdminService_GetGeozone_Handler,
+		},
+		{
+			MethodName: "CreateGeozone",
+			Handler:    _AdminService_CreateGeozone_Handler,
+		},
+		{
+			MethodName: "UpdateGeozone",
+			Handler:    _AdminService_UpdateGeozone_Handler,
+		},
+		{
+			MethodName: "DeleteGeozone",
+			Handler:    _AdminService_DeleteGeozone_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "admin.proto",
@@ -0,0 +1,655 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.32.0
+// source: events.proto
+
+package eventspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Общая часть всех событий
+type Envelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SchemaVersion uint32                 `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"` // версия схемы, сейчас 1
+	TraceId       string                 `protobuf:"bytes,2,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`                    // trace_id запроса, в котором принято показание
+	CarId         string                 `protobuf:"bytes,3,opt,name=car_id,json=carId,proto3" json:"car_id,omitempty"`
+	RecordedAt    int64                  `protobuf:"varint,4,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"` // unix ms по часам устройства, 0 — неизвестно
+	ProducedAt    int64                  `protobuf:"varint,5,opt,name=produced_at,json=producedAt,proto3" json:"produced_at,omitempty"` // unix ms, когда сервис телеметрии принял показание
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Envelope) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *Envelope) GetCarId() string {
+	if x != nil {
+		return x.CarId
+	}
+	return ""
+}
+
+func (x *Envelope) GetRecordedAt() int64 {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return 0
+}
+
+func (x *Envelope) GetProducedAt() int64 {
+	if x != nil {
+		return x.ProducedAt
+	}
+	return 0
+}
+
+// Показание автомобиля
+type Telemetry struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Brand             string                 `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	Model             string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	YearOfManufacture int32                  `protobuf:"varint,3,opt,name=year_of_manufacture,json=yearOfManufacture,proto3" json:"year_of_manufacture,omitempty"`
+	Odo               int64                  `protobuf:"varint,4,opt,name=odo,proto3" json:"odo,omitempty"` // km
+	Lat               float64                `protobuf:"fixed64,5,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon               float64                `protobuf:"fixed64,6,opt,name=lon,proto3" json:"lon,omitempty"`
+	Fuel              float64                `protobuf:"fixed64,7,opt,name=fuel,proto3" json:"fuel,omitempty"`                       // percents of fuel tank
+	FuelType          string                 `protobuf:"bytes,8,opt,name=fuel_type,json=fuelType,proto3" json:"fuel_type,omitempty"` // one of (diesel, 92,95,98)
+	Speed             int32                  `protobuf:"varint,9,opt,name=speed,proto3" json:"speed,omitempty"`                      // km/h
+	EngineOn          bool                   `protobuf:"varint,10,opt,name=engine_on,json=engineOn,proto3" json:"engine_on,omitempty"`
+	Locked            bool                   `protobuf:"varint,11,opt,name=locked,proto3" json:"locked,omitempty"`
+	Activated         bool                   `protobuf:"varint,12,opt,name=activated,proto3" json:"activated,omitempty"`
+	Rpm               int32                  `protobuf:"varint,13,opt,name=rpm,proto3" json:"rpm,omitempty"`
+	Handbrake         bool                   `protobuf:"varint,14,opt,name=handbrake,proto3" json:"handbrake,omitempty"`
+	Sequence          uint64                 `protobuf:"varint,15,opt,name=sequence,proto3" json:"sequence,omitempty"`                                  // 0 — not set
+	ClockSkew         bool                   `protobuf:"varint,16,opt,name=clock_skew,json=clockSkew,proto3" json:"clock_skew,omitempty"`               // recorded_at нельзя доверять
+	IdempotencyKey    string                 `protobuf:"bytes,17,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // ключ для дедупликации повторной доставки
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Telemetry) Reset() {
+	*x = Telemetry{}
+	mi := &file_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Telemetry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Telemetry) ProtoMessage() {}
+
+func (x *Telemetry) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Telemetry.ProtoReflect.Descriptor instead.
+func (*Telemetry) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Telemetry) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *Telemetry) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Telemetry) GetYearOfManufacture() int32 {
+	if x != nil {
+		return x.YearOfManufacture
+	}
+	return 0
+}
+
+func (x *Telemetry) GetOdo() int64 {
+	if x != nil {
+		return x.Odo
+	}
+	return 0
+}
+
+func (x *Telemetry) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Telemetry) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *Telemetry) GetFuel() float64 {
+	if x != nil {
+		return x.Fuel
+	}
+	return 0
+}
+
+func (x *Telemetry) GetFuelType() string {
+	if x != nil {
+		return x.FuelType
+	}
+	return ""
+}
+
+func (x *Telemetry) GetSpeed() int32 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Telemetry) GetEngineOn() bool {
+	if x != nil {
+		return x.EngineOn
+	}
+	return false
+}
+
+func (x *Telemetry) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *Telemetry) GetActivated() bool {
+	if x != nil {
+		return x.Activated
+	}
+	return false
+}
+
+func (x *Telemetry) GetRpm() int32 {
+	if x != nil {
+		return x.Rpm
+	}
+	return 0
+}
+
+func (x *Telemetry) GetHandbrake() bool {
+	if x != nil {
+		return x.Handbrake
+	}
+	return false
+}
+
+func (x *Telemetry) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Telemetry) GetClockSkew() bool {
+	if x != nil {
+		return x.ClockSkew
+	}
+	return false
+}
+
+func (x *Telemetry) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// Топик telemetry.raw, ключ сообщения — car_id
+type TelemetryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Envelope      *Envelope              `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	Telemetry     *Telemetry             `protobuf:"bytes,2,opt,name=telemetry,proto3" json:"telemetry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryEvent) Reset() {
+	*x = TelemetryEvent{}
+	mi := &file_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryEvent) ProtoMessage() {}
+
+func (x *TelemetryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryEvent.ProtoReflect.Descriptor instead.
+func (*TelemetryEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TelemetryEvent) GetEnvelope() *Envelope {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *TelemetryEvent) GetTelemetry() *Telemetry {
+	if x != nil {
+		return x.Telemetry
+	}
+	return nil
+}
+
+// Топик telemetry.violations, ключ сообщения — тип нарушения
+type ViolationEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Envelope      *Envelope              `protobuf:"bytes,1,opt,name=envelope,proto3" json:"envelope,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                   // speeding_low, low_fuel, registry_mismatch, ...
+	Telemetry     *Telemetry             `protobuf:"bytes,3,opt,name=telemetry,proto3" json:"telemetry,omitempty"`         // показание, на котором сработало нарушение
+	Details       *structpb.Struct       `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"`             // {speed: 120, limit: 110}
+	Severity      string                 `protobuf:"bytes,5,opt,name=severity,proto3" json:"severity,omitempty"`           // low, medium, high, critical
+	RuleId        string                 `protobuf:"bytes,6,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"` // правило из citydrive.violation_rules, пусто для нарушений из кода
+	Episode       *ViolationEpisode      `protobuf:"bytes,7,opt,name=episode,proto3" json:"episode,omitempty"`
+	Subject       string                 `protobuf:"bytes,8,opt,name=subject,proto3" json:"subject,omitempty"` // id геозоны для zone_speeding и restricted_zone
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViolationEvent) Reset() {
+	*x = ViolationEvent{}
+	mi := &file_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViolationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViolationEvent) ProtoMessage() {}
+
+func (x *ViolationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViolationEvent.ProtoReflect.Descriptor instead.
+func (*ViolationEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ViolationEvent) GetEnvelope() *Envelope {
+	if x != nil {
+		return x.Envelope
+	}
+	return nil
+}
+
+func (x *ViolationEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ViolationEvent) GetTelemetry() *Telemetry {
+	if x != nil {
+		return x.Telemetry
+	}
+	return nil
+}
+
+func (x *ViolationEvent) GetDetails() *structpb.Struct {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *ViolationEvent) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *ViolationEvent) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *ViolationEvent) GetEpisode() *ViolationEpisode {
+	if x != nil {
+		return x.Episode
+	}
+	return nil
+}
+
+func (x *ViolationEvent) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// Эпизод — нарушение, которое держится несколько показаний подряд. Публикуются только
+// его начало и конец. Время — unix ms показаний.
+type ViolationEpisode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Phase         string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"` // start или end
+	StartedAt     int64                  `protobuf:"varint,3,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       int64                  `protobuf:"varint,4,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`          // последнее показание с нарушением, только в end
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"` // только в end
+	PeakSpeed     int32                  `protobuf:"varint,6,opt,name=peak_speed,json=peakSpeed,proto3" json:"peak_speed,omitempty"`
+	PeakRpm       int32                  `protobuf:"varint,7,opt,name=peak_rpm,json=peakRpm,proto3" json:"peak_rpm,omitempty"`
+	MinFuel       float64                `protobuf:"fixed64,8,opt,name=min_fuel,json=minFuel,proto3" json:"min_fuel,omitempty"`
+	Readings      uint32                 `protobuf:"varint,9,opt,name=readings,proto3" json:"readings,omitempty"` // сколько показаний нарушали правило
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViolationEpisode) Reset() {
+	*x = ViolationEpisode{}
+	mi := &file_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViolationEpisode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViolationEpisode) ProtoMessage() {}
+
+func (x *ViolationEpisode) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViolationEpisode.ProtoReflect.Descriptor instead.
+func (*ViolationEpisode) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ViolationEpisode) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ViolationEpisode) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *ViolationEpisode) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *ViolationEpisode) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+func (x *ViolationEpisode) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *ViolationEpisode) GetPeakSpeed() int32 {
+	if x != nil {
+		return x.PeakSpeed
+	}
+	return 0
+}
+
+func (x *ViolationEpisode) GetPeakRpm() int32 {
+	if x != nil {
+		return x.PeakRpm
+	}
+	return 0
+}
+
+func (x *ViolationEpisode) GetMinFuel() float64 {
+	if x != nil {
+		return x.MinFuel
+	}
+	return 0
+}
+
+func (x *ViolationEpisode) GetReadings() uint32 {
+	if x != nil {
+		return x.Readings
+	}
+	return 0
+}
+
+var File_events_proto protoreflect.FileDescriptor
+
+const file_events_proto_rawDesc = "" +
+	"\n" +
+	"\fevents.proto\x12\x06events\x1a\x1cgoogle/protobuf/struct.proto\"\xa5\x01\n" +
+	"\bEnvelope\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\rR\rschemaVersion\x12\x19\n" +
+	"\btrace_id\x18\x02 \x01(\tR\atraceId\x12\x15\n" +
+	"\x06car_id\x18\x03 \x01(\tR\x05carId\x12\x1f\n" +
+	"\vrecorded_at\x18\x04 \x01(\x03R\n" +
+	"recordedAt\x12\x1f\n" +
+	"\vproduced_at\x18\x05 \x01(\x03R\n" +
+	"producedAt\"\xcb\x03\n" +
+	"\tTelemetry\x12\x14\n" +
+	"\x05brand\x18\x01 \x01(\tR\x05brand\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12.\n" +
+	"\x13year_of_manufacture\x18\x03 \x01(\x05R\x11yearOfManufacture\x12\x10\n" +
+	"\x03odo\x18\x04 \x01(\x03R\x03odo\x12\x10\n" +
+	"\x03lat\x18\x05 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x06 \x01(\x01R\x03lon\x12\x12\n" +
+	"\x04fuel\x18\a \x01(\x01R\x04fuel\x12\x1b\n" +
+	"\tfuel_type\x18\b \x01(\tR\bfuelType\x12\x14\n" +
+	"\x05speed\x18\t \x01(\x05R\x05speed\x12\x1b\n" +
+	"\tengine_on\x18\n" +
+	" \x01(\bR\bengineOn\x12\x16\n" +
+	"\x06locked\x18\v \x01(\bR\x06locked\x12\x1c\n" +
+	"\tactivated\x18\f \x01(\bR\tactivated\x12\x10\n" +
+	"\x03rpm\x18\r \x01(\x05R\x03rpm\x12\x1c\n" +
+	"\thandbrake\x18\x0e \x01(\bR\thandbrake\x12\x1a\n" +
+	"\bsequence\x18\x0f \x01(\x04R\bsequence\x12\x1d\n" +
+	"\n" +
+	"clock_skew\x18\x10 \x01(\bR\tclockSkew\x12'\n" +
+	"\x0fidempotency_key\x18\x11 \x01(\tR\x0eidempotencyKey\"o\n" +
+	"\x0eTelemetryEvent\x12,\n" +
+	"\benvelope\x18\x01 \x01(\v2\x10.events.EnvelopeR\benvelope\x12/\n" +
+	"\ttelemetry\x18\x02 \x01(\v2\x11.events.TelemetryR\ttelemetry\"\xb9\x02\n" +
+	"\x0eViolationEvent\x12,\n" +
+	"\benvelope\x18\x01 \x01(\v2\x10.events.EnvelopeR\benvelope\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12/\n" +
+	"\ttelemetry\x18\x03 \x01(\v2\x11.events.TelemetryR\ttelemetry\x121\n" +
+	"\adetails\x18\x04 \x01(\v2\x17.google.protobuf.StructR\adetails\x12\x1a\n" +
+	"\bseverity\x18\x05 \x01(\tR\bseverity\x12\x17\n" +
+	"\arule_id\x18\x06 \x01(\tR\x06ruleId\x122\n" +
+	"\aepisode\x18\a \x01(\v2\x18.events.ViolationEpisodeR\aepisode\x12\x18\n" +
+	"\asubject\x18\b \x01(\tR\asubject\"\x84\x02\n" +
+	"\x10ViolationEpisode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x03 \x01(\x03R\tstartedAt\x12\x19\n" +
+	"\bended_at\x18\x04 \x01(\x03R\aendedAt\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12\x1d\n" +
+	"\n" +
+	"peak_speed\x18\x06 \x01(\x05R\tpeakSpeed\x12\x19\n" +
+	"\bpeak_rpm\x18\a \x01(\x05R\apeakRpm\x12\x19\n" +
+	"\bmin_fuel\x18\b \x01(\x01R\aminFuel\x12\x1a\n" +
+	"\breadings\x18\t \x01(\rR\breadingsB7Z5github.com/jekiti/citydrive/gen/proto/events;eventspbb\x06proto3"
+
+var (
+	file_events_proto_rawDescOnce sync.Once
+	file_events_proto_rawDescData []byte
+)
+
+func file_events_proto_rawDescGZIP() []byte {
+	file_events_proto_rawDescOnce.Do(func() {
+		file_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)))
+	})
+	return file_events_proto_rawDescData
+}
+
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_events_proto_goTypes = []any{
+	(*Envelope)(nil),         // 0: events.Envelope
+	(*Telemetry)(nil),        // 1: events.Telemetry
+	(*TelemetryEvent)(nil),   // 2: events.TelemetryEvent
+	(*ViolationEvent)(nil),   // 3: events.ViolationEvent
+	(*ViolationEpisode)(nil), // 4: events.ViolationEpisode
+	(*structpb.Struct)(nil),  // 5: google.protobuf.Struct
+}
+var file_events_proto_depIdxs = []int32{
+	0, // 0: events.TelemetryEvent.envelope:type_name -> events.Envelope
+	1, // 1: events.TelemetryEvent.telemetry:type_name -> events.Telemetry
+	0, // 2: events.ViolationEvent.envelope:type_name -> events.Envelope
+	1, // 3: events.ViolationEvent.telemetry:type_name -> events.Telemetry
+	5, // 4: events.ViolationEvent.details:type_name -> google.protobuf.Struct
+	4, // 5: events.ViolationEvent.episode:type_name -> events.ViolationEpisode
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_events_proto_init() }
+func file_events_proto_init() {
+	if File_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_proto_goTypes,
+		DependencyIndexes: file_events_proto_depIdxs,
+		MessageInfos:      file_events_proto_msgTypes,
+	}.Build()
+	File_events_proto = out.File
+	file_events_proto_goTypes = nil
+	file_events_proto_depIdxs = nil
+}
@@ -21,6 +21,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BatchItemStatus int32
+
+const (
+	BatchItemStatus_BATCH_ITEM_STATUS_UNSPECIFIED BatchItemStatus = 0
+	BatchItemStatus_BATCH_ITEM_STATUS_ACCEPTED    BatchItemStatus = 1 // принято, можно удалять из буфера
+	BatchItemStatus_BATCH_ITEM_STATUS_REJECTED    BatchItemStatus = 2 // некорректные данные, повторять не нужно
+	BatchItemStatus_BATCH_ITEM_STATUS_RETRY       BatchItemStatus = 3 // не обработано, отправить повторно
+)
+
+// Enum value maps for BatchItemStatus.
+var (
+	BatchItemStatus_name = map[int32]string{
+		0: "BATCH_ITEM_STATUS_UNSPECIFIED",
+		1: "BATCH_ITEM_STATUS_ACCEPTED",
+		2: "BATCH_ITEM_STATUS_REJECTED",
+		3: "BATCH_ITEM_STATUS_RETRY",
+	}
+	BatchItemStatus_value = map[string]int32{
+		"BATCH_ITEM_STATUS_UNSPECIFIED": 0,
+		"BATCH_ITEM_STATUS_ACCEPTED":    1,
+		"BATCH_ITEM_STATUS_REJECTED":    2,
+		"BATCH_ITEM_STATUS_RETRY":       3,
+	}
+)
+
+func (x BatchItemStatus) Enum() *BatchItemStatus {
+	p := new(BatchItemStatus)
+	*p = x
+	return p
+}
+
+func (x BatchItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_telemetry_proto_enumTypes[0].Descriptor()
+}
+
+func (BatchItemStatus) Type() protoreflect.EnumType {
+	return &file_telemetry_proto_enumTypes[0]
+}
+
+func (x BatchItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchItemStatus.Descriptor instead.
+func (BatchItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_telemetry_proto_rawDescGZIP(), []int{0}
+}
+
 // Запрос телеметрии от автомобиля
 type PutRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -38,6 +90,9 @@ type PutRequest struct {
 	Activated         bool                   `protobuf:"varint,12,opt,name=activated,proto3" json:"activated,omitempty"`                                           // is the car in use
 	Rpm               int32                  `protobuf:"varint,13,opt,name=rpm,proto3" json:"rpm,omitempty"`                                                       // engine revs
 	Handbrake         bool                   `protobuf:"varint,14,opt,name=handbrake,proto3" json:"handbrake,omitempty"`                                           // is the handbrake activated
+	RecordedAt        int64                  `protobuf:"varint,15,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`                       // unix timestamp (ms) on the device, 0 — unknown
+	Sequence          uint64                 `protobuf:"varint,16,opt,name=sequence,proto3" json:"sequence,omitempty"`                                             // monotonically increasing per car, 0 — not set
+	IdempotencyKey    string                 `protobuf:"bytes,17,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`            // client-provided key, if empty car_id + sequence is used
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -170,9 +225,31 @@ func (x *PutRequest) GetHandbrake() bool {
 	return false
 }
 
+func (x *PutRequest) GetRecordedAt() int64 {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return 0
+}
+
+func (x *PutRequest) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PutRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type PutResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Duplicate     bool                   `protobuf:"varint,3,opt,name=duplicate,proto3" json:"duplicate,omitempty"` // такое показание уже принято, повтор проигнорирован
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -214,11 +291,294 @@ func (x *PutResponse) GetMessage() string {
 	return ""
 }
 
+func (x *PutResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type StreamTelemetryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     uint64                 `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"` // id сообщения на стороне отправителя, возвращается в ack
+	CarId         string                 `protobuf:"bytes,2,opt,name=car_id,json=carId,proto3" json:"car_id,omitempty"`              // только для агрегатора из GRPC_STREAM_AGGREGATORS, остальным — пусто или car_id из metadata
+	Telemetry     *PutRequest            `protobuf:"bytes,3,opt,name=telemetry,proto3" json:"telemetry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTelemetryRequest) Reset() {
+	*x = StreamTelemetryRequest{}
+	mi := &file_telemetry_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTelemetryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTelemetryRequest) ProtoMessage() {}
+
+func (x *StreamTelemetryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTelemetryRequest.ProtoReflect.Descriptor instead.
+func (*StreamTelemetryRequest) Descriptor() ([]byte, []int) {
+	return file_telemetry_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamTelemetryRequest) GetMessageId() uint64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *StreamTelemetryRequest) GetCarId() string {
+	if x != nil {
+		return x.CarId
+	}
+	return ""
+}
+
+func (x *StreamTelemetryRequest) GetTelemetry() *PutRequest {
+	if x != nil {
+		return x.Telemetry
+	}
+	return nil
+}
+
+type TelemetryAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     uint64                 `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TelemetryAck) Reset() {
+	*x = TelemetryAck{}
+	mi := &file_telemetry_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TelemetryAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TelemetryAck) ProtoMessage() {}
+
+func (x *TelemetryAck) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TelemetryAck.ProtoReflect.Descriptor instead.
+func (*TelemetryAck) Descriptor() ([]byte, []int) {
+	return file_telemetry_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TelemetryAck) GetMessageId() uint64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *TelemetryAck) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *TelemetryAck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PutBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*PutRequest          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // в порядке снятия показаний
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutBatchRequest) Reset() {
+	*x = PutBatchRequest{}
+	mi := &file_telemetry_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutBatchRequest) ProtoMessage() {}
+
+func (x *PutBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutBatchRequest.ProtoReflect.Descriptor instead.
+func (*PutBatchRequest) Descriptor() ([]byte, []int) {
+	return file_telemetry_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PutBatchRequest) GetItems() []*PutRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type BatchItemResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Status        BatchItemStatus        `protobuf:"varint,2,opt,name=status,proto3,enum=telemetry.BatchItemStatus" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItemResult) Reset() {
+	*x = BatchItemResult{}
+	mi := &file_telemetry_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemResult) ProtoMessage() {}
+
+func (x *BatchItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemResult.ProtoReflect.Descriptor instead.
+func (*BatchItemResult) Descriptor() ([]byte, []int) {
+	return file_telemetry_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchItemResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BatchItemResult) GetStatus() BatchItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchItemStatus_BATCH_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *BatchItemResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PutBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchItemResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Accepted      int32                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutBatchResponse) Reset() {
+	*x = PutBatchResponse{}
+	mi := &file_telemetry_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutBatchResponse) ProtoMessage() {}
+
+func (x *PutBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_telemetry_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutBatchResponse.ProtoReflect.Descriptor instead.
+func (*PutBatchResponse) Descriptor() ([]byte, []int) {
+	return file_telemetry_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PutBatchResponse) GetResults() []*BatchItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *PutBatchResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
 var File_telemetry_proto protoreflect.FileDescriptor
 
 const file_telemetry_proto_rawDesc = "" +
 	"\n" +
-	"\x0ftelemetry.proto\x12\ttelemetry\"\xe8\x02\n" +
+	"\x0ftelemetry.proto\x12\ttelemetry\"\xce\x03\n" +
 	"\n" +
 	"PutRequest\x12\x14\n" +
 	"\x05brand\x18\x01 \x01(\tR\x05brand\x12\x14\n" +
@@ -235,11 +595,42 @@ const file_telemetry_proto_rawDesc = "" +
 	"\x06locked\x18\v \x01(\bR\x06locked\x12\x1c\n" +
 	"\tactivated\x18\f \x01(\bR\tactivated\x12\x10\n" +
 	"\x03rpm\x18\r \x01(\x05R\x03rpm\x12\x1c\n" +
-	"\thandbrake\x18\x0e \x01(\bR\thandbrake\"'\n" +
+	"\thandbrake\x18\x0e \x01(\bR\thandbrake\x12\x1f\n" +
+	"\vrecorded_at\x18\x0f \x01(\x03R\n" +
+	"recordedAt\x12\x1a\n" +
+	"\bsequence\x18\x10 \x01(\x04R\bsequence\x12'\n" +
+	"\x0fidempotency_key\x18\x11 \x01(\tR\x0eidempotencyKey\"E\n" +
 	"\vPutResponse\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2Q\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1c\n" +
+	"\tduplicate\x18\x03 \x01(\bR\tduplicate\"\x83\x01\n" +
+	"\x16StreamTelemetryRequest\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x04R\tmessageId\x12\x15\n" +
+	"\x06car_id\x18\x02 \x01(\tR\x05carId\x123\n" +
+	"\ttelemetry\x18\x03 \x01(\v2\x15.telemetry.PutRequestR\ttelemetry\"_\n" +
+	"\fTelemetryAck\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\x04R\tmessageId\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\">\n" +
+	"\x0fPutBatchRequest\x12+\n" +
+	"\x05items\x18\x01 \x03(\v2\x15.telemetry.PutRequestR\x05items\"q\n" +
+	"\x0fBatchItemResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x122\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1a.telemetry.BatchItemStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"d\n" +
+	"\x10PutBatchResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.telemetry.BatchItemResultR\aresults\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted*\x91\x01\n" +
+	"\x0fBatchItemStatus\x12!\n" +
+	"\x1dBATCH_ITEM_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aBATCH_ITEM_STATUS_ACCEPTED\x10\x01\x12\x1e\n" +
+	"\x1aBATCH_ITEM_STATUS_REJECTED\x10\x02\x12\x1b\n" +
+	"\x17BATCH_ITEM_STATUS_RETRY\x10\x032\xf2\x01\n" +
 	"\x10TelemetryService\x12=\n" +
-	"\fPutTelemetry\x12\x15.telemetry.PutRequest\x1a\x16.telemetry.PutResponseB=Z;github.com/jekiti/citydrive/gen/proto/telemetry;telemetrypbb\x06proto3"
+	"\fPutTelemetry\x12\x15.telemetry.PutRequest\x1a\x16.telemetry.PutResponse\x12Q\n" +
+	"\x0fStreamTelemetry\x12!.telemetry.StreamTelemetryRequest\x1a\x17.telemetry.TelemetryAck(\x010\x01\x12L\n" +
+	"\x11PutTelemetryBatch\x12\x1a.telemetry.PutBatchRequest\x1a\x1b.telemetry.PutBatchResponseB=Z;github.com/jekiti/citydrive/gen/proto/telemetry;telemetrypbb\x06proto3"
 
 var (
 	file_telemetry_proto_rawDescOnce sync.Once
@@ -253,19 +644,34 @@ func file_telemetry_proto_rawDescGZIP() []byte {
 	return file_telemetry_proto_rawDescData
 }
 
-var file_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_telemetry_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_telemetry_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_telemetry_proto_goTypes = []any{
-	(*PutRequest)(nil),  // 0: telemetry.PutRequest
-	(*PutResponse)(nil), // 1: telemetry.PutResponse
+	(BatchItemStatus)(0),           // 0: telemetry.BatchItemStatus
+	(*PutRequest)(nil),             // 1: telemetry.PutRequest
+	(*PutResponse)(nil),            // 2: telemetry.PutResponse
+	(*StreamTelemetryRequest)(nil), // 3: telemetry.StreamTelemetryRequest
+	(*TelemetryAck)(nil),           // 4: telemetry.TelemetryAck
+	(*PutBatchRequest)(nil),        // 5: telemetry.PutBatchRequest
+	(*BatchItemResult)(nil),        // 6: telemetry.BatchItemResult
+	(*PutBatchResponse)(nil),       // 7: telemetry.PutBatchResponse
 }
 var file_telemetry_proto_depIdxs = []int32{
-	0, // 0: telemetry.TelemetryService.PutTelemetry:input_type -> telemetry.PutRequest
-	1, // 1: telemetry.TelemetryService.PutTelemetry:output_type -> telemetry.PutResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: telemetry.StreamTelemetryRequest.telemetry:type_name -> telemetry.PutRequest
+	1, // 1: telemetry.PutBatchRequest.items:type_name -> telemetry.PutRequest
+	0, // 2: telemetry.BatchItemResult.status:type_name -> telemetry.BatchItemStatus
+	6, // 3: telemetry.PutBatchResponse.results:type_name -> telemetry.BatchItemResult
+	1, // 4: telemetry.TelemetryService.PutTelemetry:input_type -> telemetry.PutRequest
+	3, // 5: telemetry.TelemetryService.StreamTelemetry:input_type -> telemetry.StreamTelemetryRequest
+	5, // 6: telemetry.TelemetryService.PutTelemetryBatch:input_type -> telemetry.PutBatchRequest
+	2, // 7: telemetry.TelemetryService.PutTelemetry:output_type -> telemetry.PutResponse
+	4, // 8: telemetry.TelemetryService.StreamTelemetry:output_type -> telemetry.TelemetryAck
+	7, // 9: telemetry.TelemetryService.PutTelemetryBatch:output_type -> telemetry.PutBatchResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_telemetry_proto_init() }
@@ -278,13 +684,14 @@ func file_telemetry_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_telemetry_proto_rawDesc), len(file_telemetry_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_telemetry_proto_goTypes,
 		DependencyIndexes: file_telemetry_proto_depIdxs,
+		EnumInfos:         file_telemetry_proto_enumTypes,
 		MessageInfos:      file_telemetry_proto_msgTypes,
 	}.Build()
 	File_telemetry_proto = out.File
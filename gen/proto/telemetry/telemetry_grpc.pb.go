@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TelemetryService_PutTelemetry_FullMethodName = "/telemetry.TelemetryService/PutTelemetry"
+	TelemetryService_PutTelemetry_FullMethodName      = "/telemetry.TelemetryService/PutTelemetry"
+	TelemetryService_StreamTelemetry_FullMethodName   = "/telemetry.TelemetryService/StreamTelemetry"
+	TelemetryService_PutTelemetryBatch_FullMethodName = "/telemetry.TelemetryService/PutTelemetryBatch"
 )
 
 // TelemetryServiceClient is the client API for TelemetryService service.
@@ -27,6 +29,13 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TelemetryServiceClient interface {
 	PutTelemetry(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
+	// Долгоживущий поток от машины или edge-агрегатора. На каждое сообщение приходит ack
+	// с тем же message_id; пока сервер не обработал окно сообщений, чтение из потока
+	// приостанавливается и отправитель упирается в flow control gRPC.
+	StreamTelemetry(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamTelemetryRequest, TelemetryAck], error)
+	// Пачка показаний, накопленных машиной без связи. Обрабатываются по порядку,
+	// результат возвращается для каждого элемента.
+	PutTelemetryBatch(ctx context.Context, in *PutBatchRequest, opts ...grpc.CallOption) (*PutBatchResponse, error)
 }
 
 type telemetryServiceClient struct {
@@ -47,11 +56,41 @@ func (c *telemetryServiceClient) PutTelemetry(ctx context.Context, in *PutReques
 	return out, nil
 }
 
+func (c *telemetryServiceClient) StreamTelemetry(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamTelemetryRequest, TelemetryAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TelemetryService_ServiceDesc.Streams[0], TelemetryService_StreamTelemetry_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTelemetryRequest, TelemetryAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TelemetryService_StreamTelemetryClient = grpc.BidiStreamingClient[StreamTelemetryRequest, TelemetryAck]
+
+func (c *telemetryServiceClient) PutTelemetryBatch(ctx context.Context, in *PutBatchRequest, opts ...grpc.CallOption) (*PutBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutBatchResponse)
+	err := c.cc.Invoke(ctx, TelemetryService_PutTelemetryBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TelemetryServiceServer is the server API for TelemetryService service.
 // All implementations must embed UnimplementedTelemetryServiceServer
 // for forward compatibility.
 type TelemetryServiceServer interface {
 	PutTelemetry(context.Context, *PutRequest) (*PutResponse, error)
+	// Долгоживущий поток от машины или edge-агрегатора. На каждое сообщение приходит ack
+	// с тем же message_id; пока сервер не обработал окно сообщений, чтение из потока
+	// приостанавливается и отправитель упирается в flow control gRPC.
+	StreamTelemetry(grpc.BidiStreamingServer[StreamTelemetryRequest, TelemetryAck]) error
+	// Пачка показаний, накопленных машиной без связи. Обрабатываются по порядку,
+	// результат возвращается для каждого элемента.
+	PutTelemetryBatch(context.Context, *PutBatchRequest) (*PutBatchResponse, error)
 	mustEmbedUnimplementedTelemetryServiceServer()
 }
 
@@ -65,6 +104,12 @@ type UnimplementedTelemetryServiceServer struct{}
 func (UnimplementedTelemetryServiceServer) PutTelemetry(context.Context, *PutRequest) (*PutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutTelemetry not implemented")
 }
+func (UnimplementedTelemetryServiceServer) StreamTelemetry(grpc.BidiStreamingServer[StreamTelemetryRequest, TelemetryAck]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTelemetry not implemented")
+}
+func (UnimplementedTelemetryServiceServer) PutTelemetryBatch(context.Context, *PutBatchRequest) (*PutBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutTelemetryBatch not implemented")
+}
 func (UnimplementedTelemetryServiceServer) mustEmbedUnimplementedTelemetryServiceServer() {}
 func (UnimplementedTelemetryServiceServer) testEmbeddedByValue()                          {}
 
@@ -104,6 +149,31 @@ func _TelemetryService_PutTelemetry_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TelemetryService_StreamTelemetry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TelemetryServiceServer).StreamTelemetry(&grpc.GenericServerStream[StreamTelemetryRequest, TelemetryAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TelemetryService_StreamTelemetryServer = grpc.BidiStreamingServer[StreamTelemetryRequest, TelemetryAck]
+
+func _TelemetryService_PutTelemetryBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TelemetryServiceServer).PutTelemetryBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TelemetryService_PutTelemetryBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TelemetryServiceServer).PutTelemetryBatch(ctx, req.(*PutBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TelemetryService_ServiceDesc is the grpc.ServiceDesc for TelemetryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,7 +185,18 @@ var TelemetryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutTelemetry",
 			Handler:    _TelemetryService_PutTelemetry_Handler,
 		},
+		{
+			MethodName: "PutTelemetryBatch",
+			Handler:    _TelemetryService_PutTelemetryBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTelemetry",
+			Handler:       _TelemetryService_StreamTelemetry_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "telemetry.proto",
 }
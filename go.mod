@@ -3,6 +3,8 @@ module github.com/jekiti/citydrive
 go 1.24.5
 
 require (
+	github.com/expr-lang/expr v1.17.8
+	github.com/golang-jwt/jwt/v5 v5.3.1
 	google.golang.org/grpc v1.75.1
 	google.golang.org/protobuf v1.36.9
 )
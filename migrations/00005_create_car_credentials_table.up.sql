@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS citydrive.car_credentials (
+    car_id UUID PRIMARY KEY REFERENCES citydrive.cars(id) ON DELETE CASCADE,
+    token_id TEXT NOT NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_car_credentials_token_id ON citydrive.car_credentials(token_id);
@@ -0,0 +1,63 @@
+CREATE TABLE IF NOT EXISTS citydrive.roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) UNIQUE NOT NULL,
+    description TEXT
+);
+
+CREATE TABLE IF NOT EXISTS citydrive.permissions (
+    name VARCHAR(100) PRIMARY KEY,
+    description TEXT
+);
+
+CREATE TABLE IF NOT EXISTS citydrive.role_permissions (
+    role_id INTEGER NOT NULL REFERENCES citydrive.roles(id) ON DELETE CASCADE,
+    permission VARCHAR(100) NOT NULL REFERENCES citydrive.permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission)
+);
+
+CREATE TABLE IF NOT EXISTS citydrive.user_roles (
+    user_id INTEGER NOT NULL REFERENCES citydrive.users(id) ON DELETE CASCADE,
+    role_id INTEGER NOT NULL REFERENCES citydrive.roles(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+INSERT INTO citydrive.roles (name, description) VALUES
+    ('viewer', 'Просмотр текущего положения машин'),
+    ('dispatcher', 'Просмотр машин и выпуск токенов устройств'),
+    ('fleet-admin', 'Полный доступ к данным автопарка'),
+    ('superuser', 'Полный доступ, включая управление пользователями')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO citydrive.permissions (name, description) VALUES
+    ('cars.now.read', 'GET /api/v1/cars/now'),
+    ('cars.details.read', 'GET /api/v1/cars/:id'),
+    ('cars.history.read', 'GET /api/v1/cars/history, GET /api/v1/cars/:id/history'),
+    ('cars.tokens.manage', 'Выпуск и отзыв токенов автомобилей'),
+    ('users.manage', 'Регистрация пользователей и назначение ролей')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO citydrive.role_permissions (role_id, permission)
+SELECT r.id, p.permission
+FROM (VALUES
+    ('viewer', 'cars.now.read'),
+    ('dispatcher', 'cars.now.read'),
+    ('dispatcher', 'cars.tokens.manage'),
+    ('fleet-admin', 'cars.now.read'),
+    ('fleet-admin', 'cars.details.read'),
+    ('fleet-admin', 'cars.history.read'),
+    ('fleet-admin', 'cars.tokens.manage'),
+    ('superuser', 'cars.now.read'),
+    ('superuser', 'cars.details.read'),
+    ('superuser', 'cars.history.read'),
+    ('superuser', 'cars.tokens.manage'),
+    ('superuser', 'users.manage')
+) AS p(role, permission)
+JOIN citydrive.roles r ON r.name = p.role
+ON CONFLICT DO NOTHING;
+
+-- Существующие пользователи получали в токене роль admin, переносим их в fleet-admin.
+INSERT INTO citydrive.user_roles (user_id, role_id)
+SELECT u.id, r.id
+FROM citydrive.users u
+JOIN citydrive.roles r ON r.name = 'fleet-admin'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+ALTER TABLE citydrive.users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_users_department ON citydrive.users(department);
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS citydrive.api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash TEXT UNIQUE NOT NULL,
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    rate_limit INTEGER NOT NULL,
+    created_by INTEGER REFERENCES citydrive.users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    last_used_ip VARCHAR(45),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+INSERT INTO citydrive.permissions (name, description) VALUES
+    ('api_keys.manage', 'Выпуск и отзыв API ключей')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO citydrive.role_permissions (role_id, permission)
+SELECT r.id, 'api_keys.manage'
+FROM citydrive.roles r
+WHERE r.name = 'superuser'
+ON CONFLICT DO NOTHING;
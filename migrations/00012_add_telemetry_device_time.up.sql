@@ -0,0 +1,6 @@
+ALTER TABLE citydrive.car_telemetry_history
+    ADD COLUMN IF NOT EXISTS device_time BIGINT,
+    ADD COLUMN IF NOT EXISTS sequence BIGINT,
+    ADD COLUMN IF NOT EXISTS clock_skew BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_cth_car_id_sequence ON citydrive.car_telemetry_history(car_id, sequence);
@@ -0,0 +1,5 @@
+ALTER TABLE citydrive.car_telemetry_history
+    ADD COLUMN IF NOT EXISTS idempotency_key TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_cth_car_id_idempotency_key
+    ON citydrive.car_telemetry_history(car_id, idempotency_key);
@@ -0,0 +1,18 @@
+ALTER TABLE citydrive.cars ADD COLUMN IF NOT EXISTS decommissioned_at TIMESTAMP WITH TIME ZONE;
+
+-- telemetry keeps the car registry cached in Redis and drops an entry on this notification
+CREATE OR REPLACE FUNCTION citydrive.notify_car_changed() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('car_changed', OLD.id::text);
+        RETURN OLD;
+    END IF;
+    PERFORM pg_notify('car_changed', NEW.id::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_cars_notify_changed ON citydrive.cars;
+CREATE TRIGGER trg_cars_notify_changed
+    AFTER INSERT OR UPDATE OR DELETE ON citydrive.cars
+    FOR EACH ROW EXECUTE FUNCTION citydrive.notify_car_changed();
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS citydrive.violation_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type VARCHAR(64) UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    condition TEXT NOT NULL,
+    severity VARCHAR(16) NOT NULL CHECK (severity IN ('low', 'medium', 'high', 'critical')),
+    cooldown_seconds INTEGER NOT NULL DEFAULT 0 CHECK (cooldown_seconds >= 0),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- the rules telemetry had hard-coded, with the former VIOLATION_* defaults
+INSERT INTO citydrive.violation_rules (type, description, condition, severity) VALUES
+    ('speeding_low', 'Превышение скорости до 130 км/ч', 'speed > 110 && speed < 130', 'low'),
+    ('speeding_medium', 'Превышение скорости от 130 до 150 км/ч', 'speed >= 130 && speed < 150', 'medium'),
+    ('speeding_high', 'Превышение скорости от 150 км/ч', 'speed >= 150', 'high'),
+    ('drift', 'Высокие обороты на ручнике', 'rpm > 5000 && handbrake', 'medium'),
+    ('low_fuel', 'Топлива меньше 2%', 'fuel < 2', 'low'),
+    ('stealed_auto', 'Движение закрытой машины без аренды', '!activated && !locked && engine_on && speed != 0', 'critical')
+ON CONFLICT (type) DO NOTHING;
+
+-- telemetry reloads the rules on this notification
+CREATE OR REPLACE FUNCTION citydrive.notify_violation_rules_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('violation_rules_changed', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_violation_rules_notify_changed ON citydrive.violation_rules;
+CREATE TRIGGER trg_violation_rules_notify_changed
+    AFTER INSERT OR UPDATE OR DELETE ON citydrive.violation_rules
+    FOR EACH STATEMENT EXECUTE FUNCTION citydrive.notify_violation_rules_changed();
+
+INSERT INTO citydrive.permissions (name, description) VALUES
+    ('violation_rules.manage', 'Настройка правил нарушений')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO citydrive.role_permissions (role_id, permission)
+SELECT r.id, 'violation_rules.manage'
+FROM citydrive.roles r
+WHERE r.name IN ('fleet-admin', 'superuser')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,13 @@
+-- episodes close only after the violation is gone for cooldown_seconds,
+-- so a short dip below the limit doesn't split one speeding into several
+UPDATE citydrive.violation_rules AS r
+SET cooldown_seconds = d.cooldown_seconds, updated_at = CURRENT_TIMESTAMP
+FROM (VALUES
+    ('speeding_low', 30),
+    ('speeding_medium', 30),
+    ('speeding_high', 30),
+    ('drift', 10),
+    ('low_fuel', 600),
+    ('stealed_auto', 300)
+) AS d(type, cooldown_seconds)
+WHERE r.type = d.type AND r.cooldown_seconds = 0;
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS citydrive.geozones (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    -- [{"lat": 55.75, "lon": 37.61}, ...], the ring is closed implicitly
+    polygon JSONB NOT NULL,
+    speed_limit INTEGER CHECK (speed_limit > 0),
+    no_entry BOOLEAN NOT NULL DEFAULT FALSE,
+    operating_area BOOLEAN NOT NULL DEFAULT FALSE,
+    -- local time in TELEMETRY_ZONE_TIMEZONE, both NULL means any time
+    allowed_from TIME,
+    allowed_to TIME,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((allowed_from IS NULL) = (allowed_to IS NULL))
+);
+
+-- telemetry rebuilds its zone index on this notification
+CREATE OR REPLACE FUNCTION citydrive.notify_geozones_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('geozones_changed', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_geozones_notify_changed ON citydrive.geozones;
+CREATE TRIGGER trg_geozones_notify_changed
+    AFTER INSERT OR UPDATE OR DELETE ON citydrive.geozones
+    FOR EACH STATEMENT EXECUTE FUNCTION citydrive.notify_geozones_changed();
+
+INSERT INTO citydrive.permissions (name, description) VALUES
+    ('geozones.manage', 'Настройка геозон')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO citydrive.role_permissions (role_id, permission)
+SELECT r.id, 'geozones.manage'
+FROM citydrive.roles r
+WHERE r.name IN ('fleet-admin', 'superuser')
+ON CONFLICT DO NOTHING;
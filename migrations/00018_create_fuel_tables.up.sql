@@ -0,0 +1,27 @@
+-- fuel norms of a model, telemetry estimates liters and checks consumption by them
+CREATE TABLE IF NOT EXISTS citydrive.fuel_norms (
+    brand TEXT NOT NULL,
+    model TEXT NOT NULL,
+    tank_liters NUMERIC(6, 1) NOT NULL CHECK (tank_liters > 0),
+    consumption_l_100km NUMERIC(5, 2) NOT NULL CHECK (consumption_l_100km > 0),
+    PRIMARY KEY (brand, model)
+);
+
+-- refuels detected by telemetry, written by processing from telemetry.violations
+CREATE TABLE IF NOT EXISTS citydrive.refuels (
+    id UUID PRIMARY KEY,
+    car_id UUID NOT NULL REFERENCES citydrive.cars(id) ON DELETE CASCADE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    lat DOUBLE PRECISION NOT NULL,
+    lon DOUBLE PRECISION NOT NULL,
+    odo BIGINT NOT NULL,
+    fuel_before DOUBLE PRECISION NOT NULL,
+    fuel_after DOUBLE PRECISION NOT NULL,
+    -- NULL when there is no fuel norm for the model
+    volume_liters DOUBLE PRECISION,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_refuels_started_at ON citydrive.refuels (started_at);
+CREATE INDEX IF NOT EXISTS idx_refuels_car_id_started_at ON citydrive.refuels (car_id, started_at);
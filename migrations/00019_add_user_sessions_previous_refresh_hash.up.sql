@@ -0,0 +1,4 @@
+-- the refresh token replaced by the last rotation, presenting it again means the token leaked
+ALTER TABLE citydrive.user_sessions ADD COLUMN IF NOT EXISTS previous_refresh_token_hash TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_previous_refresh_token_hash ON citydrive.user_sessions(previous_refresh_token_hash);
@@ -0,0 +1,39 @@
+// Package cartoken checks the claims of car tokens issued by auth. api-gateway uses it
+// for HTTP and gRPC telemetry, telemetry for MQTT connections.
+package cartoken
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrExpired       = errors.New("car token is expired")
+	ErrNotCar        = errors.New("not a car token")
+	ErrInvalidClaims = errors.New("invalid car token claims")
+)
+
+// CarID returns the car_id of a car token whose signature is already verified.
+// Unless HS256 is used user and car tokens are signed with the same keys, so besides
+// the role the subject has to name the same car as car_id.
+func CarID(claims jwt.MapClaims, now time.Time) (string, error) {
+	exp, ok := claims["exp"].(float64)
+	if !ok || float64(now.Unix()) > exp {
+		return "", ErrExpired
+	}
+	roles, ok := claims["roles"].([]any)
+	if !ok {
+		return "", ErrInvalidClaims
+	}
+	if len(roles) == 0 || roles[0] != "car" {
+		return "", ErrNotCar
+	}
+	carID, _ := claims["car_id"].(string)
+	sub, _ := claims["sub"].(string)
+	if carID == "" || sub != "car:"+carID {
+		return "", ErrInvalidClaims
+	}
+	return carID, nil
+}
@@ -0,0 +1,201 @@
+// Package jwks verifies tokens signed by auth with RS256 or EdDSA against the public
+// keys auth serves, api-gateway and telemetry share it.
+package jwks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRefreshInterval = 30 * time.Second
+
+var ErrUnknownKeyID = errors.New("unknown signing key id")
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+}
+
+type signingKey struct {
+	alg string
+	key any
+}
+
+// Client caches the keys from auth's JWKS endpoint for ttl.
+type Client struct {
+	url        string
+	ttl        time.Duration
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]signingKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	// closed when the running refresh ends, nil when none is running
+	refreshing chan struct{}
+}
+
+func NewClient(url string, ttl time.Duration) *Client {
+	return &Client{
+		url:        url,
+		ttl:        ttl,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		keys:       map[string]signingKey{},
+	}
+}
+
+func (c *Client) Keyfunc(token *jwt.Token) (any, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return nil, ErrUnknownKeyID
+	}
+
+	key, err := c.lookup(kid)
+	if err != nil {
+		return nil, err
+	}
+	if token.Method.Alg() != key.alg {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	return key.key, nil
+}
+
+func (c *Client) lookup(kid string) (signingKey, error) {
+	c.mu.Lock()
+	key, found := c.keys[kid]
+	if found && time.Since(c.fetchedAt) < c.ttl {
+		c.mu.Unlock()
+		return key, nil
+	}
+
+	// an unknown kid usually means auth rotated its key, but refetching is
+	// throttled so garbage kids can't be used to hammer the auth service;
+	// if auth is unreachable the stale keys keep working
+	done := c.refreshing
+	if done == nil && time.Since(c.attemptedAt) >= minRefreshInterval {
+		c.attemptedAt = time.Now()
+		done = make(chan struct{})
+		c.refreshing = done
+		go c.refresh(done)
+	}
+	c.mu.Unlock()
+
+	// a known key is used stale while the refresh runs, only an unknown kid waits for it
+	if found {
+		return key, nil
+	}
+	if done == nil {
+		return signingKey{}, ErrUnknownKeyID
+	}
+	<-done
+
+	c.mu.Lock()
+	key, found = c.keys[kid]
+	c.mu.Unlock()
+	if !found {
+		return signingKey{}, ErrUnknownKeyID
+	}
+	return key, nil
+}
+
+// refresh fetches the keys without holding mu and closes done when it is over.
+func (c *Client) refresh(done chan struct{}) {
+	keys, err := c.fetch()
+
+	c.mu.Lock()
+	if err != nil {
+		slog.Default().Error("failed to refresh jwks", "module", "jwks", "url", c.url, "error", err)
+	} else {
+		c.keys = keys
+		c.fetchedAt = time.Now()
+	}
+	c.refreshing = nil
+	c.mu.Unlock()
+	close(done)
+}
+
+func (c *Client) fetch() (map[string]signingKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.httpClient.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected jwks response status: %d", resp.StatusCode)
+	}
+
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]signingKey, len(doc.Keys))
+	for _, k := range doc.Keys {
+		key, err := parseJWK(k)
+		if err != nil {
+			slog.Default().Warn("skipping jwk", "module", "jwks", "kid", k.Kid, "error", err)
+			continue
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func parseJWK(k jwk) (signingKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return signingKey{}, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return signingKey{}, fmt.Errorf("invalid exponent: %w", err)
+		}
+		return signingKey{
+			alg: "RS256",
+			key: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			},
+		}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return signingKey{}, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return signingKey{}, errors.New("invalid Ed25519 public key")
+		}
+		return signingKey{alg: "EdDSA", key: ed25519.PublicKey(x)}, nil
+	default:
+		return signingKey{}, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
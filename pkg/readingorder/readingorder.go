@@ -0,0 +1,29 @@
+// Package readingorder tells a delayed reading of a car from a newer one. telemetry uses it
+// to keep the stored car state from going back, processing for the state cache.
+package readingorder
+
+// Reading is what the order of readings is decided on. RecordedAt is the device time in
+// unix ms, ClockSkew marks a device clock that can't be trusted.
+type Reading struct {
+	Sequence   uint64
+	RecordedAt int64
+	ClockSkew  bool
+}
+
+// Older reports whether current was taken before previous and must not replace it.
+// The device time decides when both readings have one: the sequence starts over after
+// a reboot or a firmware update, so a lower sequence with a newer time is a restart.
+// The sequence is compared only when the time can't tell. With a skewed clock the
+// order is unknown and the reading is not treated as older.
+func Older(current, previous Reading) bool {
+	if current.ClockSkew || previous.ClockSkew {
+		return false
+	}
+	if current.RecordedAt != 0 && previous.RecordedAt != 0 && current.RecordedAt != previous.RecordedAt {
+		return current.RecordedAt < previous.RecordedAt
+	}
+	if current.Sequence != 0 && previous.Sequence != 0 {
+		return current.Sequence < previous.Sequence
+	}
+	return false
+}
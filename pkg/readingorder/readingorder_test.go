@@ -0,0 +1,48 @@
+package readingorder
+
+import "testing"
+
+func TestOlder(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  Reading
+		previous Reading
+		older    bool
+	}{
+		{"lower sequence", Reading{Sequence: 4}, Reading{Sequence: 5}, true},
+		{"higher sequence", Reading{Sequence: 6}, Reading{Sequence: 5}, false},
+		{"same sequence", Reading{Sequence: 5}, Reading{Sequence: 5}, false},
+		{"earlier recorded_at", Reading{RecordedAt: 1000}, Reading{RecordedAt: 2000}, true},
+		{"later recorded_at", Reading{RecordedAt: 3000}, Reading{RecordedAt: 2000}, false},
+		{"device restart: sequence dropped, recorded_at newer",
+			Reading{Sequence: 1, RecordedAt: 3000},
+			Reading{Sequence: 9000, RecordedAt: 2000}, false},
+		{"delayed reading: sequence higher, recorded_at older",
+			Reading{Sequence: 6, RecordedAt: 1000},
+			Reading{Sequence: 5, RecordedAt: 2000}, true},
+		{"sequence decides on equal recorded_at",
+			Reading{Sequence: 4, RecordedAt: 2000},
+			Reading{Sequence: 5, RecordedAt: 2000}, true},
+		{"recorded_at when only one side has a sequence",
+			Reading{Sequence: 7, RecordedAt: 1000},
+			Reading{RecordedAt: 2000}, true},
+		{"sequence when only one side has recorded_at",
+			Reading{Sequence: 4, RecordedAt: 3000},
+			Reading{Sequence: 5}, true},
+		{"no device time on the current reading", Reading{}, Reading{RecordedAt: 2000}, false},
+		{"no device time on the previous reading", Reading{RecordedAt: 1000}, Reading{}, false},
+		{"current clock skewed",
+			Reading{Sequence: 4, RecordedAt: 1000, ClockSkew: true},
+			Reading{Sequence: 5, RecordedAt: 2000}, false},
+		{"previous clock skewed",
+			Reading{RecordedAt: 1000},
+			Reading{RecordedAt: 2000, ClockSkew: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Older(tt.current, tt.previous); got != tt.older {
+				t.Fatalf("Older = %v, want %v", got, tt.older)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package violationrules compiles the conditions of violation rules stored in
+// citydrive.violation_rules. admin uses it to validate a rule before saving it,
+// telemetry to evaluate rules against every reading.
+package violationrules
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+const maxConditionLength = 1000
+
+var (
+	ErrInvalidRule = errors.New("invalid violation rule")
+
+	typePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+)
+
+// Reading is a car reading as seen by conditions, e.g. `speed > 110 && !handbrake`.
+type Reading struct {
+	Brand             string  `expr:"brand"`
+	Model             string  `expr:"model"`
+	YearOfManufacture int32   `expr:"year_of_manufacture"`
+	Odo               int64   `expr:"odo"`
+	Lat               float64 `expr:"lat"`
+	Lon               float64 `expr:"lon"`
+	Fuel              float64 `expr:"fuel"`
+	FuelType          string  `expr:"fuel_type"`
+	Speed             int32   `expr:"speed"`
+	EngineOn          bool    `expr:"engine_on"`
+	Locked            bool    `expr:"locked"`
+	Activated         bool    `expr:"activated"`
+	RPM               int32   `expr:"rpm"`
+	Handbrake         bool    `expr:"handbrake"`
+	RecordedAt        int64   `expr:"recorded_at"`
+	Sequence          uint64  `expr:"sequence"`
+}
+
+// Env is what a condition is evaluated against: the fields of the current reading and
+// prev, the last known state of the car, which is nil for a car seen the first time.
+type Env struct {
+	Reading
+	Prev *Reading `expr:"prev"`
+}
+
+// Rule is a definition as stored, Condition must evaluate to a bool.
+type Rule struct {
+	ID          string
+	Type        string
+	Description string
+	Condition   string
+	Severity    string
+	// CooldownSeconds is how long the violation may be absent before its episode is closed,
+	// a repeat within it continues the episode instead of starting a new one.
+	CooldownSeconds int32
+	Enabled         bool
+}
+
+// Validate checks the definition and compiles the condition.
+func Validate(rule *Rule) error {
+	if !typePattern.MatchString(rule.Type) {
+		return fmt.Errorf("%w: type must be snake_case, at most 64 characters", ErrInvalidRule)
+	}
+	switch rule.Severity {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+	default:
+		return fmt.Errorf("%w: severity must be one of: low, medium, high, critical", ErrInvalidRule)
+	}
+	if rule.CooldownSeconds < 0 {
+		return fmt.Errorf("%w: cooldown must not be negative", ErrInvalidRule)
+	}
+	_, err := Compile(rule.Condition)
+	return err
+}
+
+// Compile checks the condition against Env and prepares it for Eval.
+func Compile(condition string) (*vm.Program, error) {
+	if condition == "" {
+		return nil, fmt.Errorf("%w: condition is required", ErrInvalidRule)
+	}
+	if len(condition) > maxConditionLength {
+		return nil, fmt.Errorf("%w: condition must be at most %d characters", ErrInvalidRule, maxConditionLength)
+	}
+	program, err := expr.Compile(condition, expr.Env(Env{}), expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("%w: condition: %v", ErrInvalidRule, err)
+	}
+	return program, nil
+}
+
+// Eval runs a compiled condition, an error means the condition failed at runtime,
+// e.g. it used prev without checking it for nil.
+func Eval(program *vm.Program, env *Env) (bool, error) {
+	out, err := expr.Run(program, env)
+	if err != nil {
+		return false, err
+	}
+	matched, _ := out.(bool)
+	return matched, nil
+}
@@ -42,15 +42,23 @@ func main() {
 	}
 
 	svc := service.NewService(consumer, cache, repo, &cfg.Processor, log)
+	refuelConsumer := repository.NewKafkaRefuelConsumer(&cfg.Kafka, log)
+	refuels := service.NewRefuelService(refuelConsumer, repo, &cfg.Processor, log)
 
 	var wg sync.WaitGroup
-	wg.Add(1)
+	wg.Add(2)
 	go func() {
 		defer wg.Done()
 		if err := svc.ProcessTelemetry(ctx); err != nil && !errors.Is(err, context.Canceled) {
 			log.Error("process telemetry", "error", err)
 		}
 	}()
+	go func() {
+		defer wg.Done()
+		if err := refuels.ProcessRefuels(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error("process refuels", "error", err)
+		}
+	}()
 
 	addr := ":" + cfg.App.HTTPPort
 	srv := &http.Server{
@@ -92,6 +100,9 @@ func main() {
 	if err := consumer.Close(); err != nil {
 		log.Error("close kafka", "error", err)
 	}
+	if err := refuelConsumer.Close(); err != nil {
+		log.Error("close kafka refuels", "error", err)
+	}
 
 	log.Info("Shutdown completed")
 }
@@ -44,7 +44,10 @@ type RedisConfig struct {
 type KafkaConfig struct {
 	Brokers         string
 	TopicTelemetry  string
+	TopicViolations string
 	ConsumerGroupID string
+	// refuels are read from TopicViolations by their own group
+	RefuelsGroupID  string
 	AutoOffsetReset string
 	ClientID        string
 }
@@ -101,7 +104,9 @@ func LoadProcessorConfig() *ProcessorConfig {
 		Kafka: KafkaConfig{
 			Brokers:         mustGet("KAFKA_BROKERS"),
 			TopicTelemetry:  topicTelemetry,
+			TopicViolations: getDefault("KAFKA_TOPIC_VIOLATIONS", "telemetry.violations"),
 			ConsumerGroupID: getDefault("KAFKA_CONSUMER_GROUP_ID", "telemetry-processor-group"),
+			RefuelsGroupID:  getDefault("KAFKA_REFUELS_GROUP_ID", "telemetry-processor-refuels"),
 			AutoOffsetReset: getDefault("KAFKA_AUTO_OFFSET_RESET", "earliest"),
 			ClientID:        getDefault("KAFKA_CLIENT_ID", "telemetry-processor"),
 		},
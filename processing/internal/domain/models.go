@@ -22,4 +22,31 @@ type CarTelemetry struct {
 	Handbrake         bool    `json:"handbrake"`
 	CarID             string  `json:"car_id"`
 	ReceivedAt        int64   `json:"received_at"`
+	RecordedAt        int64   `json:"recorded_at"`
+	Sequence          uint64  `json:"sequence"`
+	ClockSkew         bool    `json:"clock_skew"`
+	IdempotencyKey    string  `json:"idempotency_key"`
+}
+
+// Timestamp is the reading time in unix seconds: the device clock when it can be trusted,
+// the Kafka append time otherwise.
+func (t *CarTelemetry) Timestamp() int64 {
+	if t.RecordedAt > 0 && !t.ClockSkew {
+		return t.RecordedAt / 1000
+	}
+	return t.ReceivedAt
+}
+
+// Refuel is a refuel event from telemetry.violations. Times are unix ms.
+type Refuel struct {
+	ID           string
+	CarID        string
+	StartedAt    int64
+	EndedAt      int64
+	Lat          float64
+	Lon          float64
+	Odo          int64
+	FuelBefore   float64
+	FuelAfter    float64
+	VolumeLiters float64 // 0 when the model has no fuel norm
 }
@@ -4,13 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"strings"
 	"time"
 
+	eventspb "github.com/jekiti/citydrive/gen/proto/events"
 	"github.com/jekiti/citydrive/processing/internal/config"
 	"github.com/jekiti/citydrive/processing/internal/domain"
 	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	headerContentType   = "content-type"
+	contentTypeProtobuf = "application/x-protobuf"
 )
 
 type Consumer interface {
@@ -65,21 +73,72 @@ func (kc *KafkaConsumer) GetMessages(ctx context.Context, count int) ([]domain.C
 
 		log.Info("read message from kafka", "offset", msg.Offset)
 
-		var telemetry domain.CarTelemetry
-		err = json.Unmarshal(msg.Value, &telemetry)
+		kc.lastMessages = append(kc.lastMessages, msg)
+		telemetry, err := decodeTelemetry(msg)
 		if err != nil {
-			log.Error("error unmarshaling message", "error", err)
+			// a message that can't be decoded never will be, it is committed and skipped
+			log.Error("error decoding message", "offset", msg.Offset, "error", err)
+			continue
 		}
-		telemetry.CarID = string(msg.Key)
-		telemetry.ReceivedAt = msg.Time.Unix()
-
-		messages = append(messages, telemetry)
-		kc.lastMessages = append(kc.lastMessages, msg)
+		messages = append(messages, *telemetry)
 	}
 	log.Info("getted messages successful", "msg", messages)
 	return messages, nil
 }
 
+// decodeTelemetry reads a telemetry.raw message: protobuf events.TelemetryEvent when
+// the content-type header says so, the JSON of TelemetryData sent before otherwise.
+func decodeTelemetry(msg kafka.Message) (*domain.CarTelemetry, error) {
+	telemetry := domain.CarTelemetry{
+		CarID:      string(msg.Key),
+		ReceivedAt: msg.Time.Unix(),
+	}
+	if header(msg, headerContentType) != contentTypeProtobuf {
+		if err := json.Unmarshal(msg.Value, &telemetry); err != nil {
+			return nil, fmt.Errorf("failed unmarshal telemetry json:%w", err)
+		}
+		return &telemetry, nil
+	}
+
+	var event eventspb.TelemetryEvent
+	if err := proto.Unmarshal(msg.Value, &event); err != nil {
+		return nil, fmt.Errorf("failed unmarshal telemetry event:%w", err)
+	}
+	// newer schema versions only add fields, the ones known here are still valid
+	if carID := event.GetEnvelope().GetCarId(); carID != "" {
+		telemetry.CarID = carID
+	}
+	telemetry.RecordedAt = event.GetEnvelope().GetRecordedAt()
+	t := event.GetTelemetry()
+	telemetry.Brand = t.GetBrand()
+	telemetry.Model = t.GetModel()
+	telemetry.YearOfManufacture = t.GetYearOfManufacture()
+	telemetry.Odo = t.GetOdo()
+	telemetry.Lat = t.GetLat()
+	telemetry.Lon = t.GetLon()
+	telemetry.Fuel = t.GetFuel()
+	telemetry.FuelType = t.GetFuelType()
+	telemetry.Speed = t.GetSpeed()
+	telemetry.EngineOn = t.GetEngineOn()
+	telemetry.Locked = t.GetLocked()
+	telemetry.Activated = t.GetActivated()
+	telemetry.Rpm = t.GetRpm()
+	telemetry.Handbrake = t.GetHandbrake()
+	telemetry.Sequence = t.GetSequence()
+	telemetry.ClockSkew = t.GetClockSkew()
+	telemetry.IdempotencyKey = t.GetIdempotencyKey()
+	return &telemetry, nil
+}
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
 func (kc *KafkaConsumer) Commit() error {
 	log := kc.log.With("module", "repository", "function", "Commit")
 	log.Info("committing offsets to kafka", "count", len(kc.lastMessages))
@@ -2,15 +2,24 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jekiti/citydrive/processing/internal/config"
 	"github.com/jekiti/citydrive/processing/internal/domain"
 )
 
+// ErrRefuelRejected means postgres will never accept the refuel, e.g. its car was deleted,
+// so retrying it is pointless.
+var ErrRefuelRejected = errors.New("refuel rejected")
+
 type DBRepository interface {
 	SaveTelemetry(telemetry domain.CarTelemetry) error
+	SaveRefuel(refuel domain.Refuel) error
 	Close() error
 }
 
@@ -44,12 +53,15 @@ func (r *PostgresRepository) SaveTelemetry(tel domain.CarTelemetry) error {
 	log := r.log.With("module", "repository", "function", "SaveCarState", "car_id", tel.CarID)
 	query := `
 		INSERT INTO citydrive.car_telemetry_history
-		(car_id, lat, lon, fuel, speed, engine_on, locked, activated, rpm, handbrake, odo, "timestamp")
+		(car_id, lat, lon, fuel, speed, engine_on, locked, activated, rpm, handbrake, odo, "timestamp",
+		device_time, sequence, clock_skew, idempotency_key)
 		VALUES
-		($1::uuid, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		($1::uuid, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13::bigint, 0), NULLIF($14::bigint, 0), $15,
+		NULLIF($16::text, ''))
+		ON CONFLICT (car_id, idempotency_key) DO NOTHING
 		`
 
-	_, err := r.db.Exec(query,
+	res, err := r.db.Exec(query,
 		tel.CarID,      
 		tel.Lat,
 		tel.Lon,
@@ -61,7 +73,11 @@ func (r *PostgresRepository) SaveTelemetry(tel domain.CarTelemetry) error {
 		tel.Rpm,
 		tel.Handbrake,
 		tel.Odo,
-		tel.ReceivedAt,
+		tel.Timestamp(),
+		tel.RecordedAt,
+		int64(tel.Sequence),
+		tel.ClockSkew,
+		tel.IdempotencyKey,
 	)
 
 
@@ -69,10 +85,50 @@ func (r *PostgresRepository) SaveTelemetry(tel domain.CarTelemetry) error {
 		log.Error("error saving car state to postgres", "error", err)
 		return err
 	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		log.Warn("duplicate telemetry skipped", "idempotency_key", tel.IdempotencyKey)
+		return nil
+	}
 	log.Info("car state saved to postgres", "car_id", tel.CarID)
 	return nil
 }
 
+// SaveRefuel ignores a refuel delivered again, it is keyed by the id telemetry gave it.
+func (r *PostgresRepository) SaveRefuel(refuel domain.Refuel) error {
+	log := r.log.With("module", "repository", "function", "SaveRefuel", "car_id", refuel.CarID, "refuel_id", refuel.ID)
+	query := `
+		INSERT INTO citydrive.refuels
+		(id, car_id, started_at, ended_at, lat, lon, odo, fuel_before, fuel_after, volume_liters)
+		VALUES
+		($1::uuid, $2::uuid, to_timestamp($3::bigint / 1000.0), to_timestamp($4::bigint / 1000.0), $5, $6, $7, $8, $9,
+		NULLIF($10::float8, 0))
+		ON CONFLICT (id) DO NOTHING
+		`
+	_, err := r.db.Exec(query,
+		refuel.ID,
+		refuel.CarID,
+		refuel.StartedAt,
+		refuel.EndedAt,
+		refuel.Lat,
+		refuel.Lon,
+		refuel.Odo,
+		refuel.FuelBefore,
+		refuel.FuelAfter,
+		refuel.VolumeLiters,
+	)
+	if err != nil {
+		log.Error("error saving refuel to postgres", "error", err)
+		var pgErr *pgconn.PgError
+		// class 22 is bad data, class 23 a violated constraint such as an unknown car_id
+		if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+			return fmt.Errorf("%w: %v", ErrRefuelRejected, err)
+		}
+		return err
+	}
+	log.Info("refuel saved to postgres")
+	return nil
+}
+
 func (r *PostgresRepository) Close() error {
 	log := r.log.With("module", "repository", "function", "Close")
 	log.Info("closing postgres connection")
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	eventspb "github.com/jekiti/citydrive/gen/proto/events"
+	"github.com/jekiti/citydrive/processing/internal/config"
+	"github.com/jekiti/citydrive/processing/internal/domain"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+)
+
+const violationTypeRefuel = "refuel"
+
+type RefuelConsumer interface {
+	GetRefuels(ctx context.Context, count int) ([]domain.Refuel, error)
+	Commit() error
+	Close() error
+}
+
+// KafkaRefuelConsumer reads telemetry.violations and keeps only refuel events.
+type KafkaRefuelConsumer struct {
+	reader       *kafka.Reader
+	lastMessages []kafka.Message
+	log          *slog.Logger
+}
+
+func NewKafkaRefuelConsumer(config *config.KafkaConfig, log *slog.Logger) RefuelConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  strings.Split(config.Brokers, ","),
+		Topic:    config.TopicViolations,
+		GroupID:  config.RefuelsGroupID,
+		MinBytes: 1,
+		MaxBytes: 10e6,
+		MaxWait:  300 * time.Millisecond,
+	})
+	return &KafkaRefuelConsumer{reader: reader, log: log}
+}
+
+// GetRefuels fetches up to count messages, the ones that are not refuels are committed with them.
+// Offsets are committed only by Commit, once the refuels are stored. A Kafka error ends the
+// batch early: the refuels fetched before it are still returned, Commit covers exactly them.
+func (kc *KafkaRefuelConsumer) GetRefuels(ctx context.Context, count int) ([]domain.Refuel, error) {
+	log := kc.log.With("module", "repository", "function", "GetRefuels")
+	refuels := make([]domain.Refuel, 0)
+
+	newCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	for read := 0; read < count; read++ {
+		msg, err := kc.reader.FetchMessage(newCtx)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				break
+			}
+			log.Error("error reading message from kafka", "error", err, "fetched", len(kc.lastMessages))
+			if len(kc.lastMessages) == 0 {
+				return nil, err
+			}
+			break
+		}
+		kc.lastMessages = append(kc.lastMessages, msg)
+
+		refuel, err := decodeRefuel(msg)
+		if err != nil {
+			log.Error("error decoding message", "offset", msg.Offset, "error", err)
+			continue
+		}
+		if refuel != nil {
+			refuels = append(refuels, *refuel)
+		}
+	}
+	return refuels, nil
+}
+
+// legacyViolation is the JSON a violation was sent as before proto/events.
+type legacyViolation struct {
+	Type    string
+	CarID   string
+	Details map[string]interface{}
+}
+
+// decodeRefuel returns nil for a violation of another type.
+func decodeRefuel(msg kafka.Message) (*domain.Refuel, error) {
+	var violationType, carID string
+	var details map[string]interface{}
+	if header(msg, headerContentType) != contentTypeProtobuf {
+		var v legacyViolation
+		if err := json.Unmarshal(msg.Value, &v); err != nil {
+			return nil, fmt.Errorf("failed unmarshal violation json:%w", err)
+		}
+		violationType, carID, details = v.Type, v.CarID, v.Details
+	} else {
+		var event eventspb.ViolationEvent
+		if err := proto.Unmarshal(msg.Value, &event); err != nil {
+			return nil, fmt.Errorf("failed unmarshal violation event:%w", err)
+		}
+		violationType, carID = event.GetType(), event.GetEnvelope().GetCarId()
+		details = event.GetDetails().AsMap()
+	}
+	if violationType != violationTypeRefuel {
+		return nil, nil
+	}
+
+	refuel := &domain.Refuel{
+		CarID:        carID,
+		StartedAt:    int64(number(details, "started_at")),
+		EndedAt:      int64(number(details, "ended_at")),
+		Lat:          number(details, "lat"),
+		Lon:          number(details, "lon"),
+		Odo:          int64(number(details, "odo")),
+		FuelBefore:   number(details, "fuel_before"),
+		FuelAfter:    number(details, "fuel_after"),
+		VolumeLiters: number(details, "volume_liters"),
+	}
+	refuel.ID, _ = details["refuel_id"].(string)
+	if refuel.ID == "" || refuel.CarID == "" {
+		return nil, fmt.Errorf("refuel without refuel_id or car_id")
+	}
+	return refuel, nil
+}
+
+// number reads a detail, JSON and structpb both keep numbers as float64.
+func number(details map[string]interface{}, key string) float64 {
+	v, _ := details[key].(float64)
+	return v
+}
+
+func (kc *KafkaRefuelConsumer) Commit() error {
+	log := kc.log.With("module", "repository", "function", "Commit")
+	if len(kc.lastMessages) == 0 {
+		return nil
+	}
+	err := kc.reader.CommitMessages(context.Background(), kc.lastMessages...)
+	if err != nil {
+		log.Error("error committing messages", "error", err)
+		return err
+	}
+	kc.lastMessages = nil
+	return nil
+}
+
+func (kc *KafkaRefuelConsumer) Close() error {
+	log := kc.log.With("module", "repository", "function", "Close")
+	log.Info("closing kafka refuel consumer")
+	return kc.reader.Close()
+}
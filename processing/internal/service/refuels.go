@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/jekiti/citydrive/processing/internal/config"
+	"github.com/jekiti/citydrive/processing/internal/domain"
+	"github.com/jekiti/citydrive/processing/internal/repository"
+)
+
+// RefuelService stores the refuels telemetry publishes to telemetry.violations, so they
+// can be reconciled with fuel cards.
+type RefuelService struct {
+	consumer   repository.RefuelConsumer
+	repository repository.DBRepository
+	config     *config.ProcessorSpecificConfig
+	log        *slog.Logger
+}
+
+func NewRefuelService(consumer repository.RefuelConsumer, repo repository.DBRepository, config *config.ProcessorSpecificConfig, log *slog.Logger) *RefuelService {
+	return &RefuelService{
+		consumer:   consumer,
+		repository: repo,
+		config:     config,
+		log:        log,
+	}
+}
+
+func (s *RefuelService) ProcessRefuels(ctx context.Context) error {
+	log := s.log.With("module", "service", "function", "ProcessRefuels")
+	log.Info("processing refuels")
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("shutting down refuel processing")
+			return nil
+		default:
+		}
+
+		refuels, err := s.consumer.GetRefuels(ctx, s.config.BatchSize)
+		if err != nil {
+			log.Error("error getting refuels from consumer", "error", err)
+			time.Sleep(s.config.PollTimeout)
+			continue
+		}
+		for _, refuel := range refuels {
+			if !s.saveRefuel(ctx, refuel) {
+				log.Info("shutting down refuel processing")
+				return nil
+			}
+		}
+		if err := s.consumer.Commit(); err != nil {
+			log.Error("error committing refuels", "error", err)
+		}
+	}
+}
+
+// saveRefuel retries a transient failure until the refuel is stored, committing past it
+// would lose it. A refuel postgres rejects is skipped. False means ctx is done.
+func (s *RefuelService) saveRefuel(ctx context.Context, refuel domain.Refuel) bool {
+	log := s.log.With("module", "service", "function", "saveRefuel", "car_id", refuel.CarID, "refuel_id", refuel.ID)
+	for {
+		err := s.repository.SaveRefuel(refuel)
+		if err == nil {
+			return true
+		}
+		if errors.Is(err, repository.ErrRefuelRejected) {
+			log.Error("refuel rejected, skipping it", "error", err)
+			return true
+		}
+		select {
+		case <-time.After(s.config.PollTimeout):
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
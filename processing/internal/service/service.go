@@ -6,6 +6,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/jekiti/citydrive/pkg/readingorder"
 	"github.com/jekiti/citydrive/processing/internal/config"
 	"github.com/jekiti/citydrive/processing/internal/domain"
 	"github.com/jekiti/citydrive/processing/internal/repository"
@@ -57,7 +58,7 @@ func (s *ProcessingService) ProcessTelemetry(ctx context.Context) error {
 					log.Error("error getting car state from cache", "error", err)
 					continue
 				}
-				if hasDataChanged(prev, &msg) {
+				if hasDataChanged(prev, &msg) && !isOlder(&msg, prev) {
 					err := s.cache.SaveCarState(msg)
 					if err != nil {
 						log.Error("error saving car state to cache", "error", err)
@@ -108,6 +109,17 @@ func hasDataChanged(previous, current *domain.CarTelemetry) bool {
 		previous.Handbrake != current.Handbrake
 }
 
+// isOlder reports a delayed reading that arrived after a newer one, it must not replace the current state.
+func isOlder(current, previous *domain.CarTelemetry) bool {
+	if previous == nil {
+		return false
+	}
+	return readingorder.Older(
+		readingorder.Reading{Sequence: current.Sequence, RecordedAt: current.RecordedAt, ClockSkew: current.ClockSkew},
+		readingorder.Reading{Sequence: previous.Sequence, RecordedAt: previous.RecordedAt, ClockSkew: previous.ClockSkew},
+	)
+}
+
 func floatsEqual(a, b float64) bool {
 	const epsilon = 0.000001
 	return math.Abs(a-b) < epsilon
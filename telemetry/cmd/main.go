@@ -13,6 +13,7 @@ import (
 
 func main() {
 	cfg := config.LoadTelemetryConfig()
+	cfg.Validate()
 	log := slog.New(slog.NewTextHandler(os.Stdout, nil))
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -22,6 +23,7 @@ func main() {
 		log.Error("error creating app in main", "error", err)
 		os.Exit(1)
 	}
+	defer app.Close()
 	err = app.Run(ctx)
 	if err != nil {
 		log.Error("app run failed", "error", err)
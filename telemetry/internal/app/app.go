@@ -4,13 +4,18 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	telemetrypb "github.com/jekiti/citydrive/gen/proto/telemetry"
+	"github.com/jekiti/citydrive/telemetry/internal/carauth"
 	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/events"
 	"github.com/jekiti/citydrive/telemetry/internal/handler"
+	"github.com/jekiti/citydrive/telemetry/internal/mqtt"
 	"github.com/jekiti/citydrive/telemetry/internal/producer"
 	"github.com/jekiti/citydrive/telemetry/internal/repository"
 	"github.com/jekiti/citydrive/telemetry/internal/server"
 	"github.com/jekiti/citydrive/telemetry/internal/service"
+	"github.com/jekiti/citydrive/telemetry/internal/validation"
 	"google.golang.org/grpc"
 )
 
@@ -18,6 +23,14 @@ type App struct {
 	log      *slog.Logger
 	port     string
 	register func(*grpc.Server)
+	mqtt     *mqtt.Server
+	pipeline *service.IngestPipeline
+	registry *service.RegistryService
+	rules    *service.ViolationService
+	zones    *service.GeozoneService
+	relay    *service.OutboxRelay
+	producer *producer.KafkaProducer
+	db       *pgxpool.Pool
 }
 
 func NewApp(cfg *config.TelemetryConfig, log *slog.Logger, envPath string) (*App, error) {
@@ -28,32 +41,99 @@ func NewApp(cfg *config.TelemetryConfig, log *slog.Logger, envPath string) (*App
 		return nil, err
 	}
 
-	violationService := service.NewViolationService(&cfg.Violations, log)
+	db, err := repository.NewPostgres(&cfg.DB, log)
+	if err != nil {
+		log.Error("error creating postgres pool in app", "error", err)
+		return nil, err
+	}
+	cars := repository.NewCarRepository(db, log)
+	registryService := service.NewRegistryService(redis, cars, &cfg.Registry, log)
+
+	violationService := service.NewViolationService(repository.NewRuleRepository(db, log), &cfg.Violations, log)
+	if err := violationService.Load(context.Background()); err != nil {
+		db.Close()
+		log.Error("error loading violation rules in app", "error", err)
+		return nil, err
+	}
+	geozoneService := service.NewGeozoneService(repository.NewGeozoneRepository(db, log), &cfg.Geozones, log)
+	if err := geozoneService.Load(context.Background()); err != nil {
+		db.Close()
+		log.Error("error loading geozones in app", "error", err)
+		return nil, err
+	}
 	producerKafka, err := producer.NewKafkaProducer(cfg, log)
 	if err != nil {
 		log.Error("error creating producer in app", "error", err)
 		return nil, err
 	}
 
-	telemetryService := service.NewTelemetryService(redis, violationService, producerKafka, cfg, log)
-	telemetryHandler := handler.NewTelemetryHandler(telemetryService, log)
+	relay := service.NewOutboxRelay(redis, producerKafka, &cfg.Outbox, log)
+
+	telemetryService := service.NewTelemetryService(redis, validation.NewRules(&cfg.Processing), registryService, violationService, geozoneService, service.NewKinematicsService(&cfg.Kinematics, log), service.NewFuelService(&cfg.Fuel, log), events.NewEncoder(&cfg.Kafka), cfg, log)
+	pipeline := service.NewIngestPipeline(telemetryService, &cfg.Processing, log)
+	telemetryHandler := handler.NewTelemetryHandler(pipeline, cfg, log)
 	reg := func(s *grpc.Server) {
 		telemetrypb.RegisterTelemetryServiceServer(s, telemetryHandler)
 	}
+
+	var mqttServer *mqtt.Server
+	if cfg.MQTT.Enabled {
+		authenticator := carauth.NewAuthenticator(&cfg.JWT, redis)
+		mqttServer, err = mqtt.NewServer(cfg, authenticator, pipeline, log)
+		if err != nil {
+			log.Error("error creating mqtt server in app", "error", err)
+			return nil, err
+		}
+	}
 	log.Info("app initialized successfully")
 	return &App{
 		log:      log,
 		port:     cfg.GRPC.Port,
 		register: reg,
+		mqtt:     mqttServer,
+		pipeline: pipeline,
+		registry: registryService,
+		rules:    violationService,
+		zones:    geozoneService,
+		relay:    relay,
+		producer: producerKafka,
+		db:       db,
 	}, nil
 }
 
 func (a *App) Run(ctx context.Context) error {
 	log := a.log.With("function", "Run")
 	 log.Info("starting app")
+	go a.registry.Watch(ctx)
+	go a.rules.Watch(ctx)
+	go a.zones.Watch(ctx)
+
+	// the relay outlives ctx to publish what the pipeline stores while draining,
+	// anything left in the outbox is published after the next start
+	relayCtx, stopRelay := context.WithCancel(context.WithoutCancel(ctx))
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		a.relay.Run(relayCtx)
+	}()
+	defer func() {
+		stopRelay()
+		<-relayDone
+	}()
+	// deferred before the servers so it runs after they stopped feeding it
+	defer a.pipeline.Close()
+	if a.mqtt != nil {
+		if err := a.mqtt.Start(); err != nil {
+			log.Error("error starting mqtt server", "error", err)
+			return err
+		}
+		defer a.mqtt.Close()
+	}
 	return server.Run(ctx, a.log, a.port, a.register)
 }
 
 func (a *App) Close() {
+	a.producer.Close()
+	a.db.Close()
 	a.log.Info("app closed")
 }
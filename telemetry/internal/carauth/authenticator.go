@@ -0,0 +1,99 @@
+package carauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jekiti/citydrive/pkg/cartoken"
+	"github.com/jekiti/citydrive/pkg/jwks"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid car token")
+	ErrTokenExpired = errors.New("car token is expired")
+	ErrTokenRevoked = errors.New("car token is revoked")
+)
+
+// Token is an accepted car token, kept for the connection so it can be checked again.
+type Token struct {
+	CarID     string
+	ID        string // jti, empty for tokens without one
+	ExpiresAt time.Time
+}
+
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// Authenticator checks car tokens the same way RequireCarAuth does in api-gateway.
+type Authenticator struct {
+	keyfunc     jwt.Keyfunc
+	revocations RevocationChecker
+}
+
+func NewAuthenticator(cfg *config.JWTConfig, revocations RevocationChecker) *Authenticator {
+	keyfunc := hmacKeyfunc(cfg.CarSecretKey)
+	if cfg.Algorithm != "HS256" {
+		keyfunc = jwks.NewClient(cfg.JWKSURL, cfg.JWKSCacheTTL).Keyfunc
+	}
+	return &Authenticator{keyfunc: keyfunc, revocations: revocations}
+}
+
+// Authenticate parses a valid, not revoked car token.
+func (a *Authenticator) Authenticate(ctx context.Context, tokenString string) (*Token, error) {
+	token, err := jwt.Parse(tokenString, a.keyfunc)
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid claims", ErrInvalidToken)
+	}
+	now := time.Now()
+	carID, err := cartoken.CarID(claims, now)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	// CarID has checked that exp is there
+	exp, _ := claims["exp"].(float64)
+	jti, _ := claims["jti"].(string)
+	car := &Token{CarID: carID, ID: jti, ExpiresAt: time.Unix(int64(exp), 0)}
+	if err := a.Check(ctx, car, now); err != nil {
+		return nil, err
+	}
+	return car, nil
+}
+
+// Check tells whether a token accepted earlier is still good: it has not expired and
+// has not been revoked since.
+func (a *Authenticator) Check(ctx context.Context, token *Token, now time.Time) error {
+	// exp has second precision, the same comparison as in cartoken
+	if now.Unix() > token.ExpiresAt.Unix() {
+		return ErrTokenExpired
+	}
+	if token.ID == "" {
+		return nil
+	}
+	revoked, err := a.revocations.IsRevoked(ctx, token.ID)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+func hmacKeyfunc(secret string) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(secret), nil
+	}
+}
@@ -12,10 +12,33 @@ import (
 type TelemetryConfig struct {
 	GRPC       GRPCConfig
 	Redis      RedisConfig
+	DB         DBConfig
+	Registry   RegistryConfig
 	Kafka      KafkaConfig
+	Outbox     OutboxConfig
 	Violations ViolationsConfig
+	Geozones   GeozonesConfig
+	Kinematics KinematicsConfig
+	Fuel       FuelConfig
 	App        AppConfig
 	Processing ProcessingConfig
+	MQTT       MQTTConfig
+	JWT        JWTConfig
+}
+
+type MQTTConfig struct {
+	Enabled     bool
+	Port        string
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// JWTConfig описывает проверку токенов машин, те же настройки что и у api-gateway.
+type JWTConfig struct {
+	Algorithm    string
+	CarSecretKey string
+	JWKSURL      string
+	JWKSCacheTTL time.Duration
 }
 
 type GRPCConfig struct {
@@ -23,6 +46,9 @@ type GRPCConfig struct {
 	MaxConcurrentStreams uint32
 	MaxRecvMsgSize       int
 	ConnectionTimeout    time.Duration
+	StreamWindow         int
+	// StreamAggregators are the authenticated ids allowed to stream readings of other cars
+	StreamAggregators []string
 }
 
 type RedisConfig struct {
@@ -36,6 +62,16 @@ type RedisConfig struct {
 	DialTimeout  time.Duration
 }
 
+type DBConfig struct {
+	URL     string
+	MaxConn int
+}
+
+type RegistryConfig struct {
+	CacheTTL    time.Duration
+	NotFoundTTL time.Duration
+}
+
 type KafkaConfig struct {
 	Brokers         []string
 	ClientID        string
@@ -46,14 +82,60 @@ type KafkaConfig struct {
 	BatchSize       int
 	BatchTimeout    time.Duration
 	Compression     string
+	MessageFormat   string
+}
+
+type OutboxConfig struct {
+	Stream     string
+	Group      string
+	BatchSize  int
+	Block      time.Duration
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	ClaimIdle  time.Duration
 }
 
+// ViolationsConfig: the rules themselves are in citydrive.violation_rules.
 type ViolationsConfig struct {
-	SpeedLimit    int
-	SpeedMedium   int
-	SpeedHigh     int
-	DriftRPMLimit int
-	LowFuelLimit  float64
+	ReloadInterval time.Duration
+}
+
+// GeozonesConfig: the zones themselves are in citydrive.geozones.
+type GeozonesConfig struct {
+	ReloadInterval time.Duration
+	// Timezone is the one the allowed hours of zones are in.
+	Timezone          string
+	ViolationCooldown time.Duration
+}
+
+// KinematicsConfig: distances are in km, speeds in km/h.
+type KinematicsConfig struct {
+	// GPSMinDistance is the smallest jump between fixes checked, to ignore GPS jitter.
+	GPSMinDistance float64
+	// the fix may move at most SpeedTolerance times the reported speed plus SpeedMargin
+	SpeedTolerance float64
+	SpeedMargin    float64
+	OdoJumpMargin  float64
+	// the odometer has to cover OdoDriftMinRatio..OdoDriftMaxRatio of the GPS distance,
+	// compared every OdoDriftWindow of it
+	OdoDriftWindow    float64
+	OdoDriftMinRatio  float64
+	OdoDriftMaxRatio  float64
+	ViolationCooldown time.Duration
+}
+
+// FuelConfig: levels are in percent of the tank.
+type FuelConfig struct {
+	// rises up to Noise between readings are sensor noise
+	Noise         float64
+	TheftMinDrop  float64
+	RefuelMinRise float64
+	// a refuel ends when the level hasn't risen for RefuelSettle
+	RefuelSettle time.Duration
+	// consumption is compared with the model norm every ConsumptionWindow km and is
+	// reported above ConsumptionFactor times the norm
+	ConsumptionWindow float64
+	ConsumptionFactor float64
 }
 
 type AppConfig struct {
@@ -68,6 +150,12 @@ type ProcessingConfig struct {
 	QueueSize            int
 	StateChangeThreshold time.Duration
 	MaxProcessingTime    time.Duration
+	BatchMaxSize         int
+	ClockSkewTolerance   time.Duration
+	MaxReadingAge        time.Duration
+	ClockSkewAction      string
+	DedupWindow          time.Duration
+	MaxFuelRise          float64
 }
 
 func LoadTelemetryConfig() *TelemetryConfig {
@@ -95,6 +183,8 @@ func LoadTelemetryConfig() *TelemetryConfig {
 			MaxConcurrentStreams: uint32(getIntDefault("GRPC_MAX_CONCURRENT_STREAMS", 1000)),
 			MaxRecvMsgSize:       getIntDefault("GRPC_MAX_RECV_MSG_SIZE", 10485760),
 			ConnectionTimeout:    getDurationDefault("GRPC_CONNECTION_TIMEOUT", "10s"),
+			StreamWindow:         getIntDefault("GRPC_STREAM_WINDOW", 64),
+			StreamAggregators:    getSliceDefault("GRPC_STREAM_AGGREGATORS", nil),
 		},
 		Redis: RedisConfig{
 			Host:         getDefault("REDIS_HOST", "localhost"),
@@ -106,6 +196,14 @@ func LoadTelemetryConfig() *TelemetryConfig {
 			WriteTimeout: getDurationDefault("REDIS_WRITE_TIMEOUT", "3s"),
 			DialTimeout:  getDurationDefault("REDIS_DIAL_TIMEOUT", "5s"),
 		},
+		DB: DBConfig{
+			URL:     getDefault("DB_URL", ""),
+			MaxConn: getIntDefault("DB_MAX_CONN", 10),
+		},
+		Registry: RegistryConfig{
+			CacheTTL:    getDurationDefault("TELEMETRY_CAR_CACHE_TTL", "10m"),
+			NotFoundTTL: getDurationDefault("TELEMETRY_CAR_NOT_FOUND_TTL", "1m"),
+		},
 		Kafka: KafkaConfig{
 			Brokers:         getSliceDefault("KAFKA_BROKERS", []string{"localhost:9092"}),
 			ClientID:        getDefault("KAFKA_CLIENT_ID", "telemetry-ingestion"),
@@ -116,13 +214,42 @@ func LoadTelemetryConfig() *TelemetryConfig {
 			BatchSize:       getIntDefault("KAFKA_PRODUCER_BATCH_SIZE", 1000000),
 			BatchTimeout:    getDurationDefault("KAFKA_PRODUCER_BATCH_TIMEOUT", "500ms"),
 			Compression:     getDefault("KAFKA_PRODUCER_COMPRESSION", "lz4"),
+			MessageFormat:   getDefault("KAFKA_MESSAGE_FORMAT", "protobuf"),
+		},
+		Outbox: OutboxConfig{
+			Stream:     getDefault("OUTBOX_STREAM", "telemetry:outbox"),
+			Group:      getDefault("OUTBOX_GROUP", "telemetry-relay"),
+			BatchSize:  getIntDefault("OUTBOX_BATCH_SIZE", 100),
+			Block:      getDurationDefault("OUTBOX_BLOCK", "1s"),
+			MinBackoff: getDurationDefault("OUTBOX_RETRY_MIN_BACKOFF", "500ms"),
+			MaxBackoff: getDurationDefault("OUTBOX_RETRY_MAX_BACKOFF", "30s"),
+			ClaimIdle:  getDurationDefault("OUTBOX_CLAIM_IDLE", "1m"),
 		},
 		Violations: ViolationsConfig{
-			SpeedLimit:    getIntDefault("VIOLATION_SPEED_LIMIT", 110),
-			SpeedMedium:   getIntDefault("VIOLATION_SPEED_MEDIUM", 130),
-			SpeedHigh:     getIntDefault("VIOLATION_SPEED_HIGH", 150),
-			DriftRPMLimit: getIntDefault("VIOLATION_DRIFT_RPM_LIMIT", 5000),
-			LowFuelLimit:  getFloatDefault("VIOLATION_LOW_FUEL_LIMIT", 2.0),
+			ReloadInterval: getDurationDefault("VIOLATION_RULES_RELOAD_INTERVAL", "1m"),
+		},
+		Geozones: GeozonesConfig{
+			ReloadInterval:    getDurationDefault("GEOZONES_RELOAD_INTERVAL", "1m"),
+			Timezone:          getDefault("TELEMETRY_ZONE_TIMEZONE", "Europe/Moscow"),
+			ViolationCooldown: getDurationDefault("GEOZONE_VIOLATION_COOLDOWN", "30s"),
+		},
+		Kinematics: KinematicsConfig{
+			GPSMinDistance:    getFloatDefault("TELEMETRY_GPS_MIN_DISTANCE_KM", 0.5),
+			SpeedTolerance:    getFloatDefault("TELEMETRY_GPS_SPEED_TOLERANCE", 1.5),
+			SpeedMargin:       getFloatDefault("TELEMETRY_GPS_SPEED_MARGIN", 30),
+			OdoJumpMargin:     getFloatDefault("TELEMETRY_ODO_JUMP_MARGIN_KM", 2),
+			OdoDriftWindow:    getFloatDefault("TELEMETRY_ODO_DRIFT_WINDOW_KM", 20),
+			OdoDriftMinRatio:  getFloatDefault("TELEMETRY_ODO_DRIFT_MIN_RATIO", 0.8),
+			OdoDriftMaxRatio:  getFloatDefault("TELEMETRY_ODO_DRIFT_MAX_RATIO", 1.6),
+			ViolationCooldown: getDurationDefault("TELEMETRY_KINEMATICS_VIOLATION_COOLDOWN", "5m"),
+		},
+		Fuel: FuelConfig{
+			Noise:             getFloatDefault("TELEMETRY_FUEL_NOISE", 0.5),
+			TheftMinDrop:      getFloatDefault("TELEMETRY_FUEL_THEFT_MIN_DROP", 5),
+			RefuelMinRise:     getFloatDefault("TELEMETRY_REFUEL_MIN_RISE", 5),
+			RefuelSettle:      getDurationDefault("TELEMETRY_REFUEL_SETTLE", "3m"),
+			ConsumptionWindow: getFloatDefault("TELEMETRY_FUEL_CONSUMPTION_WINDOW_KM", 100),
+			ConsumptionFactor: getFloatDefault("TELEMETRY_FUEL_CONSUMPTION_FACTOR", 1.5),
 		},
 		App: AppConfig{
 			Env:         getDefault("ENV", "development"),
@@ -135,6 +262,24 @@ func LoadTelemetryConfig() *TelemetryConfig {
 			QueueSize:            getIntDefault("TELEMETRY_QUEUE_SIZE", 1000),
 			StateChangeThreshold: getDurationDefault("STATE_CHANGE_THRESHOLD_MS", "5s"),
 			MaxProcessingTime:    getDurationDefault("MAX_PROCESSING_TIME", "30s"),
+			BatchMaxSize:         getIntDefault("TELEMETRY_BATCH_MAX_SIZE", 500),
+			ClockSkewTolerance:   getDurationDefault("TELEMETRY_CLOCK_SKEW_TOLERANCE", "2m"),
+			MaxReadingAge:        getDurationDefault("TELEMETRY_MAX_READING_AGE", "72h"),
+			ClockSkewAction:      getDefault("TELEMETRY_CLOCK_SKEW_ACTION", "flag"),
+			DedupWindow:          getDurationDefault("TELEMETRY_DEDUP_WINDOW", "10m"),
+			MaxFuelRise:          getFloatDefault("TELEMETRY_MAX_FUEL_RISE", 30),
+		},
+		MQTT: MQTTConfig{
+			Enabled:     getBoolDefault("MQTT_ENABLED", false),
+			Port:        getDefault("MQTT_PORT", "1883"),
+			TLSCertFile: getDefault("MQTT_TLS_CERT_FILE", ""),
+			TLSKeyFile:  getDefault("MQTT_TLS_KEY_FILE", ""),
+		},
+		JWT: JWTConfig{
+			Algorithm:    getDefault("JWT_ALG", "HS256"),
+			CarSecretKey: getDefault("JWT_CAR_SECRET_KEY", ""),
+			JWKSURL:      getDefault("AUTH_JWKS_URL", ""),
+			JWKSCacheTTL: getDurationDefault("JWKS_CACHE_TTL", "5m"),
 		},
 	}
 }
@@ -146,12 +291,59 @@ func (c *TelemetryConfig) Validate() error {
 	if c.Redis.Host == "" {
 		log.Fatal("REDIS_HOST is required")
 	}
+	if c.DB.URL == "" {
+		log.Fatal("DB_URL is required")
+	}
 
-	if c.Violations.SpeedLimit <= 0 {
-		log.Fatal("VIOLATION_SPEED_LIMIT must be positive")
+	if c.Kafka.MessageFormat != "protobuf" && c.Kafka.MessageFormat != "json" {
+		log.Fatal("KAFKA_MESSAGE_FORMAT must be protobuf or json")
 	}
-	if c.Violations.LowFuelLimit < 0 || c.Violations.LowFuelLimit > 100 {
-		log.Fatal("VIOLATION_LOW_FUEL_LIMIT must be between 0 and 100")
+
+	if c.Outbox.BatchSize <= 0 {
+		log.Fatal("OUTBOX_BATCH_SIZE must be positive")
+	}
+
+	if c.Violations.ReloadInterval <= 0 {
+		log.Fatal("VIOLATION_RULES_RELOAD_INTERVAL must be positive")
+	}
+	if c.Geozones.ReloadInterval <= 0 {
+		log.Fatal("GEOZONES_RELOAD_INTERVAL must be positive")
+	}
+	if _, err := time.LoadLocation(c.Geozones.Timezone); err != nil {
+		log.Fatalf("invalid TELEMETRY_ZONE_TIMEZONE: %v", err)
+	}
+	if c.Kinematics.OdoDriftWindow <= 0 {
+		log.Fatal("TELEMETRY_ODO_DRIFT_WINDOW_KM must be positive")
+	}
+	if c.Kinematics.OdoDriftMinRatio >= c.Kinematics.OdoDriftMaxRatio {
+		log.Fatal("TELEMETRY_ODO_DRIFT_MIN_RATIO must be less than TELEMETRY_ODO_DRIFT_MAX_RATIO")
+	}
+	if c.Fuel.ConsumptionWindow <= 0 {
+		log.Fatal("TELEMETRY_FUEL_CONSUMPTION_WINDOW_KM must be positive")
+	}
+	if c.Processing.WorkerPoolSize <= 0 || c.Processing.QueueSize <= 0 {
+		log.Fatal("TELEMETRY_WORKER_POOL_SIZE and TELEMETRY_QUEUE_SIZE must be positive")
+	}
+	if c.Processing.ClockSkewAction != "flag" && c.Processing.ClockSkewAction != "reject" {
+		log.Fatal("TELEMETRY_CLOCK_SKEW_ACTION must be flag or reject")
+	}
+
+	if c.MQTT.Enabled {
+		switch c.JWT.Algorithm {
+		case "HS256":
+			if c.JWT.CarSecretKey == "" {
+				log.Fatal("JWT_CAR_SECRET_KEY is required for MQTT")
+			}
+		case "RS256", "EdDSA":
+			if c.JWT.JWKSURL == "" {
+				log.Fatalf("AUTH_JWKS_URL is required for %s", c.JWT.Algorithm)
+			}
+		default:
+			log.Fatalf("unsupported JWT_ALG: %s", c.JWT.Algorithm)
+		}
+		if (c.MQTT.TLSCertFile == "") != (c.MQTT.TLSKeyFile == "") {
+			log.Fatal("MQTT_TLS_CERT_FILE and MQTT_TLS_KEY_FILE must be set together")
+		}
 	}
 
 	return nil
@@ -231,3 +423,15 @@ func getDurationDefault(key, def string) time.Duration {
 	}
 	return d
 }
+
+func getBoolDefault(key string, def bool) bool {
+	s := os.Getenv(key)
+	if s == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		log.Fatalf("error parsing bool from env %s: %v", key, err)
+	}
+	return b
+}
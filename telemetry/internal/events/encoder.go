@@ -0,0 +1,150 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	eventspb "github.com/jekiti/citydrive/gen/proto/events"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	SchemaVersion = 1
+
+	HeaderContentType   = "content-type"
+	HeaderSchemaVersion = "schema-version"
+
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeJSON     = "application/json"
+)
+
+// Encoder builds Kafka message values in the format set by KAFKA_MESSAGE_FORMAT.
+// JSON is the format consumers read before proto/events and is kept for the migration.
+type Encoder struct {
+	json bool
+}
+
+func NewEncoder(cfg *config.KafkaConfig) *Encoder {
+	return &Encoder{json: cfg.MessageFormat == "json"}
+}
+
+// Telemetry returns the value of a telemetry.raw message and its content type.
+func (e *Encoder) Telemetry(carID, traceID string, data *models.TelemetryData, producedAt time.Time) ([]byte, string, error) {
+	if e.json {
+		value, err := json.Marshal(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed marshal telemetry:%w", err)
+		}
+		return value, ContentTypeJSON, nil
+	}
+	value, err := proto.Marshal(&eventspb.TelemetryEvent{
+		Envelope:  envelope(carID, traceID, data, producedAt),
+		Telemetry: toTelemetry(data),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed marshal telemetry event:%w", err)
+	}
+	return value, ContentTypeProtobuf, nil
+}
+
+// Violation returns the value of a telemetry.violations message and its content type.
+func (e *Encoder) Violation(traceID string, violation *models.Violation, producedAt time.Time) ([]byte, string, error) {
+	if e.json {
+		value, err := json.Marshal(violation)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed marshal violation:%w", err)
+		}
+		return value, ContentTypeJSON, nil
+	}
+	details, err := toStruct(violation.Details)
+	if err != nil {
+		return nil, "", err
+	}
+	value, err := proto.Marshal(&eventspb.ViolationEvent{
+		Envelope:  envelope(violation.CarID, traceID, &violation.Data, producedAt),
+		Type:      violation.Type,
+		Telemetry: toTelemetry(&violation.Data),
+		Details:   details,
+		Severity:  violation.Severity,
+		RuleId:    violation.RuleID,
+		Episode:   toEpisode(violation.Episode),
+		Subject:   violation.Subject,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed marshal violation event:%w", err)
+	}
+	return value, ContentTypeProtobuf, nil
+}
+
+func envelope(carID, traceID string, data *models.TelemetryData, producedAt time.Time) *eventspb.Envelope {
+	return &eventspb.Envelope{
+		SchemaVersion: SchemaVersion,
+		TraceId:       traceID,
+		CarId:         carID,
+		RecordedAt:    data.RecordedAt,
+		ProducedAt:    producedAt.UnixMilli(),
+	}
+}
+
+func toTelemetry(data *models.TelemetryData) *eventspb.Telemetry {
+	return &eventspb.Telemetry{
+		Brand:             data.Brand,
+		Model:             data.Model,
+		YearOfManufacture: data.YearOfManufacture,
+		Odo:               data.Odo,
+		Lat:               data.Lat,
+		Lon:               data.Lon,
+		Fuel:              data.Fuel,
+		FuelType:          data.FuelType,
+		Speed:             data.Speed,
+		EngineOn:          data.EngineOn,
+		Locked:            data.Locked,
+		Activated:         data.Activated,
+		Rpm:               data.RPM,
+		Handbrake:         data.Handbrake,
+		Sequence:          data.Sequence,
+		ClockSkew:         data.ClockSkew,
+		IdempotencyKey:    data.IdempotencyKey,
+	}
+}
+
+func toEpisode(episode *models.ViolationEpisode) *eventspb.ViolationEpisode {
+	if episode == nil {
+		return nil
+	}
+	var duration int64
+	if episode.EndedAt > 0 {
+		duration = episode.EndedAt - episode.StartedAt
+	}
+	return &eventspb.ViolationEpisode{
+		Id:         episode.ID,
+		Phase:      episode.Phase,
+		StartedAt:  episode.StartedAt,
+		EndedAt:    episode.EndedAt,
+		DurationMs: duration,
+		PeakSpeed:  episode.PeakSpeed,
+		PeakRpm:    episode.PeakRPM,
+		MinFuel:    episode.MinFuel,
+		Readings:   uint32(episode.Readings),
+	}
+}
+
+// toStruct goes through JSON since details hold values structpb can't take directly, like map[string]string.
+func toStruct(details map[string]interface{}) (*structpb.Struct, error) {
+	if len(details) == 0 {
+		return nil, nil
+	}
+	jsonData, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshal violation details:%w", err)
+	}
+	var s structpb.Struct
+	if err := s.UnmarshalJSON(jsonData); err != nil {
+		return nil, fmt.Errorf("failed convert violation details:%w", err)
+	}
+	return &s, nil
+}
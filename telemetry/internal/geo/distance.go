@@ -0,0 +1,18 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+const earthRadiusKm = 6371.0
+
+// Distance is the great-circle distance between two points in km.
+func Distance(a, b models.Point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Lon - a.Lon) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
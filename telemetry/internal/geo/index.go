@@ -0,0 +1,113 @@
+// Package geo finds the geozones a point lies in.
+package geo
+
+import (
+	"math"
+
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+const (
+	// cellSize is the side of a grid cell in degrees, about 5 km at Moscow latitude.
+	cellSize = 0.05
+	// zones covering more cells than this, like a whole operating area,
+	// are checked for every point instead of being put into the grid
+	maxZoneCells = 4096
+)
+
+type cell struct {
+	x, y int
+}
+
+type zone struct {
+	models.Geozone
+	minLat, maxLat, minLon, maxLon float64
+}
+
+// Index is a uniform grid over zone bounding boxes, it is immutable once built.
+type Index struct {
+	cells          map[cell][]*zone
+	large          []*zone
+	operatingAreas int
+}
+
+func NewIndex(zones []models.Geozone) *Index {
+	idx := &Index{cells: map[cell][]*zone{}}
+	for _, gz := range zones {
+		if len(gz.Polygon) < 3 {
+			continue
+		}
+		z := &zone{Geozone: gz, minLat: 90, maxLat: -90, minLon: 180, maxLon: -180}
+		for _, p := range gz.Polygon {
+			z.minLat = math.Min(z.minLat, p.Lat)
+			z.maxLat = math.Max(z.maxLat, p.Lat)
+			z.minLon = math.Min(z.minLon, p.Lon)
+			z.maxLon = math.Max(z.maxLon, p.Lon)
+		}
+		if gz.OperatingArea {
+			idx.operatingAreas++
+		}
+
+		from, to := cellOf(z.minLat, z.minLon), cellOf(z.maxLat, z.maxLon)
+		if (to.x-from.x+1)*(to.y-from.y+1) > maxZoneCells {
+			idx.large = append(idx.large, z)
+			continue
+		}
+		for x := from.x; x <= to.x; x++ {
+			for y := from.y; y <= to.y; y++ {
+				c := cell{x, y}
+				idx.cells[c] = append(idx.cells[c], z)
+			}
+		}
+	}
+	return idx
+}
+
+// Lookup returns the zones containing the point.
+func (idx *Index) Lookup(lat, lon float64) []*models.Geozone {
+	var found []*models.Geozone
+	for _, candidates := range [][]*zone{idx.cells[cellOf(lat, lon)], idx.large} {
+		for _, z := range candidates {
+			if z.contains(lat, lon) {
+				found = append(found, &z.Geozone)
+			}
+		}
+	}
+	return found
+}
+
+// HasOperatingArea tells whether cars are limited to operating areas at all.
+func (idx *Index) HasOperatingArea() bool {
+	return idx.operatingAreas > 0
+}
+
+func (idx *Index) Len() int {
+	seen := map[*zone]bool{}
+	for _, zones := range idx.cells {
+		for _, z := range zones {
+			seen[z] = true
+		}
+	}
+	return len(seen) + len(idx.large)
+}
+
+// contains is the even-odd ray casting test with lon as x and lat as y.
+func (z *zone) contains(lat, lon float64) bool {
+	if lat < z.minLat || lat > z.maxLat || lon < z.minLon || lon > z.maxLon {
+		return false
+	}
+	inside := false
+	points := z.Polygon
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		a, b := points[i], points[j]
+		if (a.Lat > lat) != (b.Lat > lat) &&
+			lon < (b.Lon-a.Lon)*(lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func cellOf(lat, lon float64) cell {
+	return cell{x: int(math.Floor(lon / cellSize)), y: int(math.Floor(lat / cellSize))}
+}
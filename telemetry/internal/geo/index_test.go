@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+// rect is a rectangle polygon, corners given as lat/lon.
+func rect(id string, minLat, minLon, maxLat, maxLon float64) models.Geozone {
+	return models.Geozone{ID: id, Polygon: []models.Point{
+		{Lat: minLat, Lon: minLon},
+		{Lat: minLat, Lon: maxLon},
+		{Lat: maxLat, Lon: maxLon},
+		{Lat: maxLat, Lon: minLon},
+	}}
+}
+
+func lookupIDs(idx *Index, lat, lon float64) []string {
+	var ids []string
+	for _, z := range idx.Lookup(lat, lon) {
+		ids = append(ids, z.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestLookup(t *testing.T) {
+	area := rect("area", 50, 30, 60, 45)
+	area.OperatingArea = true
+	// an L around the notch 55.76..55.78 x 37.62..37.64
+	lShape := models.Geozone{ID: "l", Polygon: []models.Point{
+		{Lat: 55.74, Lon: 37.60},
+		{Lat: 55.74, Lon: 37.64},
+		{Lat: 55.76, Lon: 37.64},
+		{Lat: 55.76, Lon: 37.62},
+		{Lat: 55.78, Lon: 37.62},
+		{Lat: 55.78, Lon: 37.60},
+	}}
+	idx := NewIndex([]models.Geozone{
+		area,
+		lShape,
+		// west and east share the edge lon 37.70
+		rect("west", 55.70, 37.65, 55.72, 37.70),
+		rect("east", 55.70, 37.70, 55.72, 37.75),
+		// crosses several grid cells
+		rect("wide", 55.80, 37.45, 55.81, 37.80),
+		{ID: "degenerate", Polygon: []models.Point{{Lat: 55.7, Lon: 37.6}, {Lat: 55.8, Lon: 37.7}}},
+	})
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		ids      []string
+	}{
+		{"inside the L", 55.75, 37.61, []string{"area", "l"}},
+		{"in the notch of the L", 55.77, 37.63, []string{"area"}},
+		{"on a vertex of the L", 55.74, 37.60, []string{"area", "l"}},
+		{"inside the west zone", 55.71, 37.67, []string{"area", "west"}},
+		{"on the shared edge", 55.71, 37.70, []string{"area", "east"}},
+		{"wide zone from its first cell", 55.805, 37.46, []string{"area", "wide"}},
+		{"wide zone from its last cell", 55.805, 37.79, []string{"area", "wide"}},
+		{"far from small zones", 51, 31, []string{"area"}},
+		{"outside the operating area", 61, 37.6, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lookupIDs(idx, tt.lat, tt.lon)
+			if len(got) != len(tt.ids) {
+				t.Fatalf("Lookup = %v, want %v", got, tt.ids)
+			}
+			for i := range got {
+				if got[i] != tt.ids[i] {
+					t.Fatalf("Lookup = %v, want %v", got, tt.ids)
+				}
+			}
+		})
+	}
+
+	if len(idx.large) != 1 || idx.large[0].ID != "area" {
+		t.Fatal("operating area must be kept out of the grid")
+	}
+	if !idx.HasOperatingArea() {
+		t.Fatal("HasOperatingArea = false with an operating area")
+	}
+	if n := idx.Len(); n != 5 {
+		t.Fatalf("Len = %d, want 5 without the degenerate zone", n)
+	}
+}
+
+func TestSharedEdgeBelongsToOneZone(t *testing.T) {
+	// a point on the border of two neighbouring zones must not be in both or in neither
+	idx := NewIndex([]models.Geozone{
+		rect("south", 55.70, 37.60, 55.72, 37.65),
+		rect("north", 55.72, 37.60, 55.74, 37.65),
+		rect("east", 55.70, 37.65, 55.74, 37.70),
+	})
+	points := []models.Point{
+		{Lat: 55.72, Lon: 37.62},
+		{Lat: 55.71, Lon: 37.65},
+		{Lat: 55.73, Lon: 37.65},
+	}
+	for _, p := range points {
+		if got := lookupIDs(idx, p.Lat, p.Lon); len(got) != 1 {
+			t.Fatalf("point %+v is in %v, want exactly one zone", p, got)
+		}
+	}
+	if idx.HasOperatingArea() {
+		t.Fatal("HasOperatingArea = true without operating areas")
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	telemetrypb "github.com/jekiti/citydrive/gen/proto/telemetry"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *TelemetryHandler) PutTelemetryBatch(ctx context.Context, req *telemetrypb.PutBatchRequest) (*telemetrypb.PutBatchResponse, error) {
+	carID, traceID := fromMetadata(ctx)
+	if carID == "" {
+		return nil, status.Error(codes.Unauthenticated, "car_id required in metadata")
+	}
+	log := h.log.With(
+		"module", "handler",
+		"function", "PutTelemetryBatch",
+		"car_id", carID,
+		"trace_id", traceID,
+	)
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "items are required")
+	}
+	if len(req.Items) > h.config.Processing.BatchMaxSize {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("batch is limited to %d items", h.config.Processing.BatchMaxSize))
+	}
+	log.Info("processing telemetry batch", "items", len(req.Items))
+
+	ctx = context.WithValue(ctx, "trace_id", traceID)
+	resp := &telemetrypb.PutBatchResponse{Results: make([]*telemetrypb.BatchItemResult, len(req.Items))}
+	failed := false
+	for i, item := range req.Items {
+		result := &telemetrypb.BatchItemResult{Index: int32(i)}
+		resp.Results[i] = result
+		// once an item fails the rest is left for a retry, so the car resends them in the original order
+		if failed {
+			result.Status = telemetrypb.BatchItemStatus_BATCH_ITEM_STATUS_RETRY
+			result.Error = "not processed"
+			continue
+		}
+
+		err := h.pipeline.ProcessTelemetry(ctx, carID, toTelemetryData(item))
+		if errors.Is(err, models.ErrDuplicateTelemetry) {
+			err = nil
+		}
+		if isRejected(err) {
+			log.Warn("telemetry item rejected", "index", i, "error", err)
+			result.Status = telemetrypb.BatchItemStatus_BATCH_ITEM_STATUS_REJECTED
+			result.Error = err.Error()
+			continue
+		}
+		if err != nil {
+			log.Error("telemetry processing failed", "index", i, "error", err)
+			failed = true
+			result.Status = telemetrypb.BatchItemStatus_BATCH_ITEM_STATUS_RETRY
+			result.Error = retryReason(err)
+			continue
+		}
+		result.Status = telemetrypb.BatchItemStatus_BATCH_ITEM_STATUS_ACCEPTED
+		resp.Accepted++
+	}
+
+	log.Info("telemetry batch processed", "accepted", resp.Accepted, "items", len(req.Items))
+	return resp, nil
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+
+	telemetrypb "github.com/jekiti/citydrive/gen/proto/telemetry"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func (h *TelemetryHandler) StreamTelemetry(stream telemetrypb.TelemetryService_StreamTelemetryServer) error {
+	ctx := stream.Context()
+	streamCarID, traceID := fromMetadata(ctx)
+	log := h.log.With(
+		"module", "handler",
+		"function", "StreamTelemetry",
+		"car_id", streamCarID,
+		"trace_id", traceID,
+	)
+	// car_id in metadata is the authenticated sender, only aggregators speak for other cars
+	aggregator := streamCarID != "" && slices.Contains(h.config.GRPC.StreamAggregators, streamCarID)
+	log.Info("telemetry stream opened", "aggregator", aggregator)
+
+	// the reader stops pulling from the stream once the window is full,
+	// so a fast sender is slowed down by gRPC flow control instead of piling up here
+	window := h.config.GRPC.StreamWindow
+	if window <= 0 {
+		window = 1
+	}
+	queue := make(chan *telemetrypb.StreamTelemetryRequest, window)
+	recvErrCh := make(chan error, 1)
+	go func() {
+		defer close(queue)
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				recvErrCh <- err
+				return
+			}
+			select {
+			case queue <- req:
+			case <-ctx.Done():
+				recvErrCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	var processed, rejected int
+	for req := range queue {
+		ack := h.processStreamMessage(ctx, streamCarID, aggregator, traceID, req)
+		if ack.Accepted {
+			processed++
+		} else {
+			rejected++
+		}
+		if err := stream.Send(ack); err != nil {
+			log.Error("error sending ack", "error", err)
+			return err
+		}
+	}
+
+	if err := <-recvErrCh; err != nil {
+		log.Warn("telemetry stream aborted", "error", err, "processed", processed, "rejected", rejected)
+		return status.FromContextError(err).Err()
+	}
+	log.Info("telemetry stream closed", "processed", processed, "rejected", rejected)
+	return nil
+}
+
+func (h *TelemetryHandler) processStreamMessage(ctx context.Context, streamCarID string, aggregator bool, traceID string, req *telemetrypb.StreamTelemetryRequest) *telemetrypb.TelemetryAck {
+	ack := &telemetrypb.TelemetryAck{MessageId: req.MessageId}
+	if streamCarID == "" {
+		ack.Error = "car_id required in metadata"
+		return ack
+	}
+	carID := streamCarID
+	if req.CarId != "" && req.CarId != streamCarID {
+		if !aggregator {
+			h.log.Warn("message for another car rejected",
+				"module", "handler",
+				"function", "StreamTelemetry",
+				"car_id", streamCarID,
+				"message_car_id", req.CarId,
+				"trace_id", traceID,
+				"message_id", req.MessageId)
+			ack.Error = "car_id does not match the authenticated car"
+			return ack
+		}
+		carID = req.CarId
+	}
+	if req.Telemetry == nil {
+		ack.Error = "telemetry is required"
+		return ack
+	}
+
+	ctx = context.WithValue(ctx, "trace_id", traceID)
+
+	err := h.pipeline.ProcessTelemetry(ctx, carID, toTelemetryData(req.Telemetry))
+	if errors.Is(err, models.ErrDuplicateTelemetry) {
+		ack.Accepted = true
+		return ack
+	}
+	if isRejected(err) {
+		ack.Error = err.Error()
+		return ack
+	}
+	if err != nil {
+		h.log.Error("telemetry processing failed",
+			"module", "handler",
+			"function", "StreamTelemetry",
+			"car_id", carID,
+			"trace_id", traceID,
+			"message_id", req.MessageId,
+			"error", err)
+		ack.Error = retryReason(err)
+		return ack
+	}
+	ack.Accepted = true
+	return ack
+}
+
+func fromMetadata(ctx context.Context) (carID, traceID string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	if carIDs := md.Get("car_id"); len(carIDs) > 0 {
+		carID = carIDs[0]
+	}
+	if traceIDs := md.Get("trace_id"); len(traceIDs) > 0 {
+		traceID = traceIDs[0]
+	}
+	return carID, traceID
+}
@@ -2,11 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	telemetrypb "github.com/jekiti/citydrive/gen/proto/telemetry"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
 	"github.com/jekiti/citydrive/telemetry/internal/models"
 	"github.com/jekiti/citydrive/telemetry/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -14,12 +17,13 @@ import (
 
 type TelemetryHandler struct {
 	telemetrypb.UnimplementedTelemetryServiceServer
-	telemetryService *service.TelemetryService
-	log              *slog.Logger
+	pipeline *service.IngestPipeline
+	config   *config.TelemetryConfig
+	log      *slog.Logger
 }
 
-func NewTelemetryHandler(telemetryService *service.TelemetryService, log *slog.Logger) *TelemetryHandler {
-	return &TelemetryHandler{telemetryService: telemetryService, log: log}
+func NewTelemetryHandler(pipeline *service.IngestPipeline, config *config.TelemetryConfig, log *slog.Logger) *TelemetryHandler {
+	return &TelemetryHandler{pipeline: pipeline, config: config, log: log}
 }
 
 func (h *TelemetryHandler) PutTelemetry(ctx context.Context, req *telemetrypb.PutRequest) (*telemetrypb.PutResponse, error) {
@@ -48,7 +52,73 @@ func (h *TelemetryHandler) PutTelemetry(ctx context.Context, req *telemetrypb.Pu
 		"trace_id", traceID,
 	)
 	log.Info("processing telemetry request")
-	data := &models.TelemetryData{
+	data := toTelemetryData(req)
+
+	err := h.pipeline.ProcessTelemetry(ctxNew, carID, data)
+	if errors.Is(err, models.ErrDuplicateTelemetry) {
+		return &telemetrypb.PutResponse{Message: "telemetry already accepted", Duplicate: true}, nil
+	}
+	if err != nil {
+		log.Error("telemetry processing failed", "error", err)
+		return nil, telemetryError(err)
+	}
+	log.Info("telemetry processed successfully")
+	return &telemetrypb.PutResponse{Message: "telemetry processed successfully"}, nil
+}
+
+func telemetryError(err error) error {
+	switch {
+	case errors.Is(err, models.ErrInvalidTelemetry):
+		st := status.New(codes.InvalidArgument, err.Error())
+		var invalid *models.ValidationError
+		if errors.As(err, &invalid) {
+			badRequest := &errdetails.BadRequest{}
+			for _, v := range invalid.Violations {
+				badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+					Field:       v.Field,
+					Description: v.Description,
+				})
+			}
+			if detailed, detailErr := st.WithDetails(badRequest); detailErr == nil {
+				st = detailed
+			}
+		}
+		return st.Err()
+	case errors.Is(err, models.ErrClockSkew):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrCarNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrCarDecommissioned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, models.ErrQueueFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, models.ErrShuttingDown):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "telemetry processing timed out")
+	default:
+		return status.Error(codes.Internal, "can't put telemetry")
+	}
+}
+
+// isRejected reports errors caused by the reading itself, resending it won't help.
+func isRejected(err error) bool {
+	return errors.Is(err, models.ErrInvalidTelemetry) ||
+		errors.Is(err, models.ErrClockSkew) ||
+		errors.Is(err, models.ErrCarNotFound) ||
+		errors.Is(err, models.ErrCarDecommissioned)
+}
+
+// retryReason tells a sender that may resend why the reading wasn't taken, without leaking internal errors.
+func retryReason(err error) string {
+	if errors.Is(err, models.ErrQueueFull) || errors.Is(err, models.ErrShuttingDown) {
+		return err.Error()
+	}
+	return "can't put telemetry"
+}
+
+func toTelemetryData(req *telemetrypb.PutRequest) *models.TelemetryData {
+	return &models.TelemetryData{
 		Brand:             req.Brand,
 		Model:             req.Model,
 		YearOfManufacture: req.YearOfManufacture,
@@ -63,13 +133,8 @@ func (h *TelemetryHandler) PutTelemetry(ctx context.Context, req *telemetrypb.Pu
 		Activated:         req.Activated,
 		RPM:               req.Rpm,
 		Handbrake:         req.Handbrake,
+		RecordedAt:        req.RecordedAt,
+		Sequence:          req.Sequence,
+		IdempotencyKey:    req.IdempotencyKey,
 	}
-
-	err := h.telemetryService.ProcessTelemetry(ctxNew, carID, data)
-	if err != nil {
-		log.Error("telemetry processing failed", "error", err)
-		return nil, status.Error(codes.Internal, "can't put telemetry")
-	}
-	log.Info("telemetry processed successfully")
-	return &telemetrypb.PutResponse{Message: "telemetry processed successfully"}, nil
 }
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrClockSkew          = errors.New("device clock skew is beyond tolerance")
+	ErrDuplicateTelemetry = errors.New("telemetry already accepted")
+	ErrInvalidTelemetry   = errors.New("invalid telemetry")
+	ErrCarNotFound        = errors.New("car is not registered")
+	ErrCarDecommissioned  = errors.New("car is decommissioned")
+	ErrQueueFull          = errors.New("telemetry queue is full")
+	ErrShuttingDown       = errors.New("telemetry service is shutting down")
+)
+
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError lists every rule a reading broke, handlers turn it into gRPC BadRequest details.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Description
+	}
+	return ErrInvalidTelemetry.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidTelemetry
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type TelemetryData struct {
 	Brand             string  `json:"brand"`
 	Model             string  `json:"model"`
@@ -15,20 +17,151 @@ type TelemetryData struct {
 	Activated         bool    `json:"activated"`
 	RPM               int32   `json:"rpm"`
 	Handbrake         bool    `json:"handbrake"`
+	RecordedAt        int64   `json:"recorded_at,omitempty"`
+	Sequence          uint64  `json:"sequence,omitempty"`
+	ClockSkew         bool    `json:"clock_skew,omitempty"`
+	IdempotencyKey    string  `json:"idempotency_key,omitempty"`
+}
+
+// Car is the part of citydrive.cars telemetry checks readings against.
+type Car struct {
+	ID             string `json:"id"`
+	Brand          string `json:"brand"`
+	Model          string `json:"model"`
+	FuelType       string `json:"fuel_type"`
+	Decommissioned bool   `json:"decommissioned"`
+	// from citydrive.fuel_norms, zero when the model has no norm
+	TankLiters      float64 `json:"tank_liters,omitempty"`
+	ConsumptionNorm float64 `json:"consumption_l_100km,omitempty"`
+}
+
+const (
+	EventTopicTelemetry  = "telemetry"
+	EventTopicViolations = "violations"
+)
+
+// OutboxEvent is a Kafka message stored in the outbox until the relay publishes it.
+type OutboxEvent struct {
+	ID          string
+	Topic       string
+	Key         string
+	Value       []byte
+	ContentType string
+	TraceID     string
 }
 
 type Violation struct {
-	Type    string
-	CarID   string
-	Data    TelemetryData
-	Details map[string]interface{} // {speed: 120, limit: 110}
+	Type     string
+	CarID    string
+	Subject  string // what the violation is about when a car can have several at once, e.g. a zone id
+	Severity string
+	RuleID   string // empty for violations not defined by a rule
+	Data     TelemetryData
+	Details  map[string]interface{} // {speed: 120, limit: 110}
+	Episode  *ViolationEpisode
+	// Cooldown is how long the violation may be absent before its episode is closed.
+	Cooldown time.Duration `json:"-"`
 }
 
 const (
-	ViolationTypeSpeedingLow    = "speeding_low"
-	ViolationTypeSpeedingMedium = "speeding_medium"
-	ViolationTypeSpeedingHigh   = "speeding_high"
-	ViolationTypeLowFuel        = "low_fuel"
-	ViolationTypeDrift          = "drift"
-	ViolationTypeStealedAuto    = "stealed_auto"
+	EpisodePhaseStart = "start"
+	EpisodePhaseEnd   = "end"
 )
+
+// ViolationEpisode is a violation lasting over consecutive readings of a car, only its
+// start and end are published. Times are unix ms of the readings.
+type ViolationEpisode struct {
+	ID         string        `json:"id"`
+	Type       string        `json:"type"`
+	Subject    string        `json:"subject,omitempty"`
+	RuleID     string        `json:"rule_id,omitempty"`
+	Severity   string        `json:"severity"`
+	Phase      string        `json:"phase,omitempty"`
+	StartedAt  int64         `json:"started_at"`
+	LastSeenAt int64         `json:"last_seen_at"`
+	EndedAt    int64         `json:"ended_at,omitempty"`
+	PeakSpeed  int32         `json:"peak_speed"`
+	PeakRPM    int32         `json:"peak_rpm"`
+	MinFuel    float64       `json:"min_fuel"`
+	Readings   int           `json:"readings"`
+	Cooldown   time.Duration `json:"cooldown"`
+}
+
+// ViolationState is what violation checks keep per car between readings.
+type ViolationState struct {
+	// Episodes are the open episodes by violation type, and zone for zone violations.
+	Episodes map[string]*ViolationEpisode `json:"episodes"`
+	Odometer *OdometerTrack               `json:"odometer,omitempty"`
+	Fuel     *FuelTrack                   `json:"fuel,omitempty"`
+}
+
+func NewViolationState() *ViolationState {
+	return &ViolationState{Episodes: map[string]*ViolationEpisode{}}
+}
+
+func (s *ViolationState) Empty() bool {
+	return len(s.Episodes) == 0 && s.Odometer == nil && s.Fuel == nil
+}
+
+// OdometerTrack sums the distance between GPS fixes since the odometer showed StartOdo.
+type OdometerTrack struct {
+	StartOdo  int64   `json:"start_odo"`
+	Distance  float64 `json:"distance_km"`
+	StartedAt int64   `json:"started_at"`
+}
+
+// FuelTrack follows the fuel level of a car. Levels are percent of the tank, times unix ms.
+type FuelTrack struct {
+	// the highest level since the car was parked with the engine off, 0 ParkedAt — not parked
+	ParkedFuel float64 `json:"parked_fuel,omitempty"`
+	ParkedAt   int64   `json:"parked_at,omitempty"`
+	// fuel burnt while driving since the odometer showed WindowOdo
+	WindowOdo       int64        `json:"window_odo,omitempty"`
+	WindowUsed      float64      `json:"window_used,omitempty"`
+	WindowStartedAt int64        `json:"window_started_at,omitempty"`
+	Refuel          *RefuelTrack `json:"refuel,omitempty"`
+}
+
+// RefuelTrack is a refuel in progress, it ends when the level stops rising or the car moves.
+type RefuelTrack struct {
+	ID         string  `json:"id"`
+	StartFuel  float64 `json:"start_fuel"`
+	LastFuel   float64 `json:"last_fuel"`
+	StartedAt  int64   `json:"started_at"`
+	LastRiseAt int64   `json:"last_rise_at"`
+	Lat        float64 `json:"lat"`
+	Lon        float64 `json:"lon"`
+	Odo        int64   `json:"odo"`
+}
+
+// Violation types detected in code, the rest are defined by citydrive.violation_rules.
+const (
+	ViolationTypeRegistryMismatch  = "registry_mismatch"
+	ViolationTypeZoneSpeeding      = "zone_speeding"
+	ViolationTypeRestrictedZone    = "restricted_zone"
+	ViolationTypeOperatingAreaExit = "operating_area_exit"
+	ViolationTypeGPSSpoofing       = "gps_spoofing"
+	ViolationTypeOdometerTampering = "odometer_tampering"
+	// fuel events are published once, without episodes
+	ViolationTypeFuelTheft           = "fuel_theft"
+	ViolationTypeRefuel              = "refuel"
+	ViolationTypeFuelOverconsumption = "fuel_overconsumption"
+)
+
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Geozone is a zone from citydrive.geozones.
+type Geozone struct {
+	ID            string
+	Name          string
+	Polygon       []Point
+	SpeedLimit    int32 // km/h, 0 — no limit
+	NoEntry       bool
+	OperatingArea bool
+	// AllowedFrom and AllowedTo are minutes from local midnight, equal means any time.
+	AllowedFrom int
+	AllowedTo   int
+}
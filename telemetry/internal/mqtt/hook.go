@@ -0,0 +1,156 @@
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiti/citydrive/telemetry/internal/carauth"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+)
+
+const authTimeout = 5 * time.Second
+
+// ingestHook authenticates cars and feeds their telemetry into TelemetryService.
+type ingestHook struct {
+	mochi.HookBase
+	broker   *mochi.Server
+	auth     *carauth.Authenticator
+	pipeline Pipeline
+	log      *slog.Logger
+	// tokens holds the car token of every connection, *mochi.Client -> *carauth.Token,
+	// a connection lives longer than its token can
+	tokens sync.Map
+}
+
+func (h *ingestHook) ID() string {
+	return "citydrive-telemetry-ingest"
+}
+
+func (h *ingestHook) Provides(b byte) bool {
+	return bytes.Contains([]byte{
+		mochi.OnConnectAuthenticate,
+		mochi.OnDisconnect,
+		mochi.OnACLCheck,
+		mochi.OnPublish,
+	}, []byte{b})
+}
+
+// OnConnectAuthenticate expects the car token as the password, the username is the car_id or empty.
+func (h *ingestHook) OnConnectAuthenticate(cl *mochi.Client, pk packets.Packet) bool {
+	log := h.log.With("module", "mqtt", "function", "OnConnectAuthenticate", "client_id", cl.ID)
+	ctx, cancel := context.WithTimeout(context.Background(), authTimeout)
+	defer cancel()
+
+	token, err := h.auth.Authenticate(ctx, string(pk.Connect.Password))
+	if err != nil {
+		log.Warn("car authentication failed", "error", err)
+		return false
+	}
+	if username := string(pk.Connect.Username); username != "" && username != token.CarID {
+		log.Warn("username does not match car token", "username", username, "car_id", token.CarID)
+		return false
+	}
+	// the username is what ACL checks and OnPublish rely on as the authenticated car_id
+	cl.Properties.Username = []byte(token.CarID)
+	h.tokens.Store(cl, token)
+	log.Info("car authenticated", "car_id", token.CarID)
+	return true
+}
+
+func (h *ingestHook) OnDisconnect(cl *mochi.Client, err error, expire bool) {
+	h.tokens.Delete(cl)
+}
+
+// OnACLCheck lets a car publish only to its own topic and denies all subscriptions.
+func (h *ingestHook) OnACLCheck(cl *mochi.Client, topic string, write bool) bool {
+	return write && topic == TelemetryTopic(string(cl.Properties.Username))
+}
+
+func (h *ingestHook) OnPublish(cl *mochi.Client, pk packets.Packet) (packets.Packet, error) {
+	carID := string(cl.Properties.Username)
+	traceID := uuid.NewString()
+	log := h.log.With(
+		"module", "mqtt",
+		"function", "OnPublish",
+		"car_id", carID,
+		"trace_id", traceID,
+	)
+
+	// a token revoked or expired after CONNECT must not keep publishing
+	if err := h.checkToken(cl); err != nil {
+		if !errors.Is(err, carauth.ErrTokenExpired) && !errors.Is(err, carauth.ErrTokenRevoked) {
+			log.Error("car token check failed", "error", err)
+			return pk, retryLater(cl, err)
+		}
+		log.Warn("car token is no longer valid, disconnecting", "error", err)
+		h.broker.DisconnectClient(cl, packets.ErrNotAuthorized)
+		return pk, packets.ErrRejectPacket
+	}
+
+	var data models.TelemetryData
+	if err := json.Unmarshal(pk.Payload, &data); err != nil {
+		log.Warn("invalid telemetry payload", "error", err)
+		return pk, reject(cl, packets.ErrPayloadFormatInvalid)
+	}
+	// clock skew is decided by the service, not by the device
+	data.ClockSkew = false
+
+	ctx := context.WithValue(context.Background(), "trace_id", traceID)
+	err := h.pipeline.ProcessTelemetry(ctx, carID, &data)
+	switch {
+	case err == nil, errors.Is(err, models.ErrDuplicateTelemetry):
+		// nobody subscribes to car topics, the reading is acked and not routed further
+		return pk, packets.CodeSuccessIgnore
+	case errors.Is(err, models.ErrInvalidTelemetry):
+		log.Warn("telemetry rejected", "error", err)
+		return pk, reject(cl, packets.ErrPayloadFormatInvalid)
+	case errors.Is(err, models.ErrCarNotFound), errors.Is(err, models.ErrCarDecommissioned):
+		log.Warn("telemetry rejected", "error", err)
+		return pk, reject(cl, packets.ErrNotAuthorized)
+	case errors.Is(err, models.ErrClockSkew):
+		log.Warn("telemetry rejected", "error", err)
+		return pk, reject(cl, packets.ErrImplementationSpecificError)
+	default:
+		log.Error("telemetry processing failed", "error", err)
+		return pk, retryLater(cl, err)
+	}
+}
+
+func (h *ingestHook) checkToken(cl *mochi.Client) error {
+	token, ok := h.tokens.Load(cl)
+	if !ok {
+		return carauth.ErrTokenRevoked
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), authTimeout)
+	defer cancel()
+	return h.auth.Check(ctx, token.(*carauth.Token), time.Now())
+}
+
+// retryLater answers a reading that could not be processed now, so the car resends it.
+func retryLater(cl *mochi.Client, err error) error {
+	if cl.Properties.ProtocolVersion == 5 {
+		if errors.Is(err, models.ErrQueueFull) || errors.Is(err, models.ErrShuttingDown) {
+			return packets.ErrServerBusy
+		}
+		return packets.ErrUnspecifiedError
+	}
+	// MQTT 3.1.1 has no negative PUBACK, without an ack the car resends the reading
+	return packets.ErrRejectPacket
+}
+
+// reject answers MQTT 5 clients with a reason code, 3.1.1 clients get a plain ack
+// and the reading is dropped since resending it would fail the same way.
+func reject(cl *mochi.Client, code packets.Code) error {
+	if cl.Properties.ProtocolVersion == 5 {
+		return code
+	}
+	return packets.CodeSuccessIgnore
+}
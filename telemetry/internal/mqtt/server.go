@@ -0,0 +1,70 @@
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+
+	"github.com/jekiti/citydrive/telemetry/internal/carauth"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/listeners"
+)
+
+// Pipeline is where published readings go, service.IngestPipeline in the app.
+type Pipeline interface {
+	ProcessTelemetry(ctx context.Context, carID string, data *models.TelemetryData) error
+}
+
+func TelemetryTopic(carID string) string {
+	return "cars/" + carID + "/telemetry"
+}
+
+// Server is an embedded MQTT broker for telematics units that can't speak HTTP or gRPC.
+type Server struct {
+	broker *mochi.Server
+	log    *slog.Logger
+}
+
+func NewServer(cfg *config.TelemetryConfig, auth *carauth.Authenticator, pipeline Pipeline, log *slog.Logger) (*Server, error) {
+	broker := mochi.New(&mochi.Options{Logger: log.With("module", "mqtt.broker")})
+
+	err := broker.AddHook(&ingestHook{
+		broker:   broker,
+		auth:     auth,
+		pipeline: pipeline,
+		log:      log,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add mqtt hook: %w", err)
+	}
+
+	listener := listeners.Config{ID: "tcp", Address: ":" + cfg.MQTT.Port}
+	if cfg.MQTT.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.MQTT.TLSCertFile, cfg.MQTT.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mqtt tls certificate: %w", err)
+		}
+		listener.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	}
+	err = broker.AddListener(listeners.NewTCP(listener))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add mqtt listener: %w", err)
+	}
+
+	return &Server{broker: broker, log: log}, nil
+}
+
+// Start begins accepting connections, listeners run in their own goroutines.
+func (s *Server) Start() error {
+	if err := s.broker.Serve(); err != nil {
+		return fmt.Errorf("failed to start mqtt broker: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) Close() error {
+	return s.broker.Close()
+}
@@ -0,0 +1,263 @@
+package mqtt
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jekiti/citydrive/telemetry/internal/carauth"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+const (
+	testSecret = "car-secret"
+	testCarID  = "6b1f2f6e-1c2d-4e5f-8a9b-0c1d2e3f4a5b"
+	otherCarID = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+)
+
+type revocations struct {
+	mu      sync.Mutex
+	revoked map[string]bool
+}
+
+func (r *revocations) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.revoked[tokenID], nil
+}
+
+func (r *revocations) Revoke(tokenID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.revoked[tokenID] = true
+}
+
+type reading struct {
+	carID string
+	data  *models.TelemetryData
+}
+
+type pipeline chan reading
+
+func (p pipeline) ProcessTelemetry(ctx context.Context, carID string, data *models.TelemetryData) error {
+	p <- reading{carID: carID, data: data}
+	return nil
+}
+
+func startServer(t *testing.T) (string, pipeline, *revocations) {
+	t.Helper()
+	cfg := &config.TelemetryConfig{
+		MQTT: config.MQTTConfig{Enabled: true, Port: freePort(t)},
+		JWT:  config.JWTConfig{Algorithm: "HS256", CarSecretKey: testSecret},
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	denylist := &revocations{revoked: map[string]bool{"revoked-jti": true}}
+	auth := carauth.NewAuthenticator(&cfg.JWT, denylist)
+	readings := make(pipeline, 10)
+
+	server, err := NewServer(cfg, auth, readings, log)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	if err := server.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+
+	// Serve starts the listener in a goroutine
+	addr := "127.0.0.1:" + cfg.MQTT.Port
+	for attempt := 0; ; attempt++ {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if attempt == 50 {
+			t.Fatalf("mqtt listener did not start: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	return "tcp://" + addr, readings, denylist
+}
+
+func freePort(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer l.Close()
+	return strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+}
+
+func carToken(t *testing.T, secret, carID, jti string) string {
+	return expiringCarToken(t, secret, carID, jti, time.Now().Add(time.Hour))
+}
+
+func expiringCarToken(t *testing.T, secret, carID, jti string, exp time.Time) string {
+	return sign(t, secret, jwt.MapClaims{
+		"sub":    "car:" + carID,
+		"car_id": carID,
+		"roles":  []string{"car"},
+		"jti":    jti,
+		"exp":    exp.Unix(),
+	})
+}
+
+func sign(t *testing.T, secret string, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+func connect(t *testing.T, broker, username, password string) (paho.Client, error) {
+	t.Helper()
+	opts := paho.NewClientOptions().
+		AddBroker(broker).
+		SetClientID("test-" + strconv.FormatInt(time.Now().UnixNano(), 36)).
+		SetUsername(username).
+		SetPassword(password).
+		SetAutoReconnect(false)
+	client := paho.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(2 * time.Second) {
+		t.Fatal("connect timed out")
+	}
+	if err := token.Error(); err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() { client.Disconnect(100) })
+	return client, nil
+}
+
+func TestConnectAuthenticatesCarToken(t *testing.T) {
+	broker, _, _ := startServer(t)
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		ok       bool
+	}{
+		{"valid token", testCarID, carToken(t, testSecret, testCarID, "jti-1"), true},
+		{"wrong signature", testCarID, carToken(t, "other-secret", testCarID, "jti-3"), false},
+		{"revoked token", testCarID, carToken(t, testSecret, testCarID, "revoked-jti"), false},
+		{"username of another car", otherCarID, carToken(t, testSecret, testCarID, "jti-4"), false},
+		{"user token", testCarID, sign(t, testSecret, jwt.MapClaims{
+			"sub":   "user:1",
+			"roles": []string{"fleet-admin"},
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}), false},
+		{"car role with a user subject", testCarID, sign(t, testSecret, jwt.MapClaims{
+			"sub":    "user:1",
+			"car_id": testCarID,
+			"roles":  []string{"car"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+		}), false},
+		{"garbage", testCarID, "not-a-token", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := connect(t, broker, tt.username, tt.password)
+			if tt.ok && err != nil {
+				t.Fatalf("expected connect to succeed, got %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected connect to be refused")
+			}
+		})
+	}
+}
+
+func TestPublishReachesPipeline(t *testing.T) {
+	broker, readings, _ := startServer(t)
+	client, err := connect(t, broker, testCarID, carToken(t, testSecret, testCarID, "jti-1"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	token := client.Publish(TelemetryTopic(testCarID), 1, false, `{"brand":"Kia","speed":42,"clock_skew":true}`)
+	if !token.WaitTimeout(2*time.Second) || token.Error() != nil {
+		t.Fatalf("publish: %v", token.Error())
+	}
+
+	select {
+	case r := <-readings:
+		if r.carID != testCarID || r.data.Speed != 42 || r.data.Brand != "Kia" {
+			t.Fatalf("unexpected reading %s %+v", r.carID, r.data)
+		}
+		if r.data.ClockSkew {
+			t.Fatal("clock_skew from the device must be ignored")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("reading did not reach the pipeline")
+	}
+}
+
+func TestPublishToForeignTopicIsDenied(t *testing.T) {
+	broker, readings, _ := startServer(t)
+	client, err := connect(t, broker, testCarID, carToken(t, testSecret, testCarID, "jti-1"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	// MQTT 3.1.1 has no negative PUBACK, the broker drops the client instead
+	client.Publish(TelemetryTopic(otherCarID), 1, false, `{"speed":42}`).WaitTimeout(time.Second)
+
+	select {
+	case r := <-readings:
+		t.Fatalf("reading for a foreign topic reached the pipeline: %s", r.carID)
+	case <-time.After(300 * time.Millisecond):
+	}
+	if client.IsConnectionOpen() {
+		t.Fatal("client publishing to a foreign topic must be disconnected")
+	}
+}
+
+func TestPublishWithInvalidatedToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		ttl        time.Duration
+		invalidate func(denylist *revocations)
+	}{
+		{"revoked after connect", time.Hour, func(denylist *revocations) {
+			denylist.Revoke("jti-1")
+		}},
+		{"expired after connect", time.Second, func(denylist *revocations) {
+			// exp has second precision
+			time.Sleep(2100 * time.Millisecond)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broker, readings, denylist := startServer(t)
+			client, err := connect(t, broker, testCarID, expiringCarToken(t, testSecret, testCarID, "jti-1", time.Now().Add(tt.ttl)))
+			if err != nil {
+				t.Fatalf("connect: %v", err)
+			}
+			tt.invalidate(denylist)
+
+			client.Publish(TelemetryTopic(testCarID), 1, false, `{"brand":"Kia","speed":42}`).WaitTimeout(time.Second)
+
+			select {
+			case r := <-readings:
+				t.Fatalf("reading with an invalidated token reached the pipeline: %+v", r.data)
+			case <-time.After(300 * time.Millisecond):
+			}
+			if client.IsConnectionOpen() {
+				t.Fatal("client with an invalidated token must be disconnected")
+			}
+		})
+	}
+}
@@ -2,11 +2,12 @@ package producer
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
 
 	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/events"
 	"github.com/jekiti/citydrive/telemetry/internal/models"
 	"github.com/segmentio/kafka-go"
 )
@@ -18,13 +19,16 @@ type KafkaProducer struct {
 }
 
 func NewKafkaProducer(cfg *config.TelemetryConfig, log *slog.Logger) (*KafkaProducer, error) {
-	telemetryWriter, err := createWriter(cfg, "telemetry")
-	if err != nil {
-		return nil, fmt.Errorf("error creating telemetryKafkaWrite: %w", err)
-	}
-	violationsWriter, err := createWriter(cfg, "violations")
-	if err != nil {
-		return nil, fmt.Errorf("error creating telemetryKafkaWrite: %w", err)
+	telemetryWriter := createWriter(cfg, "telemetry")
+	violationsWriter := createWriter(cfg, "violations")
+	// events wait in the outbox while Kafka is down, so it is not required to start
+	for _, topic := range []string{cfg.Kafka.TelemetryTopic, cfg.Kafka.ViolationsTopic} {
+		conn, err := kafka.DialLeader(context.Background(), "tcp", cfg.Kafka.Brokers[0], topic, 0)
+		if err != nil {
+			log.Warn("kafka topic not available", "topic", topic, "error", err)
+			continue
+		}
+		conn.Close()
 	}
 
 	return &KafkaProducer{
@@ -34,7 +38,7 @@ func NewKafkaProducer(cfg *config.TelemetryConfig, log *slog.Logger) (*KafkaProd
 	}, nil
 }
 
-func createWriter(cfg *config.TelemetryConfig, name string) (*kafka.Writer, error) {
+func createWriter(cfg *config.TelemetryConfig, name string) *kafka.Writer {
 
 	brokers := cfg.Kafka.Brokers
 	var topic string
@@ -73,67 +77,53 @@ func createWriter(cfg *config.TelemetryConfig, name string) (*kafka.Writer, erro
 		BatchTimeout: cfg.Kafka.BatchTimeout,
 		Compression:  compression,
 	}
-
-	conn, err := kafka.DialLeader(context.Background(), "tcp", brokers[0], topic, 0)
-	if err != nil {
-		return nil, fmt.Errorf("kafka topic %s not available: %w", topic, err)
-	}
-	conn.Close()
-
-	return writer, nil
-}
-
-func (p *KafkaProducer) SendTelemetry(ctx context.Context, carID string, data *models.TelemetryData) error {
-	traceID := ctx.Value("trace_id")
-	log := p.log.With(
-		"module", "producer",
-		"function", "SendTelemetry",
-		"car_id", carID,
-		"trace_id", traceID,
-	)
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		log.Error("error sending telemetry", "error", err)
-		return fmt.Errorf("failed sending telemetry:%w", err)
-	}
-
-	message := kafka.Message{
-		Key:   []byte(carID),
-		Value: jsonData,
-	}
-	err = p.telemetryWriter.WriteMessages(ctx, message)
-	if err != nil {
-		log.Error("error writing message in telemetry topic", "error", err)
-		return fmt.Errorf("failed to write message in telemetry Topic: %w", err)
-	}
-	log.Info("telemetry sended successfully")
-	return nil
+	return writer
 }
 
-func (p *KafkaProducer) SendViolation(ctx context.Context, violation *models.Violation) error {
-	traceID := ctx.Value("trace_id")
+// Publish writes outbox events to their topics, it succeeds only if every event was written.
+func (p *KafkaProducer) Publish(ctx context.Context, batch []models.OutboxEvent) error {
 	log := p.log.With(
 		"module", "producer",
-		"function", "SendViolation",
-		"car_id", violation.CarID,
-		"trace_id", traceID,
+		"function", "Publish",
 	)
-	jsonData, err := json.Marshal(violation)
-	if err != nil {
-		log.Error("error marshal violation", "error", err)
-		return fmt.Errorf("failed marshal violation:%w", err)
+	var telemetry, violations []kafka.Message
+	for _, event := range batch {
+		message := kafka.Message{
+			Key:   []byte(event.Key),
+			Value: event.Value,
+		}
+		// events stored before the format was recorded are plain JSON, they go out without headers
+		if event.ContentType != "" {
+			message.Headers = []kafka.Header{{Key: events.HeaderContentType, Value: []byte(event.ContentType)}}
+		}
+		if event.ContentType == events.ContentTypeProtobuf {
+			message.Headers = append(message.Headers, kafka.Header{
+				Key:   events.HeaderSchemaVersion,
+				Value: []byte(strconv.Itoa(events.SchemaVersion)),
+			})
+		}
+		if event.Topic == models.EventTopicViolations {
+			violations = append(violations, message)
+		} else {
+			telemetry = append(telemetry, message)
+		}
 	}
 
-	message := kafka.Message{
-		Key:   []byte(violation.Type),
-		Value: jsonData,
+	if len(telemetry) > 0 {
+		err := p.telemetryWriter.WriteMessages(ctx, telemetry...)
+		if err != nil {
+			log.Error("error writing messages in telemetry topic", "error", err)
+			return fmt.Errorf("failed to write messages in telemetry Topic: %w", err)
+		}
 	}
-	err = p.violationsWriter.WriteMessages(ctx, message)
-	if err != nil {
-		log.Error("error writing message in violation Topic", "error", err)
-		return fmt.Errorf("failed to write message in violation Topic: %w", err)
+	if len(violations) > 0 {
+		err := p.violationsWriter.WriteMessages(ctx, violations...)
+		if err != nil {
+			log.Error("error writing messages in violation Topic", "error", err)
+			return fmt.Errorf("failed to write messages in violation Topic: %w", err)
+		}
 	}
-	log.Info("violation sended successfully")
+	log.Info("events published", "telemetry", len(telemetry), "violations", len(violations))
 	return nil
 }
 
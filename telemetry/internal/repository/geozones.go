@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+const geozonesChangedChannel = "geozones_changed"
+
+// GeozoneRepository reads citydrive.geozones, the zones are edited through admin.
+type GeozoneRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewGeozoneRepository(db *pgxpool.Pool, log *slog.Logger) *GeozoneRepository {
+	return &GeozoneRepository{db: db, log: log}
+}
+
+func (r *GeozoneRepository) ListEnabledGeozones(ctx context.Context) ([]models.Geozone, error) {
+	log := r.log.With("module", "repository", "function", "ListEnabledGeozones")
+	query := `SELECT id::text, name, polygon, COALESCE(speed_limit, 0), no_entry, operating_area,
+		COALESCE(EXTRACT(EPOCH FROM allowed_from)::int / 60, 0),
+		COALESCE(EXTRACT(EPOCH FROM allowed_to)::int / 60, 0)
+	FROM citydrive.geozones
+	WHERE enabled`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		log.Error("error querying geozones", "error", err)
+		return nil, fmt.Errorf("failed to list geozones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []models.Geozone
+	for rows.Next() {
+		var zone models.Geozone
+		var polygon []byte
+		err := rows.Scan(&zone.ID, &zone.Name, &polygon, &zone.SpeedLimit, &zone.NoEntry, &zone.OperatingArea,
+			&zone.AllowedFrom, &zone.AllowedTo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan geozone: %w", err)
+		}
+		if err := json.Unmarshal(polygon, &zone.Polygon); err != nil {
+			log.Error("skipping geozone with invalid polygon", "zone_id", zone.ID, "error", err)
+			continue
+		}
+		zones = append(zones, zone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list geozones: %w", err)
+	}
+	return zones, nil
+}
+
+// ListenGeozoneChanges calls onChange after every change of the geozones table
+// until ctx is done or the connection breaks.
+func (r *GeozoneRepository) ListenGeozoneChanges(ctx context.Context, onChange func()) error {
+	return listen(ctx, r.db, geozonesChangedChannel, func(string) { onChange() })
+}
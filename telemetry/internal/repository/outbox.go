@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// SaveReading writes the new car state, its violation state and the events of a reading
+// in one MULTI/EXEC, so either all of them are stored or none. state and violations may
+// be nil when they must not change.
+func (r *RedisRepository) SaveReading(ctx context.Context, carID string,
+	state *models.TelemetryData,
+	violations *models.ViolationState,
+	events []models.OutboxEvent) error {
+	var stateData, violationsData []byte
+	if state != nil {
+		var err error
+		stateData, err = json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("failed to marshal car state: %w", err)
+		}
+	}
+	if violations != nil && !violations.Empty() {
+		var err error
+		violationsData, err = json.Marshal(violations)
+		if err != nil {
+			return fmt.Errorf("failed to marshal violation state: %w", err)
+		}
+	}
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, event := range events {
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: r.outbox.Stream,
+				Values: map[string]interface{}{
+					"topic":        event.Topic,
+					"key":          event.Key,
+					"value":        event.Value,
+					"content_type": event.ContentType,
+					"trace_id":     event.TraceID,
+				},
+			})
+		}
+		if stateData != nil {
+			pipe.Set(ctx, r.prefix+carID, stateData, 24*time.Hour)
+		}
+		switch {
+		case violationsData != nil:
+			// a car gone silent for a day drops its episodes without an end event
+			pipe.Set(ctx, violationPrefix+carID, violationsData, 24*time.Hour)
+		case violations != nil:
+			pipe.Del(ctx, violationPrefix+carID)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save reading:%w", err)
+	}
+	return nil
+}
+
+// GetViolationState returns the open episodes and trackers of a car.
+func (r *RedisRepository) GetViolationState(ctx context.Context, carID string) (*models.ViolationState, error) {
+	state := models.NewViolationState()
+	data, err := r.client.Get(ctx, violationPrefix+carID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("failed to get violation state:%w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal violation state:%w", err)
+	}
+	if state.Episodes == nil {
+		state.Episodes = map[string]*models.ViolationEpisode{}
+	}
+	return state, nil
+}
+
+func (r *RedisRepository) CreateOutboxGroup(ctx context.Context) error {
+	err := r.client.XGroupCreateMkStream(ctx, r.outbox.Stream, r.outbox.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create outbox group:%w", err)
+	}
+	return nil
+}
+
+// ReadOutbox reads entries for the consumer: id "0" returns the ones it already took
+// and didn't ack, ">" waits up to Block for new ones.
+func (r *RedisRepository) ReadOutbox(ctx context.Context, consumer, id string) ([]models.OutboxEvent, error) {
+	block := r.outbox.Block
+	if id != ">" {
+		block = -1
+	}
+	streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    r.outbox.Group,
+		Consumer: consumer,
+		Streams:  []string{r.outbox.Stream, id},
+		Count:    int64(r.outbox.BatchSize),
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read outbox:%w", err)
+	}
+	var events []models.OutboxEvent
+	for _, stream := range streams {
+		events = append(events, toOutboxEvents(stream.Messages)...)
+	}
+	return events, nil
+}
+
+// ClaimOutbox takes over entries another relay read but didn't ack within ClaimIdle.
+func (r *RedisRepository) ClaimOutbox(ctx context.Context, consumer string) ([]models.OutboxEvent, error) {
+	messages, _, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   r.outbox.Stream,
+		Group:    r.outbox.Group,
+		Consumer: consumer,
+		MinIdle:  r.outbox.ClaimIdle,
+		Start:    "0-0",
+		Count:    int64(r.outbox.BatchSize),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox:%w", err)
+	}
+	return toOutboxEvents(messages), nil
+}
+
+func (r *RedisRepository) AckOutbox(ctx context.Context, ids []string) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAck(ctx, r.outbox.Stream, r.outbox.Group, ids...)
+		pipe.XDel(ctx, r.outbox.Stream, ids...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ack outbox:%w", err)
+	}
+	return nil
+}
+
+func toOutboxEvents(messages []redis.XMessage) []models.OutboxEvent {
+	events := make([]models.OutboxEvent, 0, len(messages))
+	for _, msg := range messages {
+		topic, _ := msg.Values["topic"].(string)
+		key, _ := msg.Values["key"].(string)
+		value, _ := msg.Values["value"].(string)
+		contentType, _ := msg.Values["content_type"].(string)
+		traceID, _ := msg.Values["trace_id"].(string)
+		events = append(events, models.OutboxEvent{
+			ID:          msg.ID,
+			Topic:       topic,
+			Key:         key,
+			Value:       []byte(value),
+			ContentType: contentType,
+			TraceID:     traceID,
+		})
+	}
+	return events
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+const carChangedChannel = "car_changed"
+
+// NewPostgres opens the pool shared by the repositories reading citydrive tables.
+func NewPostgres(cfg *config.DBConfig, log *slog.Logger) (*pgxpool.Pool, error) {
+	log = log.With("module", "repository", "function", "NewPostgres")
+	poolCfg, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		log.Error("invalid DB_URL", "error", err)
+		return nil, fmt.Errorf("invalid postgres url: %w", err)
+	}
+	poolCfg.MaxConns = int32(cfg.MaxConn)
+
+	db, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+	if err != nil {
+		log.Error("failed to connect postgres", "error", err)
+		return nil, fmt.Errorf("postgres connection failed: %w", err)
+	}
+	if err := db.Ping(context.Background()); err != nil {
+		db.Close()
+		log.Error("failed to ping postgres", "error", err)
+		return nil, fmt.Errorf("postgres connection failed: %w", err)
+	}
+	return db, nil
+}
+
+type CarRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewCarRepository(db *pgxpool.Pool, log *slog.Logger) *CarRepository {
+	return &CarRepository{db: db, log: log}
+}
+
+// GetCar returns nil if there is no such car.
+func (r *CarRepository) GetCar(ctx context.Context, carID string) (*models.Car, error) {
+	log := r.log.With("module", "repository", "function", "GetCar", "car_id", carID)
+	query := `SELECT c.id::text, c.brand, c.model, c.fuel_type, c.decommissioned_at IS NOT NULL,
+		COALESCE(n.tank_liters, 0)::float8, COALESCE(n.consumption_l_100km, 0)::float8
+	FROM citydrive.cars c
+	LEFT JOIN citydrive.fuel_norms n ON n.brand = c.brand AND n.model = c.model
+	WHERE c.id = $1::uuid`
+
+	var car models.Car
+	err := r.db.QueryRow(ctx, query, carID).Scan(&car.ID, &car.Brand, &car.Model, &car.FuelType, &car.Decommissioned,
+		&car.TankLiters, &car.ConsumptionNorm)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		log.Error("error getting car from postgres", "error", err)
+		return nil, fmt.Errorf("failed to get car: %w", err)
+	}
+	return &car, nil
+}
+
+// ListenCarChanges calls onChange with the id of every car inserted, updated or deleted
+// until ctx is done or the connection breaks.
+func (r *CarRepository) ListenCarChanges(ctx context.Context, onChange func(carID string)) error {
+	return listen(ctx, r.db, carChangedChannel, onChange)
+}
+
+// listen calls onNotify with the payload of every notification on channel
+// until ctx is done or the connection breaks.
+func listen(ctx context.Context, db *pgxpool.Pool, channel string, onNotify func(payload string)) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "LISTEN "+channel)
+	if err != nil {
+		return fmt.Errorf("failed to listen %s: %w", channel, err)
+	}
+	// the connection goes back to the pool, it must not keep receiving notifications
+	defer conn.Exec(context.Background(), "UNLISTEN "+channel)
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onNotify(notification.Payload)
+	}
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -12,9 +13,22 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	dedupPrefix        = "telemetry:dedup:"
+	revokedTokenPrefix = "auth:revoked:"
+	registryPrefix     = "car:registry:"
+	violationPrefix    = "violation:state:"
+)
+
+// cachedCar keeps a nil Car for ids missing from the registry, so garbage ids don't reach Postgres.
+type cachedCar struct {
+	Car *models.Car `json:"car"`
+}
+
 type RedisRepository struct {
 	client *redis.Client
 	prefix string
+	outbox *config.OutboxConfig
 	log    *slog.Logger
 }
 
@@ -37,6 +51,7 @@ func NewRedisRepository(cfg *config.TelemetryConfig, log *slog.Logger) (*RedisRe
 	return &RedisRepository{
 		client: client,
 		prefix: "car:state:",
+		outbox: &cfg.Outbox,
 		log: log,
 	}, nil
 }
@@ -59,15 +74,67 @@ func (r *RedisRepository) GetCarState(ctx context.Context, carID string) (*model
 	return &state, nil
 }
 
-func (r *RedisRepository) SetCarState(ctx context.Context, carID string, data *models.TelemetryData) error {
-	key := r.prefix + carID
-	jsonData, err := json.Marshal(data)
+// ClaimReading marks a reading as taken for the dedup window, false means it was already accepted.
+func (r *RedisRepository) ClaimReading(ctx context.Context, carID, key string, window time.Duration) (bool, error) {
+	ok, err := r.client.SetNX(ctx, dedupPrefix+carID+":"+key, 1, window).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim reading:%w", err)
+	}
+	return ok, nil
+}
+
+func (r *RedisRepository) ReleaseReading(ctx context.Context, carID, key string) error {
+	err := r.client.Del(ctx, dedupPrefix+carID+":"+key).Err()
+	if err != nil {
+		return fmt.Errorf("failed to release reading:%w", err)
+	}
+	return nil
+}
+
+// IsRevoked checks the car token revocation list written by auth.
+func (r *RedisRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	err := r.client.Get(ctx, revokedTokenPrefix+tokenID).Err()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	return true, nil
+}
+
+// GetRegisteredCar returns the cached registry entry, found is false on a cache miss.
+func (r *RedisRepository) GetRegisteredCar(ctx context.Context, carID string) (car *models.Car, found bool, err error) {
+	data, err := r.client.Get(ctx, registryPrefix+carID).Bytes()
 	if err != nil {
-		return fmt.Errorf("failed to marshal car state: %w", err)
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get registered car:%w", err)
+	}
+	var cached cachedCar
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal registered car: %w", err)
 	}
-	err = r.client.Set(ctx, key, jsonData, 24*time.Hour).Err()
+	return cached.Car, true, nil
+}
+
+func (r *RedisRepository) SetRegisteredCar(ctx context.Context, carID string, car *models.Car, ttl time.Duration) error {
+	data, err := json.Marshal(cachedCar{Car: car})
+	if err != nil {
+		return fmt.Errorf("failed to marshal registered car: %w", err)
+	}
+	err = r.client.Set(ctx, registryPrefix+carID, data, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set registered car:%w", err)
+	}
+	return nil
+}
+
+func (r *RedisRepository) DeleteRegisteredCar(ctx context.Context, carID string) error {
+	err := r.client.Del(ctx, registryPrefix+carID).Err()
 	if err != nil {
-		return fmt.Errorf("failed to set car state:%w", err)
+		return fmt.Errorf("failed to delete registered car:%w", err)
 	}
 	return nil
 }
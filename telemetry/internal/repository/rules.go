@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jekiti/citydrive/pkg/violationrules"
+)
+
+const violationRulesChangedChannel = "violation_rules_changed"
+
+// RuleRepository reads citydrive.violation_rules, the rules are edited through admin.
+type RuleRepository struct {
+	db  *pgxpool.Pool
+	log *slog.Logger
+}
+
+func NewRuleRepository(db *pgxpool.Pool, log *slog.Logger) *RuleRepository {
+	return &RuleRepository{db: db, log: log}
+}
+
+func (r *RuleRepository) ListEnabledRules(ctx context.Context) ([]violationrules.Rule, error) {
+	log := r.log.With("module", "repository", "function", "ListEnabledRules")
+	query := `SELECT id::text, type, description, condition, severity, cooldown_seconds, enabled
+	FROM citydrive.violation_rules
+	WHERE enabled
+	ORDER BY type`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		log.Error("error querying violation rules", "error", err)
+		return nil, fmt.Errorf("failed to list violation rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []violationrules.Rule
+	for rows.Next() {
+		var rule violationrules.Rule
+		err := rows.Scan(&rule.ID, &rule.Type, &rule.Description, &rule.Condition, &rule.Severity, &rule.CooldownSeconds, &rule.Enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan violation rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list violation rules: %w", err)
+	}
+	return rules, nil
+}
+
+// ListenRuleChanges calls onChange after every change of the rules table
+// until ctx is done or the connection breaks.
+func (r *RuleRepository) ListenRuleChanges(ctx context.Context, onChange func()) error {
+	return listen(ctx, r.db, violationRulesChangedChannel, func(string) { onChange() })
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+// trackEpisodes folds the violations found in a reading into the open episodes of the car
+// and returns what has to be published: a start for every new episode and an end for every
+// episode whose violation has been absent for its cooldown. open is updated in place.
+func trackEpisodes(carID string, open map[string]*models.ViolationEpisode, matched []*models.Violation, data *models.TelemetryData, at int64) []*models.Violation {
+	var events []*models.Violation
+	seen := make(map[string]bool, len(matched))
+	for _, violation := range matched {
+		key := episodeKey(violation)
+		seen[key] = true
+		episode, ok := open[key]
+		if !ok {
+			episode = &models.ViolationEpisode{
+				ID:        uuid.NewString(),
+				Type:      violation.Type,
+				Subject:   violation.Subject,
+				RuleID:    violation.RuleID,
+				Severity:  violation.Severity,
+				StartedAt: at,
+				PeakSpeed: data.Speed,
+				PeakRPM:   data.RPM,
+				MinFuel:   data.Fuel,
+			}
+			open[key] = episode
+		}
+		episode.LastSeenAt = at
+		episode.Cooldown = violation.Cooldown
+		episode.Readings++
+		episode.PeakSpeed = max(episode.PeakSpeed, data.Speed)
+		episode.PeakRPM = max(episode.PeakRPM, data.RPM)
+		episode.MinFuel = min(episode.MinFuel, data.Fuel)
+		if ok {
+			continue
+		}
+
+		start := *episode
+		start.Phase = models.EpisodePhaseStart
+		violation.Episode = &start
+		events = append(events, violation)
+	}
+
+	// sorted so the end events of one reading always come out in the same order
+	keys := make([]string, 0, len(open))
+	for key := range open {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		episode := open[key]
+		if seen[key] || time.Duration(at-episode.LastSeenAt)*time.Millisecond < episode.Cooldown {
+			continue
+		}
+		delete(open, key)
+
+		end := *episode
+		end.Phase = models.EpisodePhaseEnd
+		// the episode lasted until the last reading that still violated the rule
+		end.EndedAt = episode.LastSeenAt
+		events = append(events, &models.Violation{
+			Type:     episode.Type,
+			CarID:    carID,
+			Subject:  episode.Subject,
+			Severity: episode.Severity,
+			RuleID:   episode.RuleID,
+			Data:     *data,
+			Episode:  &end,
+		})
+	}
+	return events
+}
+
+// episodeKey is the violation type, plus the subject for violations a car can have
+// several of at once, like speeding in two zones.
+func episodeKey(violation *models.Violation) string {
+	if violation.Subject == "" {
+		return violation.Type
+	}
+	return violation.Type + ":" + violation.Subject
+}
+
+// readingTime is when the reading was taken, by the device clock if it can be trusted.
+func readingTime(data *models.TelemetryData, now time.Time) int64 {
+	if data.RecordedAt > 0 && !data.ClockSkew {
+		return data.RecordedAt
+	}
+	return now.UnixMilli()
+}
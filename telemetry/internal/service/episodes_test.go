@@ -0,0 +1,129 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+const episodeCooldown = 30 * time.Second
+
+type episodeStep struct {
+	at      int64    // reading time, ms
+	speed   int32    // speed of the reading
+	matched []string // violations found in the reading, "type" or "type:subject"
+	events  []string // expected events, "phase type" or "phase type:subject"
+}
+
+func matchedViolations(keys []string) []*models.Violation {
+	violations := make([]*models.Violation, len(keys))
+	for i, key := range keys {
+		typ, subject, _ := strings.Cut(key, ":")
+		violations[i] = &models.Violation{Type: typ, Subject: subject, Severity: "medium", Cooldown: episodeCooldown}
+	}
+	return violations
+}
+
+func TestTrackEpisodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []episodeStep
+	}{
+		{"first violation starts an episode", []episodeStep{
+			{at: 0, speed: 120, matched: []string{"speeding"}, events: []string{"start speeding"}},
+		}},
+		{"continuation within the cooldown publishes nothing", []episodeStep{
+			{at: 0, speed: 120, matched: []string{"speeding"}, events: []string{"start speeding"}},
+			{at: 10_000, speed: 130, matched: []string{"speeding"}},
+			{at: 20_000, speed: 60},
+			{at: 39_000, speed: 125, matched: []string{"speeding"}},
+			{at: 60_000, speed: 60},
+		}},
+		{"episode ends once the cooldown has passed", []episodeStep{
+			{at: 0, speed: 120, matched: []string{"speeding"}, events: []string{"start speeding"}},
+			{at: 10_000, speed: 130, matched: []string{"speeding"}},
+			{at: 39_999, speed: 60},
+			{at: 40_000, speed: 60, events: []string{"end speeding"}},
+			{at: 50_000, speed: 60},
+		}},
+		{"violation after the end starts a new episode", []episodeStep{
+			{at: 0, speed: 120, matched: []string{"speeding"}, events: []string{"start speeding"}},
+			{at: 30_000, speed: 120, matched: []string{"speeding"}},
+			{at: 60_000, speed: 60, events: []string{"end speeding"}},
+			{at: 61_000, speed: 120, matched: []string{"speeding"}, events: []string{"start speeding"}},
+		}},
+		{"zones are separate episodes", []episodeStep{
+			{at: 0, speed: 70, matched: []string{"zone_speeding:a"}, events: []string{"start zone_speeding:a"}},
+			{at: 5_000, speed: 70, matched: []string{"zone_speeding:a", "zone_speeding:b"}, events: []string{"start zone_speeding:b"}},
+			{at: 35_000, speed: 70, matched: []string{"zone_speeding:b"}, events: []string{"end zone_speeding:a"}},
+			{at: 65_000, speed: 40, events: []string{"end zone_speeding:b"}},
+		}},
+		{"several ends of one reading come out sorted", []episodeStep{
+			{at: 0, speed: 120, matched: []string{"speeding", "high_rpm"}, events: []string{"start speeding", "start high_rpm"}},
+			{at: 30_000, speed: 60, events: []string{"end high_rpm", "end speeding"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open := make(map[string]*models.ViolationEpisode)
+			for i, step := range tt.steps {
+				data := &models.TelemetryData{Speed: step.speed}
+				events := trackEpisodes("car-1", open, matchedViolations(step.matched), data, step.at)
+				got := make([]string, len(events))
+				for j, e := range events {
+					got[j] = e.Episode.Phase + " " + episodeKey(e)
+				}
+				if !equalStrings(got, step.events) {
+					t.Fatalf("step %d: events %v, want %v", i, got, step.events)
+				}
+			}
+		})
+	}
+}
+
+func TestTrackEpisodesSummary(t *testing.T) {
+	open := make(map[string]*models.ViolationEpisode)
+	speeding := func() []*models.Violation { return matchedViolations([]string{"speeding"}) }
+
+	start := trackEpisodes("car-1", open, speeding(), &models.TelemetryData{Speed: 120, RPM: 3000, Fuel: 50}, 1_000)
+	trackEpisodes("car-1", open, speeding(), &models.TelemetryData{Speed: 150, RPM: 4500, Fuel: 48}, 5_000)
+	trackEpisodes("car-1", open, speeding(), &models.TelemetryData{Speed: 130, RPM: 3500, Fuel: 47}, 9_000)
+	end := trackEpisodes("car-1", open, nil, &models.TelemetryData{Speed: 50, Fuel: 47}, 40_000)
+
+	if len(start) != 1 || len(end) != 1 {
+		t.Fatalf("expected one start and one end, got %d and %d", len(start), len(end))
+	}
+	if start[0].Episode.ID != end[0].Episode.ID {
+		t.Fatal("start and end must carry the same episode id")
+	}
+	if start[0].Episode.Readings != 1 {
+		t.Fatalf("start must be a snapshot of the first reading, got %d readings", start[0].Episode.Readings)
+	}
+	got := end[0].Episode
+	if got.StartedAt != 1_000 || got.EndedAt != 9_000 {
+		t.Fatalf("episode lasted %d..%d, want 1000..9000", got.StartedAt, got.EndedAt)
+	}
+	if got.Readings != 3 || got.PeakSpeed != 150 || got.PeakRPM != 4500 || got.MinFuel != 47 {
+		t.Fatalf("unexpected summary %+v", got)
+	}
+	if end[0].CarID != "car-1" || end[0].Data.Speed != 50 {
+		t.Fatalf("end must carry the car and the reading that closed it, got %s %+v", end[0].CarID, end[0].Data)
+	}
+	if len(open) != 0 {
+		t.Fatalf("closed episode must be removed, %d left", len(open))
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiti/citydrive/pkg/violationrules"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+// FuelService follows the fuel level of a car over its readings and reports siphoning
+// while parked, refuels and consumption above the norm of the model. Its events are
+// published once each, they don't open episodes.
+type FuelService struct {
+	config *config.FuelConfig
+	log    *slog.Logger
+}
+
+func NewFuelService(cfg *config.FuelConfig, log *slog.Logger) *FuelService {
+	return &FuelService{config: cfg, log: log}
+}
+
+// Check compares the reading taken at at (unix ms) with prev, the last known state of the car.
+// The fuel tracker of state is updated in place.
+func (s *FuelService) Check(ctx context.Context, car *models.Car, carID string, prev, data *models.TelemetryData, state *models.ViolationState, at int64) []*models.Violation {
+	if prev == nil {
+		return nil
+	}
+	log := s.log.With(
+		"module", "fuel.service",
+		"function", "Check",
+		"car_id", carID,
+		"trace_id", ctx.Value("trace_id"),
+	)
+	if state.Fuel == nil {
+		state.Fuel = &models.FuelTrack{}
+	}
+	track := state.Fuel
+
+	var events []*models.Violation
+	for _, event := range []*models.Violation{
+		s.trackRefuel(car, carID, track, prev, data, at),
+		s.trackParked(car, carID, track, prev, data, at),
+		s.trackConsumption(car, carID, track, prev, data, at),
+	} {
+		if event != nil {
+			events = append(events, event)
+		}
+	}
+	if len(events) > 0 {
+		log.Info("fuel events detected", "count", len(events))
+	}
+	return events
+}
+
+// trackRefuel opens a refuel when the level rises on a standing car and reports it once
+// the level has not risen for RefuelSettle or the car drives off.
+func (s *FuelService) trackRefuel(car *models.Car, carID string, track *models.FuelTrack, prev, data *models.TelemetryData, at int64) *models.Violation {
+	standing := data.Speed == 0
+	rising := data.Fuel-prev.Fuel > s.config.Noise
+
+	refuel := track.Refuel
+	if refuel == nil {
+		if standing && rising {
+			track.Refuel = &models.RefuelTrack{
+				ID:         uuid.NewString(),
+				StartFuel:  prev.Fuel,
+				LastFuel:   data.Fuel,
+				StartedAt:  at,
+				LastRiseAt: at,
+				Lat:        data.Lat,
+				Lon:        data.Lon,
+				Odo:        data.Odo,
+			}
+		}
+		return nil
+	}
+	if standing && rising {
+		refuel.LastFuel = data.Fuel
+		refuel.LastRiseAt = at
+		return nil
+	}
+	if standing && time.Duration(at-refuel.LastRiseAt)*time.Millisecond < s.config.RefuelSettle {
+		return nil
+	}
+	track.Refuel = nil
+
+	added := refuel.LastFuel - refuel.StartFuel
+	if added < s.config.RefuelMinRise {
+		return nil
+	}
+	details := map[string]interface{}{
+		"refuel_id":   refuel.ID,
+		"started_at":  refuel.StartedAt,
+		"ended_at":    refuel.LastRiseAt,
+		"fuel_before": refuel.StartFuel,
+		"fuel_after":  refuel.LastFuel,
+		"lat":         refuel.Lat,
+		"lon":         refuel.Lon,
+		"odo":         refuel.Odo,
+	}
+	if liters := toLiters(car, added); liters > 0 {
+		details["volume_liters"] = round2(liters)
+	}
+	return fuelEvent(models.ViolationTypeRefuel, violationrules.SeverityLow, carID, data, details)
+}
+
+// trackParked reports a drop of the level by TheftMinDrop from the highest level seen since
+// the car was parked with the engine off.
+func (s *FuelService) trackParked(car *models.Car, carID string, track *models.FuelTrack, prev, data *models.TelemetryData, at int64) *models.Violation {
+	parked := !prev.EngineOn && prev.Speed == 0 && !data.EngineOn && data.Speed == 0
+	if !parked {
+		track.ParkedAt, track.ParkedFuel = 0, 0
+		return nil
+	}
+	if track.ParkedAt == 0 {
+		track.ParkedAt, track.ParkedFuel = at, prev.Fuel
+	}
+	// refueled with the engine off
+	track.ParkedFuel = max(track.ParkedFuel, data.Fuel)
+
+	drop := track.ParkedFuel - data.Fuel
+	if drop < s.config.TheftMinDrop {
+		return nil
+	}
+	details := map[string]interface{}{
+		"fuel_before":  track.ParkedFuel,
+		"fuel_after":   data.Fuel,
+		"drop_percent": round2(drop),
+		"parked_since": track.ParkedAt,
+	}
+	if liters := toLiters(car, drop); liters > 0 {
+		details["volume_liters"] = round2(liters)
+	}
+	// a siphoning that goes on is reported again after another TheftMinDrop
+	track.ParkedFuel = data.Fuel
+	return fuelEvent(models.ViolationTypeFuelTheft, violationrules.SeverityCritical, carID, data, details)
+}
+
+// trackConsumption sums the fuel burnt while driving and compares it with the norm of the
+// model every ConsumptionWindow km by the odometer.
+func (s *FuelService) trackConsumption(car *models.Car, carID string, track *models.FuelTrack, prev, data *models.TelemetryData, at int64) *models.Violation {
+	if track.WindowStartedAt == 0 || data.Odo < track.WindowOdo {
+		track.WindowOdo, track.WindowUsed, track.WindowStartedAt = prev.Odo, 0, at
+	}
+	driving := data.EngineOn || data.Speed > 0
+	if burnt := prev.Fuel - data.Fuel; driving && burnt > 0 && track.Refuel == nil {
+		track.WindowUsed += burnt
+	}
+
+	distance := data.Odo - track.WindowOdo
+	if distance <= 0 || float64(distance) < s.config.ConsumptionWindow {
+		return nil
+	}
+	used, since := track.WindowUsed, track.WindowStartedAt
+	track.WindowOdo, track.WindowUsed, track.WindowStartedAt = data.Odo, 0, at
+
+	liters := toLiters(car, used)
+	if liters <= 0 || car.ConsumptionNorm <= 0 {
+		return nil
+	}
+	consumption := liters / float64(distance) * 100
+	if consumption <= car.ConsumptionNorm*s.config.ConsumptionFactor {
+		return nil
+	}
+	return fuelEvent(models.ViolationTypeFuelOverconsumption, violationrules.SeverityMedium, carID, data, map[string]interface{}{
+		"distance_km":         distance,
+		"fuel_used_l":         round2(liters),
+		"consumption_l_100km": round2(consumption),
+		"norm_l_100km":        car.ConsumptionNorm,
+		"since":               since,
+	})
+}
+
+// toLiters converts percent of the tank, it is 0 when the model has no fuel norm.
+func toLiters(car *models.Car, percent float64) float64 {
+	if car == nil {
+		return 0
+	}
+	return percent / 100 * car.TankLiters
+}
+
+func fuelEvent(eventType, severity, carID string, data *models.TelemetryData, details map[string]interface{}) *models.Violation {
+	return &models.Violation{
+		Type:     eventType,
+		CarID:    carID,
+		Severity: severity,
+		Data:     *data,
+		Details:  details,
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+type fuelStep struct {
+	sec    int64 // seconds since the first reading
+	fuel   float64
+	speed  int32
+	engine bool
+	odo    int64
+	events []string // types of the events expected on this reading
+}
+
+func newFuelService() *FuelService {
+	return NewFuelService(&config.FuelConfig{
+		Noise:             0.5,
+		TheftMinDrop:      5,
+		RefuelMinRise:     5,
+		RefuelSettle:      3 * time.Minute,
+		ConsumptionWindow: 100,
+		ConsumptionFactor: 1.5,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// runFuel feeds the steps to Check one after another and returns the events of the last one.
+func runFuel(t *testing.T, car *models.Car, steps []fuelStep) []*models.Violation {
+	t.Helper()
+	s := newFuelService()
+	state := models.NewViolationState()
+	const start = int64(1_700_000_000_000)
+
+	var prev *models.TelemetryData
+	var events []*models.Violation
+	for i, step := range steps {
+		at := start + step.sec*1000
+		data := &models.TelemetryData{Fuel: step.fuel, Speed: step.speed, EngineOn: step.engine, Odo: step.odo, RecordedAt: at}
+		events = s.Check(context.Background(), car, "car-1", prev, data, state, at)
+		got := make([]string, len(events))
+		for j, e := range events {
+			got[j] = e.Type
+		}
+		if !equalStrings(got, step.events) {
+			t.Fatalf("reading %d: events %v, want %v", i, got, step.events)
+		}
+		prev = data
+	}
+	return events
+}
+
+func TestFuelCheck(t *testing.T) {
+	car := &models.Car{TankLiters: 50, ConsumptionNorm: 8}
+	refuel := []string{models.ViolationTypeRefuel}
+	theft := []string{models.ViolationTypeFuelTheft}
+	over := []string{models.ViolationTypeFuelOverconsumption}
+
+	tests := []struct {
+		name  string
+		car   *models.Car
+		steps []fuelStep
+	}{
+		{"refuel settles", car, []fuelStep{
+			{sec: 0, fuel: 20, odo: 1000},
+			{sec: 60, fuel: 40, odo: 1000},
+			{sec: 120, fuel: 60, odo: 1000},
+			{sec: 180, fuel: 60, odo: 1000},
+			{sec: 299, fuel: 60, odo: 1000},
+			{sec: 300, fuel: 60, odo: 1000, events: refuel},
+			{sec: 360, fuel: 60, odo: 1000},
+		}},
+		{"drive-off ends the refuel", car, []fuelStep{
+			{sec: 0, fuel: 20, odo: 1000},
+			{sec: 60, fuel: 50, engine: true, odo: 1000},
+			{sec: 90, fuel: 50, speed: 10, engine: true, odo: 1000, events: refuel},
+		}},
+		{"rise below the minimum is no refuel", car, []fuelStep{
+			{sec: 0, fuel: 20, odo: 1000},
+			{sec: 60, fuel: 23, odo: 1000},
+			{sec: 90, fuel: 23, speed: 10, engine: true, odo: 1000},
+		}},
+		{"rise while moving is no refuel", car, []fuelStep{
+			{sec: 0, fuel: 20, speed: 40, engine: true, odo: 1000},
+			{sec: 60, fuel: 50, speed: 40, engine: true, odo: 1001},
+			{sec: 120, fuel: 50, speed: 40, engine: true, odo: 1002},
+		}},
+		{"siphoning while parked", car, []fuelStep{
+			{sec: 0, fuel: 60, odo: 1000},
+			{sec: 60, fuel: 58, odo: 1000},
+			{sec: 120, fuel: 54, odo: 1000, events: theft},
+			{sec: 180, fuel: 52, odo: 1000},
+			{sec: 240, fuel: 49, odo: 1000, events: theft},
+		}},
+		{"parked level is the highest since parking", car, []fuelStep{
+			{sec: 0, fuel: 50, odo: 1000},
+			{sec: 60, fuel: 57, odo: 1000},
+			{sec: 120, fuel: 51, odo: 1000, events: theft},
+		}},
+		{"drop with the engine on is no theft", car, []fuelStep{
+			{sec: 0, fuel: 60, engine: true, odo: 1000},
+			{sec: 60, fuel: 58, engine: true, odo: 1000},
+			{sec: 120, fuel: 54, engine: true, odo: 1000},
+		}},
+		{"sensor noise while parked", car, []fuelStep{
+			{sec: 0, fuel: 60, odo: 1000},
+			{sec: 60, fuel: 59.6, odo: 1000},
+			{sec: 120, fuel: 60, odo: 1000},
+			{sec: 180, fuel: 59.5, odo: 1000},
+		}},
+		{"consumption above the norm", car, []fuelStep{
+			{sec: 0, fuel: 100, speed: 60, engine: true, odo: 1000},
+			{sec: 1500, fuel: 90, speed: 60, engine: true, odo: 1025},
+			{sec: 3000, fuel: 80, speed: 60, engine: true, odo: 1050},
+			{sec: 4500, fuel: 70, speed: 60, engine: true, odo: 1075},
+			{sec: 6000, fuel: 60, speed: 60, engine: true, odo: 1100, events: over},
+		}},
+		{"consumption within the norm", car, []fuelStep{
+			{sec: 0, fuel: 100, speed: 60, engine: true, odo: 1000},
+			{sec: 3000, fuel: 96, speed: 60, engine: true, odo: 1050},
+			{sec: 6000, fuel: 92, speed: 60, engine: true, odo: 1100},
+		}},
+		{"model without a norm", &models.Car{}, []fuelStep{
+			{sec: 0, fuel: 100, speed: 60, engine: true, odo: 1000},
+			{sec: 6000, fuel: 50, speed: 60, engine: true, odo: 1100},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runFuel(t, tt.car, tt.steps)
+		})
+	}
+}
+
+func TestFuelRefuelDetails(t *testing.T) {
+	events := runFuel(t, &models.Car{TankLiters: 50}, []fuelStep{
+		{sec: 0, fuel: 20, odo: 1000},
+		{sec: 60, fuel: 40, odo: 1000},
+		{sec: 120, fuel: 60, odo: 1000},
+		{sec: 130, fuel: 60, speed: 5, engine: true, odo: 1000, events: []string{models.ViolationTypeRefuel}},
+	})
+	details := events[0].Details
+	if details["fuel_before"] != 20.0 || details["fuel_after"] != 60.0 || details["volume_liters"] != 20.0 {
+		t.Fatalf("unexpected refuel details %v", details)
+	}
+	if details["ended_at"].(int64)-details["started_at"].(int64) != 60_000 {
+		t.Fatalf("refuel must last from the first to the last rise, got %v", details)
+	}
+	if details["refuel_id"] == "" {
+		t.Fatal("refuel must carry an id")
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+	// the zone timezone must load on hosts without a zoneinfo database
+	_ "time/tzdata"
+
+	"github.com/jekiti/citydrive/pkg/violationrules"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/geo"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	"github.com/jekiti/citydrive/telemetry/internal/repository"
+)
+
+// GeozoneService checks readings against the zones from citydrive.geozones.
+// The index is rebuilt when the table changes, without a restart.
+type GeozoneService struct {
+	index    atomic.Pointer[geo.Index]
+	repo     *repository.GeozoneRepository
+	config   *config.GeozonesConfig
+	location *time.Location
+	log      *slog.Logger
+}
+
+func NewGeozoneService(repo *repository.GeozoneRepository,
+	cfg *config.GeozonesConfig,
+	log *slog.Logger) *GeozoneService {
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		// checked by config.Validate
+		location = time.UTC
+	}
+	s := &GeozoneService{repo: repo, config: cfg, location: location, log: log}
+	s.index.Store(geo.NewIndex(nil))
+	return s
+}
+
+// Load rebuilds the index from the enabled zones.
+func (s *GeozoneService) Load(ctx context.Context) error {
+	log := s.log.With("module", "geozone.service", "function", "Load")
+	zones, err := s.repo.ListEnabledGeozones(ctx)
+	if err != nil {
+		log.Error("error loading geozones", "error", err)
+		return err
+	}
+	index := geo.NewIndex(zones)
+	s.index.Store(index)
+	log.Info("geozones loaded", "count", index.Len(), "operating_areas", index.HasOperatingArea())
+	return nil
+}
+
+// Watch rebuilds the index on every change of the table until ctx is done.
+func (s *GeozoneService) Watch(ctx context.Context) {
+	log := s.log.With("module", "geozone.service", "function", "Watch")
+	watchTable(ctx, log, s.config.ReloadInterval, s.repo.ListenGeozoneChanges, func() { s.Load(ctx) })
+}
+
+// CheckZones returns the zone violations of the reading taken at at (unix ms). A violation
+// of a zone has the zone id as its subject, so a car has an episode per zone.
+func (s *GeozoneService) CheckZones(ctx context.Context, carID string, data *models.TelemetryData, at int64) []*models.Violation {
+	if !hasFix(data) {
+		return nil
+	}
+	log := s.log.With(
+		"module", "geozone.service",
+		"function", "CheckZones",
+		"car_id", carID,
+		"trace_id", ctx.Value("trace_id"),
+	)
+	index := s.index.Load()
+	zones := index.Lookup(data.Lat, data.Lon)
+	minute := minuteOfDay(time.UnixMilli(at).In(s.location))
+
+	var violations []*models.Violation
+	var speedZone *models.Geozone
+	inOperatingArea := false
+	for _, zone := range zones {
+		if zone.OperatingArea {
+			inOperatingArea = true
+		}
+		if zone.SpeedLimit > 0 && (speedZone == nil || zone.SpeedLimit < speedZone.SpeedLimit) {
+			speedZone = zone
+		}
+
+		reason := ""
+		switch {
+		case zone.NoEntry:
+			reason = "no_entry"
+		case !allowedAt(zone, minute):
+			reason = "outside_allowed_hours"
+		}
+		if reason != "" {
+			violations = append(violations, s.violation(models.ViolationTypeRestrictedZone, violationrules.SeverityHigh, carID, zone, data,
+				map[string]interface{}{"zone_id": zone.ID, "zone_name": zone.Name, "reason": reason}))
+		}
+	}
+	// with overlapping zones the strictest limit applies
+	if speedZone != nil && data.Speed > speedZone.SpeedLimit {
+		violations = append(violations, s.violation(models.ViolationTypeZoneSpeeding, violationrules.SeverityMedium, carID, speedZone, data,
+			map[string]interface{}{"zone_id": speedZone.ID, "zone_name": speedZone.Name, "speed_limit": speedZone.SpeedLimit, "speed": data.Speed}))
+	}
+	if index.HasOperatingArea() && !inOperatingArea {
+		violations = append(violations, &models.Violation{
+			Type:     models.ViolationTypeOperatingAreaExit,
+			CarID:    carID,
+			Severity: violationrules.SeverityHigh,
+			Data:     *data,
+			Cooldown: s.config.ViolationCooldown,
+		})
+	}
+	if len(violations) > 0 {
+		log.Info("zone violations detected", "count", len(violations))
+	}
+	return violations
+}
+
+func (s *GeozoneService) violation(violationType, severity, carID string, zone *models.Geozone,
+	data *models.TelemetryData, details map[string]interface{}) *models.Violation {
+	return &models.Violation{
+		Type:     violationType,
+		CarID:    carID,
+		Subject:  zone.ID,
+		Severity: severity,
+		Data:     *data,
+		Details:  details,
+		Cooldown: s.config.ViolationCooldown,
+	}
+}
+
+// allowedAt tells whether the zone may be entered at minute, a window like 22:00-06:00
+// goes past midnight.
+func allowedAt(zone *models.Geozone, minute int) bool {
+	from, to := zone.AllowedFrom, zone.AllowedTo
+	switch {
+	case from == to:
+		return true
+	case from < to:
+		return minute >= from && minute < to
+	default:
+		return minute >= from || minute < to
+	}
+}
+
+func minuteOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+func TestAllowedAt(t *testing.T) {
+	const (
+		h06 = 6 * 60
+		h09 = 9 * 60
+		h18 = 18 * 60
+		h22 = 22 * 60
+	)
+	tests := []struct {
+		name     string
+		from, to int
+		minute   int
+		allowed  bool
+	}{
+		{"no window", 0, 0, 3 * 60, true},
+		{"equal bounds mean any time", h09, h09, 23 * 60, true},
+		{"inside a day window", h09, h18, 12 * 60, true},
+		{"start of a day window", h09, h18, h09, true},
+		{"end of a day window is excluded", h09, h18, h18, false},
+		{"before a day window", h09, h18, 8*60 + 59, false},
+		{"night window before midnight", h22, h06, 23*60 + 30, true},
+		{"night window at midnight", h22, h06, 0, true},
+		{"night window after midnight", h22, h06, 5*60 + 59, true},
+		{"start of a night window", h22, h06, h22, true},
+		{"end of a night window is excluded", h22, h06, h06, false},
+		{"day time outside a night window", h22, h06, 12 * 60, false},
+		{"just before a night window", h22, h06, h22 - 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone := &models.Geozone{AllowedFrom: tt.from, AllowedTo: tt.to}
+			if got := allowedAt(zone, tt.minute); got != tt.allowed {
+				t.Fatalf("allowedAt(%d..%d, %d) = %v, want %v", tt.from, tt.to, tt.minute, got, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestMinuteOfDay(t *testing.T) {
+	at := time.Date(2026, 3, 1, 23, 59, 59, 0, time.FixedZone("MSK", 3*60*60))
+	if got := minuteOfDay(at); got != 23*60+59 {
+		t.Fatalf("minuteOfDay = %d, want %d", got, 23*60+59)
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"math"
+
+	"github.com/jekiti/citydrive/pkg/violationrules"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/geo"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+// KinematicsService checks that consecutive readings of a car are physically possible:
+// the GPS fix can't move faster than the car drives, and the odometer has to follow the
+// distance between fixes.
+type KinematicsService struct {
+	config *config.KinematicsConfig
+	log    *slog.Logger
+}
+
+func NewKinematicsService(cfg *config.KinematicsConfig, log *slog.Logger) *KinematicsService {
+	return &KinematicsService{config: cfg, log: log}
+}
+
+// Check compares the reading taken at at (unix ms) with prev, the last known state of the car.
+// The odometer tracker of state is updated in place.
+func (s *KinematicsService) Check(ctx context.Context, carID string, prev, data *models.TelemetryData, state *models.ViolationState, at int64) []*models.Violation {
+	if prev == nil {
+		return nil
+	}
+	log := s.log.With(
+		"module", "kinematics.service",
+		"function", "Check",
+		"car_id", carID,
+		"trace_id", ctx.Value("trace_id"),
+	)
+
+	var violations []*models.Violation
+	hasFix := hasFix(prev) && hasFix(data)
+	var distance float64
+	if hasFix {
+		distance = geo.Distance(models.Point{Lat: prev.Lat, Lon: prev.Lon}, models.Point{Lat: data.Lat, Lon: data.Lon})
+	}
+	// the time between readings is only known when both device clocks can be trusted
+	var hours float64
+	timed := prev.RecordedAt > 0 && !prev.ClockSkew && data.RecordedAt > 0 && !data.ClockSkew && at > prev.RecordedAt
+	if timed {
+		hours = float64(at-prev.RecordedAt) / float64(3600*1000)
+	}
+	maxSpeed := float64(max(prev.Speed, data.Speed))*s.config.SpeedTolerance + s.config.SpeedMargin
+
+	teleport := false
+	if hasFix && timed && distance >= s.config.GPSMinDistance {
+		if implied := distance / hours; implied > maxSpeed {
+			teleport = true
+			violations = append(violations, s.violation(models.ViolationTypeGPSSpoofing, carID, data, map[string]interface{}{
+				"distance_km":    round2(distance),
+				"elapsed_s":      (at - prev.RecordedAt) / 1000,
+				"implied_speed":  round2(implied),
+				"reported_speed": max(prev.Speed, data.Speed),
+				"prev_lat":       prev.Lat,
+				"prev_lon":       prev.Lon,
+			}))
+		}
+	}
+
+	odoDelta := data.Odo - prev.Odo
+	tampered := true
+	switch {
+	case odoDelta < 0:
+		violations = append(violations, s.violation(models.ViolationTypeOdometerTampering, carID, data, map[string]interface{}{
+			"reason":   "rollback",
+			"prev_odo": prev.Odo,
+			"odo":      data.Odo,
+		}))
+	case timed && float64(odoDelta) > s.maxOdoDelta(maxSpeed*hours, distance, teleport):
+		violations = append(violations, s.violation(models.ViolationTypeOdometerTampering, carID, data, map[string]interface{}{
+			"reason":      "jump",
+			"prev_odo":    prev.Odo,
+			"odo":         data.Odo,
+			"distance_km": round2(distance),
+			"elapsed_s":   (at - prev.RecordedAt) / 1000,
+		}))
+	default:
+		tampered = false
+	}
+
+	// a missing fix, a teleport or a tampered reading breaks the comparison, it starts over
+	if !hasFix || teleport || tampered {
+		state.Odometer = nil
+	} else if drift := s.trackOdometer(state, prev, data, distance, at); drift != nil {
+		violations = append(violations, s.violation(models.ViolationTypeOdometerTampering, carID, data, drift))
+	}
+	if len(violations) > 0 {
+		log.Info("kinematic violations detected", "count", len(violations))
+	}
+	return violations
+}
+
+// maxOdoDelta is how far the odometer may go between readings: no farther than the car
+// could drive in the time, unless the fixes are even farther apart.
+func (s *KinematicsService) maxOdoDelta(drivable, distance float64, teleport bool) float64 {
+	limit := drivable
+	if !teleport {
+		limit = math.Max(limit, distance*s.config.OdoDriftMaxRatio)
+	}
+	return limit + s.config.OdoJumpMargin
+}
+
+// trackOdometer adds the distance between fixes to the tracker and, once it covers
+// OdoDriftWindow, returns the details of a drift if the odometer didn't follow it.
+func (s *KinematicsService) trackOdometer(state *models.ViolationState, prev, data *models.TelemetryData, distance float64, at int64) map[string]interface{} {
+	track := state.Odometer
+	if track == nil {
+		track = &models.OdometerTrack{StartOdo: prev.Odo, StartedAt: at}
+		state.Odometer = track
+	}
+	track.Distance += distance
+	if track.Distance < s.config.OdoDriftWindow {
+		return nil
+	}
+	state.Odometer = nil
+
+	ratio := float64(data.Odo-track.StartOdo) / track.Distance
+	if ratio >= s.config.OdoDriftMinRatio && ratio <= s.config.OdoDriftMaxRatio {
+		return nil
+	}
+	return map[string]interface{}{
+		"reason":          "drift",
+		"start_odo":       track.StartOdo,
+		"odo":             data.Odo,
+		"gps_distance_km": round2(track.Distance),
+		"ratio":           round2(ratio),
+		"since":           track.StartedAt,
+	}
+}
+
+func (s *KinematicsService) violation(violationType, carID string, data *models.TelemetryData, details map[string]interface{}) *models.Violation {
+	return &models.Violation{
+		Type:     violationType,
+		CarID:    carID,
+		Severity: violationrules.SeverityHigh,
+		Data:     *data,
+		Details:  details,
+		Cooldown: s.config.ViolationCooldown,
+	}
+}
+
+// hasFix tells whether the reading has a position, 0,0 is sent before the first fix.
+func hasFix(data *models.TelemetryData) bool {
+	return data.Lat != 0 || data.Lon != 0
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+// kmLat is about one km of latitude in degrees.
+const kmLat = 1 / 111.19
+
+func newKinematicsService() *KinematicsService {
+	return NewKinematicsService(&config.KinematicsConfig{
+		GPSMinDistance:    0.5,
+		SpeedTolerance:    1.5,
+		SpeedMargin:       30,
+		OdoJumpMargin:     2,
+		OdoDriftWindow:    20,
+		OdoDriftMinRatio:  0.8,
+		OdoDriftMaxRatio:  1.6,
+		ViolationCooldown: 5 * time.Minute,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// violationKinds is the type of every violation, with the reason for odometer tampering.
+func violationKinds(violations []*models.Violation) []string {
+	kinds := make([]string, len(violations))
+	for i, v := range violations {
+		kinds[i] = v.Type
+		if reason, ok := v.Details["reason"].(string); ok {
+			kinds[i] += ":" + reason
+		}
+	}
+	return kinds
+}
+
+func TestKinematicsCheck(t *testing.T) {
+	const start = int64(1_700_000_000_000)
+	prev := models.TelemetryData{Lat: 55.75, Lon: 37.62, Odo: 1000, Speed: 60, RecordedAt: start}
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		km      float64 // northward move of the fix
+		odo     int64   // odometer delta
+		modify  func(prev, data *models.TelemetryData)
+		kinds   []string
+	}{
+		{"normal drive", time.Minute, 1, 1, nil, []string{}},
+		{"gps jitter below the minimum distance", time.Second, 0.4, 0, nil, []string{}},
+		{"teleport with a still odometer", time.Minute, 100, 1, nil, []string{"gps_spoofing"}},
+		{"teleport the odometer follows", time.Minute, 100, 100, nil, []string{"gps_spoofing", "odometer_tampering:jump"}},
+		{"odometer jump", time.Minute, 1, 50, nil, []string{"odometer_tampering:jump"}},
+		{"long gap covers the distance", 2 * time.Hour, 100, 100, nil, []string{}},
+		{"odometer rollback", time.Minute, 1, -5, nil, []string{"odometer_tampering:rollback"}},
+		{"rollback without device time", time.Minute, 1, -5, func(prev, data *models.TelemetryData) {
+			data.RecordedAt = 0
+		}, []string{"odometer_tampering:rollback"}},
+		{"no device time, no teleport or jump", time.Minute, 100, 100, func(prev, data *models.TelemetryData) {
+			data.RecordedAt = 0
+		}, []string{}},
+		{"skewed clock, no teleport or jump", time.Minute, 100, 100, func(prev, data *models.TelemetryData) {
+			data.ClockSkew = true
+		}, []string{}},
+		{"no fix before", time.Minute, 100, 1, func(prev, data *models.TelemetryData) {
+			prev.Lat, prev.Lon = 0, 0
+		}, []string{}},
+		{"fast car is no teleport", time.Minute, 3, 3, func(prev, data *models.TelemetryData) {
+			prev.Speed, data.Speed = 170, 180
+		}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := prev
+			data := prev
+			data.Lat += tt.km * kmLat
+			data.Odo += tt.odo
+			data.RecordedAt += tt.elapsed.Milliseconds()
+			if tt.modify != nil {
+				tt.modify(&p, &data)
+			}
+			at := start + tt.elapsed.Milliseconds()
+
+			violations := newKinematicsService().Check(context.Background(), "car-1", &p, &data, models.NewViolationState(), at)
+			if got := violationKinds(violations); !equalStrings(got, tt.kinds) {
+				t.Fatalf("violations %v, want %v", got, tt.kinds)
+			}
+		})
+	}
+
+	if got := newKinematicsService().Check(context.Background(), "car-1", nil, &prev, models.NewViolationState(), start); got != nil {
+		t.Fatalf("first reading of a car must pass, got %v", violationKinds(got))
+	}
+}
+
+func TestKinematicsDrift(t *testing.T) {
+	tests := []struct {
+		name     string
+		odoPerKm int64 // odometer delta for every km driven
+		step     int   // reading the drift has to be reported on, 0 — never
+		breakAt  int   // reading that teleports and resets the tracker, 0 — none
+	}{
+		{"odometer follows the fixes", 1, 0, 0},
+		{"odometer stands still", 0, 20, 0},
+		{"teleport resets the window", 0, 0, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newKinematicsService()
+			state := models.NewViolationState()
+			at := int64(1_700_000_000_000)
+			prev := &models.TelemetryData{Lat: 55.75, Lon: 37.62, Odo: 1000, Speed: 60, RecordedAt: at}
+			// readings a minute and just over a km apart, the window is 20 km
+			for i := 1; i <= 25; i++ {
+				at += time.Minute.Milliseconds()
+				data := *prev
+				data.Lat += 1.001 * kmLat
+				data.Odo += tt.odoPerKm
+				data.RecordedAt = at
+				if i == tt.breakAt {
+					data.Lat += 100 * kmLat
+				}
+
+				kinds := violationKinds(s.Check(context.Background(), "car-1", prev, &data, state, at))
+				drift := false
+				for _, kind := range kinds {
+					drift = drift || kind == "odometer_tampering:drift"
+				}
+				if drift != (i == tt.step) {
+					t.Fatalf("reading %d: violations %v, drift expected only on reading %d", i, kinds, tt.step)
+				}
+				if i == tt.breakAt && state.Odometer != nil {
+					t.Fatal("teleport must reset the odometer tracker")
+				}
+				prev = &data
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	"github.com/jekiti/citydrive/telemetry/internal/producer"
+	"github.com/jekiti/citydrive/telemetry/internal/repository"
+)
+
+// OutboxRelay publishes events from the Redis outbox to Kafka and removes them once written.
+type OutboxRelay struct {
+	redis    *repository.RedisRepository
+	producer *producer.KafkaProducer
+	config   *config.OutboxConfig
+	consumer string
+	log      *slog.Logger
+}
+
+func NewOutboxRelay(redis *repository.RedisRepository,
+	producer *producer.KafkaProducer,
+	config *config.OutboxConfig,
+	log *slog.Logger) *OutboxRelay {
+	consumer, err := os.Hostname()
+	if err != nil || consumer == "" {
+		consumer = "telemetry"
+	}
+	return &OutboxRelay{
+		redis:    redis,
+		producer: producer,
+		config:   config,
+		consumer: consumer,
+		log:      log,
+	}
+}
+
+// Run relays events until ctx is done, failed batches are retried with exponential backoff.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	log := r.log.With("module", "outbox.relay", "function", "Run", "consumer", r.consumer)
+	log.Info("starting outbox relay")
+	backoff := r.config.MinBackoff
+	grouped := false
+	for ctx.Err() == nil {
+		var err error
+		if !grouped {
+			err = r.redis.CreateOutboxGroup(ctx)
+			grouped = err == nil
+		}
+		if err == nil {
+			err = r.relay(ctx)
+		}
+		if err == nil {
+			backoff = r.config.MinBackoff
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		// the stream may have been lost with its group, create it again before the next try
+		grouped = false
+		log.Error("error relaying outbox, retrying", "backoff", backoff, "error", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
+		backoff = min(backoff*2, r.config.MaxBackoff)
+	}
+	log.Info("outbox relay stopped")
+}
+
+// relay publishes one batch: the entries this consumer didn't ack first, then the ones
+// abandoned by other relays, then new ones.
+func (r *OutboxRelay) relay(ctx context.Context) error {
+	events, err := r.redis.ReadOutbox(ctx, r.consumer, "0")
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		events, err = r.redis.ClaimOutbox(ctx, r.consumer)
+		if err != nil {
+			return err
+		}
+	}
+	if len(events) == 0 {
+		events, err = r.redis.ReadOutbox(ctx, r.consumer, ">")
+		if err != nil {
+			return err
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return r.publish(ctx, events)
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, events []models.OutboxEvent) error {
+	err := r.producer.Publish(ctx, events)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	// a failed ack only means the events are published once more
+	return r.redis.AckOutbox(ctx, ids)
+}
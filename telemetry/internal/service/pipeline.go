@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+type ingestJob struct {
+	ctx   context.Context
+	carID string
+	data  *models.TelemetryData
+	done  chan error
+}
+
+// IngestPipeline runs ProcessTelemetry on a fixed pool of workers. Every car is pinned
+// to one worker, so readings of a car are processed in the order they arrived.
+type IngestPipeline struct {
+	telemetryService *TelemetryService
+	shards           []chan ingestJob
+	config           *config.ProcessingConfig
+	log              *slog.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func NewIngestPipeline(telemetryService *TelemetryService, cfg *config.ProcessingConfig, log *slog.Logger) *IngestPipeline {
+	workers := max(cfg.WorkerPoolSize, 1)
+	// QueueSize bounds the whole pipeline, each worker gets its share
+	shardSize := max(cfg.QueueSize/workers, 1)
+
+	p := &IngestPipeline{
+		telemetryService: telemetryService,
+		shards:           make([]chan ingestJob, workers),
+		config:           cfg,
+		log:              log,
+	}
+	for i := range p.shards {
+		p.shards[i] = make(chan ingestJob, shardSize)
+		p.wg.Add(1)
+		go p.work(p.shards[i])
+	}
+	return p
+}
+
+// ProcessTelemetry queues the reading and waits for its result. It fails fast with
+// ErrQueueFull instead of blocking when the car's worker is behind.
+func (p *IngestPipeline) ProcessTelemetry(ctx context.Context, carID string, data *models.TelemetryData) error {
+	job := ingestJob{ctx: ctx, carID: carID, data: data, done: make(chan error, 1)}
+
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return models.ErrShuttingDown
+	}
+	select {
+	case p.shards[p.shard(carID)] <- job:
+		p.mu.RUnlock()
+	default:
+		p.mu.RUnlock()
+		p.log.Warn("ingest queue is full",
+			"module", "ingest.pipeline",
+			"function", "ProcessTelemetry",
+			"car_id", carID,
+			"trace_id", ctx.Value("trace_id"),
+		)
+		return models.ErrQueueFull
+	}
+
+	select {
+	case err := <-job.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops taking new readings and waits until the queued ones are processed.
+func (p *IngestPipeline) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	for _, shard := range p.shards {
+		close(shard)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	p.log.Info("ingest pipeline drained", "module", "ingest.pipeline", "function", "Close")
+}
+
+func (p *IngestPipeline) work(jobs <-chan ingestJob) {
+	defer p.wg.Done()
+	for job := range jobs {
+		// the caller is gone, nobody would see the result
+		if err := job.ctx.Err(); err != nil {
+			job.done <- err
+			continue
+		}
+		ctx, cancel := context.WithTimeout(job.ctx, p.config.MaxProcessingTime)
+		job.done <- p.telemetryService.ProcessTelemetry(ctx, job.carID, job.data)
+		cancel()
+	}
+}
+
+func (p *IngestPipeline) shard(carID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(carID))
+	return int(h.Sum32() % uint32(len(p.shards)))
+}
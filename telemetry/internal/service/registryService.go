@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+	"github.com/jekiti/citydrive/telemetry/internal/repository"
+)
+
+const registryRelistenDelay = 5 * time.Second
+
+// RegistryService answers whether a car may send telemetry, citydrive.cars is cached in Redis.
+type RegistryService struct {
+	redis  *repository.RedisRepository
+	cars   *repository.CarRepository
+	config *config.RegistryConfig
+	log    *slog.Logger
+}
+
+func NewRegistryService(redis *repository.RedisRepository,
+	cars *repository.CarRepository,
+	config *config.RegistryConfig,
+	log *slog.Logger) *RegistryService {
+	return &RegistryService{
+		redis:  redis,
+		cars:   cars,
+		config: config,
+		log:    log,
+	}
+}
+
+// Lookup returns the registered car or ErrCarNotFound / ErrCarDecommissioned.
+func (s *RegistryService) Lookup(ctx context.Context, carID string) (*models.Car, error) {
+	log := s.log.With(
+		"module", "registry.service",
+		"function", "Lookup",
+		"car_id", carID,
+		"trace_id", ctx.Value("trace_id"),
+	)
+	if _, err := uuid.Parse(carID); err != nil {
+		return nil, models.ErrCarNotFound
+	}
+
+	car, found, err := s.redis.GetRegisteredCar(ctx, carID)
+	if err != nil {
+		log.Error("error getting car from cache", "error", err)
+		return nil, err
+	}
+	if !found {
+		car, err = s.cars.GetCar(ctx, carID)
+		if err != nil {
+			return nil, err
+		}
+		ttl := s.config.CacheTTL
+		if car == nil {
+			ttl = s.config.NotFoundTTL
+		}
+		// a cache failure only costs an extra query next time
+		if err := s.redis.SetRegisteredCar(ctx, carID, car, ttl); err != nil {
+			log.Warn("error caching car", "error", err)
+		}
+	}
+
+	if car == nil {
+		return nil, models.ErrCarNotFound
+	}
+	if car.Decommissioned {
+		return nil, models.ErrCarDecommissioned
+	}
+	return car, nil
+}
+
+// Watch drops cached cars changed in Postgres until ctx is done.
+func (s *RegistryService) Watch(ctx context.Context) {
+	log := s.log.With("module", "registry.service", "function", "Watch")
+	for {
+		err := s.cars.ListenCarChanges(ctx, func(carID string) {
+			if err := s.redis.DeleteRegisteredCar(ctx, carID); err != nil {
+				log.Error("error invalidating cached car", "car_id", carID, "error", err)
+				return
+			}
+			log.Info("cached car invalidated", "car_id", carID)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error("car change listener stopped, restarting", "error", err)
+		select {
+		case <-time.After(registryRelistenDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// registryMismatches lists the fields where a reading disagrees with the registry.
+func registryMismatches(car *models.Car, data *models.TelemetryData) map[string]interface{} {
+	mismatches := map[string]interface{}{}
+	if !strings.EqualFold(car.Brand, data.Brand) {
+		mismatches["brand"] = map[string]string{"registry": car.Brand, "reported": data.Brand}
+	}
+	if !strings.EqualFold(car.Model, data.Model) {
+		mismatches["model"] = map[string]string{"registry": car.Model, "reported": data.Model}
+	}
+	if car.FuelType != data.FuelType {
+		mismatches["fuel_type"] = map[string]string{"registry": car.FuelType, "reported": data.FuelType}
+	}
+	return mismatches
+}
@@ -2,32 +2,54 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"math"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jekiti/citydrive/pkg/readingorder"
+	"github.com/jekiti/citydrive/pkg/violationrules"
 	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/events"
 	"github.com/jekiti/citydrive/telemetry/internal/models"
-	"github.com/jekiti/citydrive/telemetry/internal/producer"
 	"github.com/jekiti/citydrive/telemetry/internal/repository"
+	"github.com/jekiti/citydrive/telemetry/internal/validation"
 )
 
 type TelemetryService struct {
 	redis            *repository.RedisRepository
+	rules            *validation.Rules
+	registry         *RegistryService
 	violationService *ViolationService
-	producer         *producer.KafkaProducer
+	geozoneService   *GeozoneService
+	kinematics       *KinematicsService
+	fuel             *FuelService
+	encoder          *events.Encoder
 	config           *config.TelemetryConfig
 	log              *slog.Logger
 }
 
 func NewTelemetryService(redis *repository.RedisRepository,
+	rules *validation.Rules,
+	registry *RegistryService,
 	violationService *ViolationService,
-	producer *producer.KafkaProducer,
+	geozoneService *GeozoneService,
+	kinematics *KinematicsService,
+	fuel *FuelService,
+	encoder *events.Encoder,
 	config *config.TelemetryConfig,
 	log *slog.Logger) *TelemetryService {
 	return &TelemetryService{
 		redis:            redis,
+		rules:            rules,
+		registry:         registry,
 		violationService: violationService,
-		producer:         producer,
+		geozoneService:   geozoneService,
+		kinematics:       kinematics,
+		fuel:             fuel,
+		encoder:          encoder,
 		config:           config,
 		log:              log,
 	}
@@ -43,40 +65,226 @@ func (s *TelemetryService) ProcessTelemetry(ctx context.Context, carID string, d
 	)
 
 	log.Info("start processing telemetry")
-	err := s.producer.SendTelemetry(ctx, carID, data)
+	now := time.Now()
+	err := s.rules.Validate(data, now)
 	if err != nil {
-		log.Error("error sending telemetry", "error", err)
+		log.Warn("telemetry rejected", "error", err)
 		return err
 	}
+	car, err := s.registry.Lookup(ctx, carID)
+	if err != nil {
+		log.Warn("car rejected by registry", "error", err)
+		return err
+	}
+	err = s.checkClock(data, now)
+	if err != nil {
+		log.Warn("telemetry rejected", "recorded_at", data.RecordedAt, "error", err)
+		return err
+	}
+	if data.ClockSkew {
+		log.Warn("device clock skew detected", "recorded_at", data.RecordedAt)
+	}
+
 	log.Info("getting car state telemetry")
 	prev, err := s.redis.GetCarState(ctx, carID)
 	if err != nil {
 		log.Error("error getting car state", "error", err)
 		return err
 	}
-	log.Info("setting car state")
-	if hasDataChanged(prev, data) {
-		err := s.redis.SetCarState(ctx, carID, data)
+	older := isOlder(data, prev)
+	if !older {
+		err = s.rules.CheckPlausibility(prev, data)
 		if err != nil {
-			log.Error("error setting car state", "error", err)
+			log.Warn("implausible telemetry rejected", "error", err)
 			return err
 		}
+	}
 
-		violations := s.violationService.CheckViolations(ctx, carID, data)
-		log.Info("violations detected", "count", len(violations))
-		for _, violation := range violations {
-			err = s.producer.SendViolation(ctx, violation)
-			if err != nil {
-				log.Error("error sending violation", "error", err)
-				return err
-			}
+	claimKey := idempotencyKey(data)
+	if claimKey != "" {
+		claimed, err := s.redis.ClaimReading(ctx, carID, claimKey, s.config.Processing.DedupWindow)
+		if err != nil {
+			log.Error("error claiming reading", "error", err)
+			return err
+		}
+		if !claimed {
+			log.Info("duplicate telemetry ignored", "idempotency_key", claimKey)
+			return models.ErrDuplicateTelemetry
 		}
 	}
+
+	// the relay may deliver an event twice, processing drops repeats by this key forever,
+	// so a bare sequence, which restarts when the device reboots, is only kept for the window
+	data.IdempotencyKey = claimKey
+	if claimKey == "" || (data.RecordedAt == 0 && claimKey == sequenceKey(data)) {
+		data.IdempotencyKey = "ingest:" + uuid.NewString()
+	}
+
+	// episodes follow readings in order, a delayed reading doesn't take part in them
+	var violations *models.ViolationState
+	if !older {
+		violations, err = s.redis.GetViolationState(ctx, carID)
+		if err != nil {
+			log.Error("error getting violation state", "error", err)
+			s.releaseReading(ctx, carID, claimKey)
+			return err
+		}
+	}
+	events, err := s.buildEvents(ctx, car, carID, prev, data, violations, now)
+	if err != nil {
+		log.Error("error building events", "error", err)
+		s.releaseReading(ctx, carID, claimKey)
+		return err
+	}
+	var state *models.TelemetryData
+	if hasDataChanged(prev, data) {
+		// a reading buffered offline must not overwrite a newer state
+		if older {
+			log.Info("skipping state update for delayed reading", "recorded_at", data.RecordedAt)
+		} else {
+			state = data
+		}
+	}
+	log.Info("saving reading to outbox", "events", len(events))
+	err = s.redis.SaveReading(ctx, carID, state, violations, events)
+	if err != nil {
+		log.Error("error saving reading", "error", err)
+		// the reading was not stored, so a retry must not be taken for a duplicate
+		s.releaseReading(ctx, carID, claimKey)
+		return err
+	}
 	log.Info("processing telemetry successfully")
 	return nil
 
 }
 
+// buildEvents returns the telemetry event and the starts and ends of violation episodes,
+// violations is nil for a delayed reading and then no violations are checked.
+func (s *TelemetryService) buildEvents(ctx context.Context,
+	car *models.Car,
+	carID string,
+	prev, data *models.TelemetryData,
+	violations *models.ViolationState,
+	now time.Time) ([]models.OutboxEvent, error) {
+	log := s.log.With(
+		"module", "telemetry.service",
+		"function", "buildEvents",
+		"car_id", carID,
+		"trace_id", ctx.Value("trace_id"),
+	)
+	traceID, _ := ctx.Value("trace_id").(string)
+	producedAt := time.Now()
+	value, contentType, err := s.encoder.Telemetry(carID, traceID, data, producedAt)
+	if err != nil {
+		return nil, err
+	}
+	events := []models.OutboxEvent{{
+		Topic:       models.EventTopicTelemetry,
+		Key:         carID,
+		Value:       value,
+		ContentType: contentType,
+		TraceID:     traceID,
+	}}
+	if violations == nil {
+		return events, nil
+	}
+
+	matched := s.violationService.CheckViolations(ctx, carID, prev, data)
+	if mismatches := registryMismatches(car, data); len(mismatches) > 0 {
+		log.Warn("telemetry does not match car registry", "mismatches", mismatches)
+		matched = append(matched, &models.Violation{
+			Type:     models.ViolationTypeRegistryMismatch,
+			CarID:    carID,
+			Severity: violationrules.SeverityMedium,
+			Data:     *data,
+			Details:  mismatches,
+		})
+	}
+	at := readingTime(data, now)
+	matched = append(matched, s.geozoneService.CheckZones(ctx, carID, data, at)...)
+	matched = append(matched, s.kinematics.Check(ctx, carID, prev, data, violations, at)...)
+	published := trackEpisodes(carID, violations.Episodes, matched, data, at)
+	published = append(published, s.fuel.Check(ctx, car, carID, prev, data, violations, at)...)
+	log.Info("violations detected", "matched", len(matched), "published", len(published), "open_episodes", len(violations.Episodes))
+	for _, violation := range published {
+		value, contentType, err := s.encoder.Violation(traceID, violation, producedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, models.OutboxEvent{
+			Topic:       models.EventTopicViolations,
+			Key:         violation.Type,
+			Value:       value,
+			ContentType: contentType,
+			TraceID:     traceID,
+		})
+	}
+	return events, nil
+}
+
+func (s *TelemetryService) releaseReading(ctx context.Context, carID, key string) {
+	if key == "" {
+		return
+	}
+	err := s.redis.ReleaseReading(context.WithoutCancel(ctx), carID, key)
+	if err != nil {
+		s.log.Error("error releasing reading",
+			"module", "telemetry.service",
+			"function", "releaseReading",
+			"car_id", carID,
+			"trace_id", ctx.Value("trace_id"),
+			"error", err,
+		)
+	}
+}
+
+func (s *TelemetryService) checkClock(data *models.TelemetryData, now time.Time) error {
+	if data.RecordedAt == 0 {
+		return nil
+	}
+	recordedAt := time.UnixMilli(data.RecordedAt)
+	if recordedAt.Sub(now) <= s.config.Processing.ClockSkewTolerance &&
+		now.Sub(recordedAt) <= s.config.Processing.MaxReadingAge {
+		return nil
+	}
+	if s.config.Processing.ClockSkewAction == "reject" {
+		return fmt.Errorf("%w: recorded_at %s", models.ErrClockSkew, recordedAt.UTC().Format(time.RFC3339))
+	}
+	data.ClockSkew = true
+	return nil
+}
+
+// idempotencyKey prefers the key sent by the client and falls back to the device sequence.
+func idempotencyKey(data *models.TelemetryData) string {
+	if data.IdempotencyKey != "" {
+		return data.IdempotencyKey
+	}
+	return sequenceKey(data)
+}
+
+// sequenceKey includes the device time, since the sequence starts over after a reboot or
+// a firmware update and would repeat keys of older readings.
+func sequenceKey(data *models.TelemetryData) string {
+	if data.Sequence == 0 {
+		return ""
+	}
+	key := "seq:" + strconv.FormatUint(data.Sequence, 10)
+	if data.RecordedAt != 0 {
+		key += ":" + strconv.FormatInt(data.RecordedAt, 10)
+	}
+	return key
+}
+
+func isOlder(current, previous *models.TelemetryData) bool {
+	if previous == nil {
+		return false
+	}
+	return readingorder.Older(
+		readingorder.Reading{Sequence: current.Sequence, RecordedAt: current.RecordedAt, ClockSkew: current.ClockSkew},
+		readingorder.Reading{Sequence: previous.Sequence, RecordedAt: previous.RecordedAt, ClockSkew: previous.ClockSkew},
+	)
+}
+
 func hasDataChanged(previous, current *models.TelemetryData) bool {
 	if previous == nil {
 		return true
@@ -3,21 +3,70 @@ package service
 import (
 	"context"
 	"log/slog"
+	"sync/atomic"
+	"time"
 
+	"github.com/expr-lang/expr/vm"
+	"github.com/jekiti/citydrive/pkg/violationrules"
 	"github.com/jekiti/citydrive/telemetry/internal/config"
 	"github.com/jekiti/citydrive/telemetry/internal/models"
+	"github.com/jekiti/citydrive/telemetry/internal/repository"
 )
 
+type compiledRule struct {
+	rule    violationrules.Rule
+	program *vm.Program
+}
+
+// ViolationService checks readings against the rules from citydrive.violation_rules.
+// The rules are reloaded when the table changes, without a restart.
 type ViolationService struct {
+	rules  atomic.Pointer[[]compiledRule]
+	repo   *repository.RuleRepository
 	config *config.ViolationsConfig
 	log    *slog.Logger
 }
 
-func NewViolationService(cfg *config.ViolationsConfig, log *slog.Logger) *ViolationService {
-	return &ViolationService{config: cfg, log: log}
+func NewViolationService(repo *repository.RuleRepository,
+	cfg *config.ViolationsConfig,
+	log *slog.Logger) *ViolationService {
+	s := &ViolationService{repo: repo, config: cfg, log: log}
+	s.rules.Store(&[]compiledRule{})
+	return s
+}
+
+// Load replaces the rules in use, a rule whose condition doesn't compile is skipped.
+func (s *ViolationService) Load(ctx context.Context) error {
+	log := s.log.With("module", "violation.service", "function", "Load")
+	rules, err := s.repo.ListEnabledRules(ctx)
+	if err != nil {
+		log.Error("error loading violation rules", "error", err)
+		return err
+	}
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		program, err := violationrules.Compile(rule.Condition)
+		if err != nil {
+			log.Error("skipping invalid violation rule", "rule_id", rule.ID, "type", rule.Type, "error", err)
+			continue
+		}
+		compiled = append(compiled, compiledRule{rule: rule, program: program})
+	}
+	s.rules.Store(&compiled)
+	log.Info("violation rules loaded", "count", len(compiled))
+	return nil
 }
 
-func (s *ViolationService) CheckViolations(ctx context.Context, carID string, current *models.TelemetryData) []*models.Violation {
+// Watch reloads the rules on every change of the table until ctx is done. They are also
+// reloaded every ReloadInterval in case a notification was lost while reconnecting.
+func (s *ViolationService) Watch(ctx context.Context) {
+	log := s.log.With("module", "violation.service", "function", "Watch")
+	watchTable(ctx, log, s.config.ReloadInterval, s.repo.ListenRuleChanges, func() { s.Load(ctx) })
+}
+
+// CheckViolations returns the rules the reading violates, prev is the last known state
+// of the car or nil. Whether a violation is published is decided by its episode.
+func (s *ViolationService) CheckViolations(ctx context.Context, carID string, prev, current *models.TelemetryData) []*models.Violation {
 	traceID := ctx.Value("trace_id")
 	log := s.log.With(
 		"module", "violation.service",
@@ -26,57 +75,52 @@ func (s *ViolationService) CheckViolations(ctx context.Context, carID string, cu
 		"trace_id", traceID,
 	)
 	log.Info("checking violations")
-	violations := []*models.Violation{}
-	speedLimit := int32(s.config.SpeedLimit)
-	fuelLimit := s.config.LowFuelLimit
-	rpmLimit := int32(s.config.DriftRPMLimit)
-
-	if current.Speed > speedLimit {
-		violations = append(violations, createSpeedViolation(speedLimit, carID, current))
+	env := &violationrules.Env{Reading: toReading(current)}
+	if prev != nil {
+		reading := toReading(prev)
+		env.Prev = &reading
 	}
-	if current.RPM > rpmLimit && current.Handbrake {
-		violations = append(violations, &models.Violation{
-			Type:  models.ViolationTypeDrift,
-			CarID: carID,
-			Data:  *current,
-		})
-	}
-	if current.Fuel < fuelLimit {
-		violations = append(violations, &models.Violation{
-			Type:  models.ViolationTypeLowFuel,
-			CarID: carID,
-			Data:  *current,
-		})
-	}
-	if !current.Activated && !current.Locked && current.EngineOn && current.Speed != 0 {
+
+	violations := []*models.Violation{}
+	for _, r := range *s.rules.Load() {
+		matched, err := violationrules.Eval(r.program, env)
+		if err != nil {
+			log.Warn("violation rule failed", "rule_id", r.rule.ID, "type", r.rule.Type, "error", err)
+			continue
+		}
+		if !matched {
+			continue
+		}
 		violations = append(violations, &models.Violation{
-			Type:  models.ViolationTypeStealedAuto,
-			CarID: carID,
-			Data:  *current,
+			Type:     r.rule.Type,
+			CarID:    carID,
+			Severity: r.rule.Severity,
+			RuleID:   r.rule.ID,
+			Data:     *current,
+			Cooldown: time.Duration(r.rule.CooldownSeconds) * time.Second,
 		})
 	}
 	log.Info("violation checked")
 	return violations
 }
 
-func createSpeedViolation(speedLimit int32, carID string, current *models.TelemetryData) *models.Violation {
-	if current.Speed-speedLimit < 20 {
-		return &models.Violation{
-			Type:  models.ViolationTypeSpeedingLow,
-			CarID: carID,
-			Data:  *current,
-		}
-	}
-	if current.Speed-speedLimit > 20 && current.Speed-speedLimit <= 40 {
-		return &models.Violation{
-			Type:  models.ViolationTypeSpeedingMedium,
-			CarID: carID,
-			Data:  *current,
-		}
-	}
-	return &models.Violation{
-		Type:  models.ViolationTypeSpeedingHigh,
-		CarID: carID,
-		Data:  *current,
+func toReading(data *models.TelemetryData) violationrules.Reading {
+	return violationrules.Reading{
+		Brand:             data.Brand,
+		Model:             data.Model,
+		YearOfManufacture: data.YearOfManufacture,
+		Odo:               data.Odo,
+		Lat:               data.Lat,
+		Lon:               data.Lon,
+		Fuel:              data.Fuel,
+		FuelType:          data.FuelType,
+		Speed:             data.Speed,
+		EngineOn:          data.EngineOn,
+		Locked:            data.Locked,
+		Activated:         data.Activated,
+		RPM:               data.RPM,
+		Handbrake:         data.Handbrake,
+		RecordedAt:        data.RecordedAt,
+		Sequence:          data.Sequence,
 	}
 }
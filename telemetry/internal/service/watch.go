@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// watchTable calls reload on every notification from listen until ctx is done, and also
+// every interval in case a notification was lost while reconnecting.
+func watchTable(ctx context.Context, log *slog.Logger, interval time.Duration,
+	listen func(ctx context.Context, onChange func()) error,
+	reload func()) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				reload()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		err := listen(ctx, func() {
+			log.Info("table changed")
+			reload()
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Error("listener stopped, restarting", "error", err)
+		select {
+		case <-time.After(registryRelistenDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
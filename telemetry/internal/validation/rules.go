@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+const (
+	maxNameLength           = 100
+	minYearOfManufacture    = 1900
+	maxOdo                  = 1000000
+	maxSpeed                = 300
+	maxRPM                  = 10000
+	maxIdempotencyKeyLength = 128
+)
+
+var fuelTypes = map[string]bool{
+	"diesel": true,
+	"92":     true,
+	"95":     true,
+	"98":     true,
+}
+
+// Rules holds the checks every reading goes through, whatever transport it came from.
+type Rules struct {
+	maxFuelRise float64
+}
+
+func NewRules(cfg *config.ProcessingConfig) *Rules {
+	return &Rules{maxFuelRise: cfg.MaxFuelRise}
+}
+
+// Validate checks a single reading on its own.
+func (r *Rules) Validate(data *models.TelemetryData, now time.Time) error {
+	var v violations
+	if data.Brand == "" {
+		v.add("brand", "is required")
+	} else if len(data.Brand) > maxNameLength {
+		v.add("brand", "must be at most %d characters", maxNameLength)
+	}
+	if data.Model == "" {
+		v.add("model", "is required")
+	} else if len(data.Model) > maxNameLength {
+		v.add("model", "must be at most %d characters", maxNameLength)
+	}
+	if maxYear := int32(now.Year() + 1); data.YearOfManufacture < minYearOfManufacture || data.YearOfManufacture > maxYear {
+		v.add("year_of_manufacture", "must be between %d and %d", minYearOfManufacture, maxYear)
+	}
+	if data.Odo < 0 || data.Odo > maxOdo {
+		v.add("odo", "must be between 0 and %d km", maxOdo)
+	}
+	if data.Lat < -90 || data.Lat > 90 {
+		v.add("lat", "must be between -90 and 90")
+	}
+	if data.Lon < -180 || data.Lon > 180 {
+		v.add("lon", "must be between -180 and 180")
+	}
+	if data.Fuel < 0 || data.Fuel > 100 {
+		v.add("fuel", "must be between 0 and 100 percent")
+	}
+	if !fuelTypes[data.FuelType] {
+		v.add("fuel_type", "must be one of: diesel, 92, 95, 98")
+	}
+	if data.Speed < 0 || data.Speed > maxSpeed {
+		v.add("speed", "must be between 0 and %d km/h", maxSpeed)
+	}
+	if data.RPM < 0 || data.RPM > maxRPM {
+		v.add("rpm", "must be between 0 and %d", maxRPM)
+	}
+	if data.RecordedAt < 0 {
+		v.add("recorded_at", "must not be negative")
+	}
+	if len(data.IdempotencyKey) > maxIdempotencyKeyLength {
+		v.add("idempotency_key", "must be at most %d characters", maxIdempotencyKeyLength)
+	}
+	return v.err()
+}
+
+// CheckPlausibility compares a reading with the last known state of the car,
+// the caller makes sure prev is not newer than data.
+func (r *Rules) CheckPlausibility(prev, data *models.TelemetryData) error {
+	if prev == nil {
+		return nil
+	}
+	// an odometer going back is accepted and reported as odometer_tampering
+	var v violations
+	// a car can only be refueled standing still
+	if rise := data.Fuel - prev.Fuel; rise > r.maxFuelRise && data.Speed > 0 && prev.Speed > 0 {
+		v.add("fuel", "rose from %.1f to %.1f percent while moving", prev.Fuel, data.Fuel)
+	}
+	return v.err()
+}
+
+type violations []models.FieldViolation
+
+func (v *violations) add(field, format string, args ...any) {
+	*v = append(*v, models.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &models.ValidationError{Violations: v}
+}
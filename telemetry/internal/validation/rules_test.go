@@ -0,0 +1,128 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jekiti/citydrive/telemetry/internal/config"
+	"github.com/jekiti/citydrive/telemetry/internal/models"
+)
+
+func validReading() models.TelemetryData {
+	return models.TelemetryData{
+		Brand:             "Kia",
+		Model:             "Rio",
+		YearOfManufacture: 2020,
+		Odo:               42000,
+		Lat:               55.75,
+		Lon:               37.62,
+		Fuel:              60,
+		FuelType:          "95",
+		Speed:             40,
+		RPM:               2000,
+	}
+}
+
+// fields returns the fields named in a validation error, nil for no error.
+func fields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verr *models.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *models.ValidationError, got %v", err)
+	}
+	if !errors.Is(err, models.ErrInvalidTelemetry) {
+		t.Fatalf("expected error to wrap ErrInvalidTelemetry, got %v", err)
+	}
+	names := make([]string, len(verr.Violations))
+	for i, v := range verr.Violations {
+		names[i] = v.Field
+	}
+	return names
+}
+
+func equalFields(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	rules := NewRules(&config.ProcessingConfig{MaxFuelRise: 5})
+
+	tests := []struct {
+		name   string
+		modify func(d *models.TelemetryData)
+		fields []string
+	}{
+		{"valid reading", func(d *models.TelemetryData) {}, nil},
+		{"boundaries are allowed", func(d *models.TelemetryData) {
+			d.YearOfManufacture = 2027
+			d.Lat, d.Lon = -90, 180
+			d.Fuel, d.Speed, d.RPM, d.Odo = 100, maxSpeed, maxRPM, maxOdo
+		}, nil},
+		{"missing brand and model", func(d *models.TelemetryData) { d.Brand, d.Model = "", "" }, []string{"brand", "model"}},
+		{"year in the future", func(d *models.TelemetryData) { d.YearOfManufacture = 2028 }, []string{"year_of_manufacture"}},
+		{"negative odometer", func(d *models.TelemetryData) { d.Odo = -1 }, []string{"odo"}},
+		{"coordinates out of range", func(d *models.TelemetryData) { d.Lat, d.Lon = 91, -181 }, []string{"lat", "lon"}},
+		{"fuel above full", func(d *models.TelemetryData) { d.Fuel = 100.5 }, []string{"fuel"}},
+		{"unknown fuel type", func(d *models.TelemetryData) { d.FuelType = "100" }, []string{"fuel_type"}},
+		{"speed and rpm too high", func(d *models.TelemetryData) { d.Speed, d.RPM = maxSpeed+1, maxRPM+1 }, []string{"speed", "rpm"}},
+		{"negative recorded_at", func(d *models.TelemetryData) { d.RecordedAt = -1 }, []string{"recorded_at"}},
+		{"long idempotency key", func(d *models.TelemetryData) {
+			d.IdempotencyKey = string(make([]byte, maxIdempotencyKeyLength+1))
+		}, []string{"idempotency_key"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := validReading()
+			tt.modify(&data)
+			if got := fields(t, rules.Validate(&data, now)); !equalFields(got, tt.fields) {
+				t.Fatalf("violations on %v, want %v", got, tt.fields)
+			}
+		})
+	}
+}
+
+func TestCheckPlausibility(t *testing.T) {
+	rules := NewRules(&config.ProcessingConfig{MaxFuelRise: 5})
+
+	tests := []struct {
+		name             string
+		prevSpeed, speed int32
+		prevFuel, fuel   float64
+		fields           []string
+	}{
+		{"fuel rising while moving", 40, 40, 30, 60, []string{"fuel"}},
+		{"rise within the tolerance", 40, 40, 30, 35, nil},
+		{"refuel standing still", 0, 0, 30, 90, nil},
+		{"drive-off after a refuel", 0, 20, 30, 90, nil},
+		{"fuel dropping while moving", 40, 40, 60, 30, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := validReading()
+			prev.Speed, prev.Fuel = tt.prevSpeed, tt.prevFuel
+			data := validReading()
+			data.Speed, data.Fuel = tt.speed, tt.fuel
+			if got := fields(t, rules.CheckPlausibility(&prev, &data)); !equalFields(got, tt.fields) {
+				t.Fatalf("violations on %v, want %v", got, tt.fields)
+			}
+		})
+	}
+
+	data := validReading()
+	if err := rules.CheckPlausibility(nil, &data); err != nil {
+		t.Fatalf("first reading of a car must pass, got %v", err)
+	}
+}